@@ -0,0 +1,184 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+)
+
+// ColorTheme is the set of styles used by [ColorizeJSON] and [ColorizeYAML] for
+// each type of token, and by [ColorizeDiff] for added and removed lines. Only
+// the text of each token is styled (never the surrounding whitespace), so styles
+// which change the width of the text (e.g. padding, margins, borders, or a fixed
+// width) should not be used.
+type ColorTheme struct {
+	Key         lipgloss.Style
+	String      lipgloss.Style
+	Number      lipgloss.Style
+	Bool        lipgloss.Style
+	Null        lipgloss.Style
+	Punctuation lipgloss.Style
+	Comment     lipgloss.Style
+	Added       lipgloss.Style
+	Removed     lipgloss.Style
+}
+
+// DefaultColorTheme is the default [ColorTheme], using the basic ANSI colors, so
+// it respects the color palette of the terminal.
+var DefaultColorTheme = ColorTheme{
+	Key:         lipgloss.NewStyle().Foreground(lipgloss.Cyan),
+	String:      lipgloss.NewStyle().Foreground(lipgloss.Green),
+	Number:      lipgloss.NewStyle().Foreground(lipgloss.Magenta),
+	Bool:        lipgloss.NewStyle().Foreground(lipgloss.Yellow),
+	Null:        lipgloss.NewStyle().Foreground(lipgloss.BrightBlack),
+	Punctuation: lipgloss.NewStyle().Foreground(lipgloss.BrightBlack),
+	Comment:     lipgloss.NewStyle().Foreground(lipgloss.BrightBlack).Italic(true),
+	Added:       lipgloss.NewStyle().Foreground(lipgloss.Green),
+	Removed:     lipgloss.NewStyle().Foreground(lipgloss.Red),
+}
+
+// ColorizeJSON converts the provided data value into indented JSON (see
+// [ToJSON]), with syntax highlighting using the provided theme. Styles are
+// applied per token, so the visible width of each line is the same as the
+// output of [ToJSON], which makes it safe to use within TUI layouts.
+func ColorizeJSON(data any, theme ColorTheme) string {
+	var b strings.Builder
+	if err := WriteJSON(&b, data, false, 2); err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return colorizeJSON(strings.TrimSuffix(b.String(), "\n"), theme)
+}
+
+// colorizeJSON applies the theme to already encoded JSON.
+func colorizeJSON(src string, theme ColorTheme) string {
+	var out strings.Builder
+	out.Grow(len(src) * 2)
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+
+			style := theme.String
+			if strings.HasPrefix(strings.TrimLeft(src[end:], " \t\r\n"), ":") {
+				style = theme.Key
+			}
+			out.WriteString(style.Render(src[i:end]))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(src) && strings.IndexByte("0123456789.eE+-", src[end]) >= 0 {
+				end++
+			}
+			out.WriteString(theme.Number.Render(src[i:end]))
+			i = end
+		case c >= 'a' && c <= 'z':
+			end := i + 1
+			for end < len(src) && src[end] >= 'a' && src[end] <= 'z' {
+				end++
+			}
+			style := theme.Bool
+			if src[i:end] == "null" {
+				style = theme.Null
+			}
+			out.WriteString(style.Render(src[i:end]))
+			i = end
+		case strings.IndexByte("{}[],:", c) >= 0:
+			out.WriteString(theme.Punctuation.Render(src[i : i+1]))
+			i++
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	return out.String()
+}
+
+// ColorizeYAML converts the provided data value into YAML (see [ToYAML]), with
+// syntax highlighting using the provided theme. Styles are applied per token, so
+// the visible width of each line is the same as the output of [ToYAML], which
+// makes it safe to use within TUI layouts.
+func ColorizeYAML(data any, theme ColorTheme) string {
+	var b strings.Builder
+	if err := WriteYAML(&b, data, false, 2); err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return colorizeYAML(strings.TrimSuffix(b.String(), "\n"), theme)
+}
+
+// colorizeYAML applies the theme to already encoded YAML.
+func colorizeYAML(src string, theme ColorTheme) string {
+	var out strings.Builder
+	out.Grow(len(src) * 2)
+
+	for _, tk := range lexer.Tokenize(src) {
+		style, ok := yamlTokenStyle(tk, theme)
+		if !ok {
+			out.WriteString(tk.Origin)
+			continue
+		}
+		out.WriteString(styleToken(tk.Origin, style))
+	}
+
+	return out.String()
+}
+
+// yamlTokenStyle returns the style for the provided YAML token, and false if the
+// token should not be styled.
+func yamlTokenStyle(tk *token.Token, theme ColorTheme) (lipgloss.Style, bool) {
+	if tk.NextType() == token.MappingValueType {
+		return theme.Key, true
+	}
+
+	switch tk.Type {
+	case token.StringType, token.SingleQuoteType, token.DoubleQuoteType:
+		return theme.String, true
+	case token.IntegerType, token.BinaryIntegerType, token.OctetIntegerType,
+		token.HexIntegerType, token.FloatType, token.InfinityType, token.NanType:
+		return theme.Number, true
+	case token.BoolType:
+		return theme.Bool, true
+	case token.NullType:
+		return theme.Null, true
+	case token.CommentType:
+		return theme.Comment, true
+	case token.MappingValueType, token.SequenceEntryType, token.CollectEntryType,
+		token.SequenceStartType, token.SequenceEndType, token.MappingStartType,
+		token.MappingEndType, token.LiteralType, token.FoldedType:
+		return theme.Punctuation, true
+	default:
+		return lipgloss.Style{}, false
+	}
+}
+
+// styleToken styles the non-whitespace portion of each line of the provided
+// token text, leaving surrounding whitespace (and newlines) untouched.
+func styleToken(text string, style lipgloss.Style) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		start := strings.IndexFunc(line, func(r rune) bool { return !unicode.IsSpace(r) })
+		if start < 0 {
+			continue
+		}
+		end := strings.LastIndexFunc(line, func(r rune) bool { return !unicode.IsSpace(r) }) + 1
+		lines[i] = line[:start] + style.Render(line[start:end]) + line[end:]
+	}
+	return strings.Join(lines, "\n")
+}
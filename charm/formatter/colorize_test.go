@@ -0,0 +1,75 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var colorizeTestData = map[string]any{
+	"name":    "foo \"bar\": baz",
+	"count":   -12.5e3,
+	"enabled": true,
+	"missing": nil,
+	"tags":    []any{"a", 1, false},
+	"nested":  map[string]any{"key": "value"},
+}
+
+func TestColorizeJSON(t *testing.T) {
+	t.Parallel()
+
+	got := ColorizeJSON(colorizeTestData, DefaultColorTheme)
+	want := ToJSON(colorizeTestData, false, 2)
+
+	if stripped := ansi.Strip(got); stripped != want {
+		t.Fatalf("stripped output mismatch:\n%s\nwant:\n%s", stripped, want)
+	}
+
+	for _, token := range []string{
+		DefaultColorTheme.Key.Render(`"name"`),
+		DefaultColorTheme.String.Render(`"foo \"bar\": baz"`),
+		DefaultColorTheme.Number.Render(`-12500`),
+		DefaultColorTheme.Bool.Render(`true`),
+		DefaultColorTheme.Null.Render(`null`),
+		DefaultColorTheme.Punctuation.Render(`{`),
+	} {
+		if !strings.Contains(got, token) {
+			t.Errorf("expected output to contain %q:\n%q", token, got)
+		}
+	}
+}
+
+func TestColorizeYAML(t *testing.T) {
+	t.Parallel()
+
+	got := ColorizeYAML(colorizeTestData, DefaultColorTheme)
+	want := ToYAML(colorizeTestData, false, 2)
+
+	if stripped := ansi.Strip(got); stripped != want {
+		t.Fatalf("stripped output mismatch:\n%s\nwant:\n%s", stripped, want)
+	}
+
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := range wantLines {
+		if ansi.StringWidth(gotLines[i]) != ansi.StringWidth(wantLines[i]) {
+			t.Errorf("line %d: width = %d, want %d", i, ansi.StringWidth(gotLines[i]), ansi.StringWidth(wantLines[i]))
+		}
+	}
+
+	for _, token := range []string{
+		DefaultColorTheme.Key.Render(`name`),
+		DefaultColorTheme.Number.Render(`-12500.0`),
+		DefaultColorTheme.Bool.Render(`true`),
+		DefaultColorTheme.Null.Render(`null`),
+		DefaultColorTheme.Punctuation.Render(`-`),
+	} {
+		if !strings.Contains(got, token) {
+			t.Errorf("expected output to contain %q:\n%q", token, got)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ToCSV converts the provided rows into RFC 4180 CSV, with a header row. Each
+// row must be a flat map or struct (see [IsFlatValue]), where struct field names
+// are handled the same as [MaskValue]. The header is derived from the keys of
+// the first row, sorted alphabetically, and all other rows must only contain
+// keys from the header (missing keys, and nil values, are written as empty
+// fields). Values which implement [encoding.TextMarshaler] (e.g. [time.Time])
+// are written using their text form. If mask is true, all values are masked
+// (see [MaskValue]). See [ToTSV] for tab-separated output.
+func ToCSV(rows []any, mask bool) (string, error) {
+	return toDelimited(rows, mask, ',')
+}
+
+// ToTSV is the same as [ToCSV], however fields are separated by tabs.
+func ToTSV(rows []any, mask bool) (string, error) {
+	return toDelimited(rows, mask, '\t')
+}
+
+// toDelimited converts the provided rows into CSV, using comma as the field
+// delimiter. See [ToCSV].
+func toDelimited(rows []any, mask bool, comma rune) (string, error) {
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+
+	var header []string
+	record := []string{}
+
+	for i, row := range rows {
+		values, ok := walkValue(row, textValue).(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("row %d: expected a map or struct, got %T", i, row)
+		}
+		if len(values) > 0 && !IsFlatValue(values) {
+			return "", fmt.Errorf("row %d: contains nested values (only flat rows are supported)", i)
+		}
+
+		if mask {
+			values, _ = MaskValue(values).(map[string]any)
+		}
+
+		if i == 0 {
+			header = slices.Sorted(maps.Keys(values))
+			if err := w.Write(header); err != nil {
+				return "", err
+			}
+		}
+
+		for key := range values {
+			if !slices.Contains(header, key) {
+				return "", fmt.Errorf("row %d: key %q is not in the header (derived from the first row)", i, key)
+			}
+		}
+
+		record = record[:0]
+		for _, key := range header {
+			var field string
+			if v := values[key]; v != nil {
+				field = fmt.Sprint(v)
+			}
+			record = append(record, field)
+		}
+
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// textValue converts values which implement [encoding.TextMarshaler] into their
+// text form, so they can be written as a single field.
+func textValue(v any) any {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return v
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToCSV(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name    string `json:"name"`
+		Age     int    `json:"age"`
+		Comment string `json:"comment"`
+		Email   *string
+		secret  string
+	}
+
+	email := "jane@example.com"
+
+	tests := []struct {
+		name    string
+		rows    []any
+		mask    bool
+		want    string
+		wantErr string
+	}{
+		{
+			name: "structs",
+			rows: []any{
+				user{Name: "John", Age: 30, Comment: "hello", secret: "x"},
+				&user{Name: "Jane", Age: 25, Comment: "hi", Email: &email},
+			},
+			want: "Email,age,comment,name\n" +
+				",30,hello,John\n" +
+				"jane@example.com,25,hi,Jane\n",
+		},
+		{
+			name: "text-marshalers",
+			rows: []any{
+				map[string]any{"at": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "name": "deploy"},
+			},
+			want: "at,name\n2024-01-02T03:04:05Z,deploy\n",
+		},
+		{
+			name: "quoting",
+			rows: []any{
+				map[string]any{"a": `say "hi"`, "b": "one, two", "c": "multi\nline"},
+			},
+			want: "a,b,c\n" +
+				`"say ""hi""","one, two","multi` + "\n" + `line"` + "\n",
+		},
+		{
+			name: "missing-keys-and-nil",
+			rows: []any{
+				map[string]any{"a": 1, "b": true},
+				map[string]any{"a": nil},
+			},
+			want: "a,b\n1,true\n,\n",
+		},
+		{
+			name: "masked",
+			rows: []any{
+				map[string]any{"user": "admin", "password": "hunter2", "empty": nil},
+			},
+			mask: true,
+			want: "empty,password,user\n,***,***\n",
+		},
+		{
+			name: "empty",
+			rows: nil,
+			want: "",
+		},
+		{
+			name:    "nested",
+			rows:    []any{map[string]any{"a": map[string]any{"b": 1}}},
+			wantErr: "row 0: contains nested values",
+		},
+		{
+			name:    "not-a-map",
+			rows:    []any{map[string]any{"a": 1}, "foo"},
+			wantErr: "row 1: expected a map or struct, got string",
+		},
+		{
+			name:    "unknown-key",
+			rows:    []any{map[string]any{"a": 1}, map[string]any{"a": 2, "b": 3}},
+			wantErr: `row 1: key "b" is not in the header`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToCSV(tt.rows, tt.mask)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ToCSV() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToCSV() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToCSV() =\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToTSV(t *testing.T) {
+	t.Parallel()
+
+	got, err := ToTSV([]any{map[string]any{"a": "one, two", "b": "x\ty"}}, false)
+	if err != nil {
+		t.Fatalf("ToTSV() unexpected error: %v", err)
+	}
+	if want := "a\tb\none, two\t\"x\ty\"\n"; got != want {
+		t.Errorf("ToTSV() = %q, want %q", got, want)
+	}
+}
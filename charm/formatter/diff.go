@@ -0,0 +1,168 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Diff returns a unified-style diff between the before and after values, with one
+// line per changed value, prefixed with "-" (removed/old value) or "+" (added/new
+// value), followed by the path of the value and its JSON encoded value, e.g:
+//
+//	fmt.Println(formatter.Diff(before, after, false))
+//	// - server.port = 8080
+//	// + server.port = 9090
+//	// + tags[2] = "new"
+//
+// Maps and structs (see [MaskValue] for how structs are handled) are compared
+// key-by-key, and slices and arrays index-by-index, with paths sorted by key. If
+// mask is true, values are compared as-is, but masked in the output (see
+// [MaskValue]), so only the paths of changed values are visible. Returns an
+// empty string if there are no differences. See [ColorizeDiff] for a colored
+// version.
+func Diff(before, after any, mask bool) string {
+	return strings.Join(diffLines(before, after, mask, nil), "\n")
+}
+
+// ColorizeDiff is the same as [Diff], however removed lines are styled with
+// [ColorTheme.Removed], and added lines with [ColorTheme.Added].
+func ColorizeDiff(before, after any, mask bool, theme ColorTheme) string {
+	return strings.Join(diffLines(before, after, mask, &theme), "\n")
+}
+
+// diffLines returns the lines of the diff between before and after, styled with
+// the theme if it isn't nil.
+func diffLines(before, after any, mask bool, theme *ColorTheme) []string {
+	identity := func(v any) any { return v }
+
+	d := &differ{mask: mask, theme: theme}
+	d.diff("", walkValue(before, identity), walkValue(after, identity))
+	return d.lines
+}
+
+type differ struct {
+	mask  bool
+	theme *ColorTheme
+	lines []string
+}
+
+// diff compares the before and after values (as returned by [walkValue]) at the
+// provided path, recursing into maps and slices.
+func (d *differ) diff(path string, before, after any) {
+	oldMap, oldIsMap := before.(map[string]any)
+	newMap, newIsMap := after.(map[string]any)
+	if oldIsMap && newIsMap && len(oldMap) > 0 && len(newMap) > 0 {
+		keys := slices.Collect(maps.Keys(oldMap))
+		for key := range newMap {
+			if _, ok := oldMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			oldValue, inOld := oldMap[key]
+			newValue, inNew := newMap[key]
+			switch {
+			case !inOld:
+				d.emit("+", joinPath(path, key), newValue)
+			case !inNew:
+				d.emit("-", joinPath(path, key), oldValue)
+			default:
+				d.diff(joinPath(path, key), oldValue, newValue)
+			}
+		}
+		return
+	}
+
+	oldSlice, oldIsSlice := before.([]any)
+	newSlice, newIsSlice := after.([]any)
+	if oldIsSlice && newIsSlice && len(oldSlice) > 0 && len(newSlice) > 0 {
+		for i := range max(len(oldSlice), len(newSlice)) {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(oldSlice):
+				d.emit("+", elemPath, newSlice[i])
+			case i >= len(newSlice):
+				d.emit("-", elemPath, oldSlice[i])
+			default:
+				d.diff(elemPath, oldSlice[i], newSlice[i])
+			}
+		}
+		return
+	}
+
+	// Concrete values, empty maps/slices, or values of different types.
+	if encodeValue(before) == encodeValue(after) {
+		return
+	}
+	d.emit("-", path, before)
+	d.emit("+", path, after)
+}
+
+// emit adds a line for every concrete value within v, with the provided prefix.
+func (d *differ) emit(prefix, path string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				d.emit(prefix, joinPath(path, key), v[key])
+			}
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, elem := range v {
+				d.emit(prefix, path+"["+strconv.Itoa(i)+"]", elem)
+			}
+			return
+		}
+	}
+
+	if d.mask {
+		v = MaskValue(v)
+	}
+
+	value := encodeValue(v)
+	line := prefix + " " + value
+	if path != "" {
+		line = prefix + " " + path + " = " + value
+	}
+
+	if d.theme != nil {
+		style := d.theme.Added
+		if prefix == "-" {
+			style = d.theme.Removed
+		}
+		line = style.Render(line)
+	}
+
+	d.lines = append(d.lines, line)
+}
+
+// joinPath joins a map key onto the provided path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// encodeValue encodes a value as compact JSON, for comparison and display.
+func encodeValue(v any) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
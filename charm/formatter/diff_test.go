@@ -0,0 +1,163 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host     string `json:"host"`
+		Port     int    `json:"port,omitempty"`
+		Password string `json:"password"`
+		internal bool
+	}
+
+	type event struct {
+		Name string    `json:"name"`
+		At   time.Time `json:"at"`
+	}
+
+	t1 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		mask   bool
+		want   []string
+	}{
+		{
+			name:   "equal",
+			before: map[string]any{"a": 1, "b": []any{"x"}},
+			after:  map[string]any{"a": 1.0, "b": []string{"x"}},
+			want:   nil,
+		},
+		{
+			name:   "added-key",
+			before: map[string]any{"a": 1},
+			after:  map[string]any{"a": 1, "b": "new"},
+			want:   []string{`+ b = "new"`},
+		},
+		{
+			name:   "removed-key",
+			before: map[string]any{"a": 1, "b": "old"},
+			after:  map[string]any{"a": 1},
+			want:   []string{`- b = "old"`},
+		},
+		{
+			name:   "changed-key",
+			before: map[string]any{"a": 1, "b": true},
+			after:  map[string]any{"a": 2, "b": true},
+			want:   []string{`- a = 1`, `+ a = 2`},
+		},
+		{
+			name:   "nested-map",
+			before: map[string]any{"db": map[string]any{"host": "a", "port": 5432}},
+			after:  map[string]any{"db": map[string]any{"host": "b", "port": 5432, "tls": map[string]any{"enabled": true}}},
+			want:   []string{`- db.host = "a"`, `+ db.host = "b"`, `+ db.tls.enabled = true`},
+		},
+		{
+			name:   "slices",
+			before: map[string]any{"tags": []any{"a", "b", "c"}},
+			after:  map[string]any{"tags": []any{"a", "x"}},
+			want:   []string{`- tags[1] = "b"`, `+ tags[1] = "x"`, `- tags[2] = "c"`},
+		},
+		{
+			name:   "type-change",
+			before: map[string]any{"a": map[string]any{"b": 1}},
+			after:  map[string]any{"a": "flat"},
+			want:   []string{`- a.b = 1`, `+ a = "flat"`},
+		},
+		{
+			name:   "empty-to-value",
+			before: map[string]any{"a": map[string]any{}},
+			after:  map[string]any{"a": map[string]any{"b": nil}},
+			want:   []string{`- a = {}`, `+ a.b = null`},
+		},
+		{
+			name:   "structs",
+			before: server{Host: "a", Port: 1, Password: "secret", internal: true},
+			after:  &server{Host: "a", Port: 2, Password: "secret"},
+			want:   []string{`- port = 1`, `+ port = 2`},
+		},
+		{
+			name:   "masked",
+			before: server{Host: "a", Password: "hunter2"},
+			after:  server{Host: "a", Password: "hunter3"},
+			mask:   true,
+			want:   []string{`- password = "***"`, `+ password = "***"`},
+		},
+		{
+			name:   "time-changed",
+			before: event{Name: "deploy", At: t1},
+			after:  event{Name: "deploy", At: t2},
+			want:   []string{`- at = "2024-01-02T03:04:05Z"`, `+ at = "2024-01-02T04:04:05Z"`},
+		},
+		{
+			name:   "time-unchanged",
+			before: event{Name: "deploy", At: t1},
+			after:  &event{Name: "deploy", At: t1},
+			want:   nil,
+		},
+		{
+			name:   "time-masked",
+			before: map[string]any{"at": t1},
+			after:  map[string]any{"at": &t2},
+			mask:   true,
+			want:   []string{`- at = "***"`, `+ at = "***"`},
+		},
+		{
+			name:   "root-value",
+			before: "a",
+			after:  "b",
+			want:   []string{`- "a"`, `+ "b"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Diff(tt.before, tt.after, tt.mask)
+			want := strings.Join(tt.want, "\n")
+			if got != want {
+				t.Errorf("Diff() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestColorizeDiff(t *testing.T) {
+	t.Parallel()
+
+	before := map[string]any{"a": 1, "b": "old"}
+	after := map[string]any{"a": 2}
+
+	got := ColorizeDiff(before, after, false, DefaultColorTheme)
+	want := Diff(before, after, false)
+
+	if stripped := ansi.Strip(got); stripped != want {
+		t.Fatalf("stripped output mismatch:\n%s\nwant:\n%s", stripped, want)
+	}
+
+	for _, line := range []string{
+		DefaultColorTheme.Removed.Render("- a = 1"),
+		DefaultColorTheme.Added.Render("+ a = 2"),
+		DefaultColorTheme.Removed.Render(`- b = "old"`),
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("expected output to contain %q:\n%q", line, got)
+		}
+	}
+}
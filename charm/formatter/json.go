@@ -5,30 +5,142 @@
 package formatter
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"reflect"
+	"slices"
 	"strings"
 )
 
-// ToJSON will convert the provided data value into JSON. If mask is true, all
-// concrete values will be masked with asterisks.
+// ToJSON will convert the provided data value into JSON, indented by indent
+// spaces per level. If mask is true, all concrete values will be masked with
+// asterisks. See [WriteJSON] for writing directly to an [io.Writer].
 func ToJSON(data any, mask bool, indent int) string {
-	if !mask {
-		b, err := json.MarshalIndent(data, "", strings.Repeat(" ", indent))
-		if err != nil {
-			return fmt.Sprintf("error: %v", err)
-		}
-		return string(b)
+	var b strings.Builder
+	if err := WriteJSON(&b, data, mask, indent); err != nil {
+		return fmt.Sprintf("error: %v", err)
 	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
 
-	if data == nil {
-		return "null"
+// WriteJSON is the same as [ToJSON], however it encodes the data value directly
+// to w (followed by a newline), rather than returning a string. This is useful
+// when writing large values to files, as the output doesn't have to be copied
+// into a string first. If mask is true, the masked output is written as the data
+// value is walked, rather than building a masked copy first (see [MaskValue]).
+func WriteJSON(w io.Writer, data any, mask bool, indent int) error {
+	if mask {
+		mw := &maskedJSONWriter{
+			w:      bufio.NewWriter(w),
+			indent: strings.Repeat(" ", max(indent, 0)),
+		}
+		mw.value(reflect.ValueOf(data), 0)
+		mw.w.WriteByte('\n')
+		return mw.w.Flush()
 	}
 
-	masked := MaskValue(data)
-	b, err := json.MarshalIndent(masked, "", strings.Repeat(" ", indent))
+	b, err := json.MarshalIndent(data, "", strings.Repeat(" ", max(indent, 0)))
 	if err != nil {
-		return fmt.Sprintf("error: %v", err)
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// maskedJSONWriter writes the JSON encoding of [MaskValue] for a value, without
+// building the masked copy. The output matches [json.MarshalIndent].
+type maskedJSONWriter struct {
+	w      *bufio.Writer
+	indent string
+}
+
+// value writes the masked encoding of val, at the provided nesting depth.
+func (m *maskedJSONWriter) value(val reflect.Value, depth int) {
+	if val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+
+	if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+		m.w.WriteString("null")
+		return
+	}
+	if isOpaqueValue(val) {
+		m.w.WriteString(`"` + MaskReplacementValue + `"`)
+		return
+	}
+
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Map:
+		fields := make(map[string]reflect.Value, val.Len())
+		for _, key := range val.MapKeys() {
+			fields[fmt.Sprintf("%v", key.Interface())] = val.MapIndex(key)
+		}
+		m.object(fields, depth)
+	case reflect.Slice, reflect.Array:
+		if val.Len() == 0 {
+			m.w.WriteString("[]")
+			return
+		}
+		m.w.WriteByte('[')
+		for i := range val.Len() {
+			if i > 0 {
+				m.w.WriteByte(',')
+			}
+			m.newline(depth + 1)
+			m.value(val.Index(i), depth+1)
+		}
+		m.newline(depth)
+		m.w.WriteByte(']')
+	case reflect.Struct:
+		fields := make(map[string]reflect.Value, val.NumField())
+		typ := val.Type()
+		for i := range val.NumField() {
+			if name, ok := fieldName(typ.Field(i)); ok {
+				fields[name] = val.Field(i)
+			}
+		}
+		m.object(fields, depth)
+	case reflect.Ptr:
+		m.value(val.Elem(), depth)
+	default:
+		m.w.WriteString(`"` + MaskReplacementValue + `"`)
+	}
+}
+
+// object writes the provided fields as a JSON object, sorted by key.
+func (m *maskedJSONWriter) object(fields map[string]reflect.Value, depth int) {
+	if len(fields) == 0 {
+		m.w.WriteString("{}")
+		return
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	m.w.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			m.w.WriteByte(',')
+		}
+		m.newline(depth + 1)
+		b, _ := json.Marshal(key)
+		m.w.Write(b)
+		m.w.WriteString(": ")
+		m.value(fields[key], depth+1)
+	}
+	m.newline(depth)
+	m.w.WriteByte('}')
+}
+
+// newline writes a newline, followed by the indent for the provided depth.
+func (m *maskedJSONWriter) newline(depth int) {
+	m.w.WriteByte('\n')
+	for range depth {
+		m.w.WriteString(m.indent)
 	}
-	return string(b)
 }
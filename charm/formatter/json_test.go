@@ -5,7 +5,10 @@
 package formatter
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestToJSONWithMask(t *testing.T) {
@@ -105,3 +108,92 @@ func TestToJSONWithMask(t *testing.T) {
 		})
 	}
 }
+
+func TestToJSONIndent(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{"name": "test", "tags": []string{"a"}}
+
+	tests := []struct {
+		indent int
+		want   string
+	}{
+		{indent: 0, want: "{\n\"name\": \"test\",\n\"tags\": [\n\"a\"\n]\n}"},
+		{indent: 2, want: "{\n  \"name\": \"test\",\n  \"tags\": [\n    \"a\"\n  ]\n}"},
+		{indent: -1, want: "{\n\"name\": \"test\",\n\"tags\": [\n\"a\"\n]\n}"},
+	}
+
+	for _, tt := range tests {
+		if got := ToJSON(input, false, tt.indent); got != tt.want {
+			t.Errorf("ToJSON(indent=%d) = %q, want %q", tt.indent, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{"name": "test", "tags": []string{"a", "<b>"}}
+
+	for _, mask := range []bool{false, true} {
+		var b strings.Builder
+		if err := WriteJSON(&b, input, mask, 2); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		if want := ToJSON(input, mask, 2) + "\n"; b.String() != want {
+			t.Errorf("WriteJSON(mask=%v) = %q, want %q", mask, b.String(), want)
+		}
+	}
+
+	var b strings.Builder
+	if err := WriteJSON(&b, make(chan int), false, 2); err == nil {
+		t.Error("WriteJSON() expected error for unsupported type")
+	}
+}
+
+func TestWriteJSONMasked(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Value  int       `json:"value"`
+		At     time.Time `json:"at"`
+		hidden string
+	}
+
+	name := "test"
+	inputs := []any{
+		nil,
+		"test",
+		&name,
+		(*string)(nil),
+		[]any{},
+		map[string]any{},
+		struct{}{},
+		map[int]any{1: "a", 2: nil, 3: []int{1, 2}},
+		map[string]any{"<key>": inner{Value: 1}, "list": []any{map[string]any{}, &inner{}, nil}},
+		[2][]string{{"a"}, nil},
+		struct {
+			Inner   inner  `json:"inner"`
+			Ptr     *inner `json:"ptr,omitempty"`
+			Skipped string `json:"-"`
+			Any     any
+		}{Any: inner{}},
+	}
+
+	for _, input := range inputs {
+		for _, indent := range []int{-1, 0, 2, 4} {
+			want, err := json.MarshalIndent(MaskValue(input), "", strings.Repeat(" ", max(indent, 0)))
+			if err != nil {
+				t.Fatalf("json.MarshalIndent() error = %v", err)
+			}
+
+			var b strings.Builder
+			if err := WriteJSON(&b, input, true, indent); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			if b.String() != string(want)+"\n" {
+				t.Errorf("WriteJSON(%#v, indent=%d) = %q, want %q", input, indent, b.String(), string(want)+"\n")
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// KVTheme is the set of styles used by [HighlightKV]. Like [ColorTheme], only the
+// text of each token is styled, so styles which change the width of the text
+// (e.g. padding, margins, borders, or a fixed width) should not be used.
+type KVTheme struct {
+	Key       lipgloss.Style
+	Separator lipgloss.Style
+	Value     lipgloss.Style
+}
+
+// DefaultKVTheme is the default [KVTheme], with dim keys and bright values, using
+// the basic ANSI colors, so it respects the color palette of the terminal.
+var DefaultKVTheme = KVTheme{
+	Key:       lipgloss.NewStyle().Foreground(lipgloss.BrightBlack),
+	Separator: lipgloss.NewStyle().Foreground(lipgloss.BrightBlack),
+	Value:     lipgloss.NewStyle().Foreground(lipgloss.BrightCyan),
+}
+
+// HighlightKV highlights the fields within a plain log line, using the provided
+// theme, e.g. the line:
+//
+//	level=INFO msg="request complete" status=200 {"user": "alice", "id": 1}
+//
+// has the keys (level, msg, status, user and id), separators (= and :) and
+// values highlighted. Supported fields are logfmt-style key=value pairs (where
+// the value is either quoted, or runs until the next whitespace), and JSON
+// "key": value pairs (nested objects and arrays are highlighted field by field).
+// The rest of the line, including any existing ANSI escape sequences, is left
+// untouched, so the visible width of the line doesn't change, which makes it safe
+// to use within TUI layouts.
+func HighlightKV(line string, theme KVTheme) string {
+	var out strings.Builder
+	out.Grow(len(line) * 2)
+
+	for i := 0; i < len(line); {
+		c := line[i]
+
+		switch {
+		case c == ansi.ESC:
+			seq, _, n, _ := ansi.DecodeSequence(line[i:], ansi.NormalState, nil)
+			if n <= 0 {
+				n = 1
+			}
+			out.WriteString(seq)
+			i += n
+		case c == '"':
+			end := quotedEnd(line, i)
+
+			// A quoted string followed by a colon is a JSON key.
+			sep := end
+			for sep < len(line) && (line[sep] == ' ' || line[sep] == '\t') {
+				sep++
+			}
+			if sep >= len(line) || line[sep] != ':' {
+				out.WriteString(line[i:end])
+				i = end
+				continue
+			}
+
+			out.WriteString(theme.Key.Render(line[i:end]))
+			out.WriteString(line[end:sep])
+			out.WriteString(theme.Separator.Render(":"))
+			i = sep + 1
+
+			for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+				out.WriteByte(line[i])
+				i++
+			}
+			i = writeKVValue(&out, line, i, ",}] \t", theme)
+		case isKeyByte(c) && (i == 0 || !isKeyByte(line[i-1])):
+			end := i + 1
+			for end < len(line) && isKeyByte(line[end]) {
+				end++
+			}
+			if end >= len(line) || line[end] != '=' {
+				out.WriteString(line[i:end])
+				i = end
+				continue
+			}
+
+			out.WriteString(theme.Key.Render(line[i:end]))
+			out.WriteString(theme.Separator.Render("="))
+			i = writeKVValue(&out, line, end+1, " \t", theme)
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	return out.String()
+}
+
+// writeKVValue writes the value starting at i, which is either quoted, or runs
+// until one of the terminator bytes (or an escape sequence), and returns the
+// index after the value. Values starting with "{" or "[" aren't styled, so the
+// fields within them can be highlighted.
+func writeKVValue(out *strings.Builder, line string, i int, terminators string, theme KVTheme) int {
+	if i >= len(line) || line[i] == '{' || line[i] == '[' {
+		return i
+	}
+
+	end := i
+	if line[i] == '"' {
+		end = quotedEnd(line, i)
+	} else {
+		for end < len(line) && line[end] != ansi.ESC && strings.IndexByte(terminators, line[end]) < 0 {
+			end++
+		}
+	}
+
+	if end > i {
+		out.WriteString(theme.Value.Render(line[i:end]))
+	}
+	return end
+}
+
+// quotedEnd returns the index after the closing quote of the quoted string
+// starting at i, or the end of the line if it isn't terminated.
+func quotedEnd(line string, i int) int {
+	for end := i + 1; end < len(line); end++ {
+		switch line[end] {
+		case '\\':
+			end++
+		case '"':
+			return end + 1
+		}
+	}
+	return len(line)
+}
+
+// isKeyByte returns true if c can be part of a logfmt-style key.
+func isKeyByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
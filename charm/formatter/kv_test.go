@@ -0,0 +1,99 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// markerKVTheme wraps each token with readable markers, rather than ANSI escape
+// sequences, so the expected output is easy to read.
+var markerKVTheme = KVTheme{
+	Key:       lipgloss.NewStyle().Transform(func(s string) string { return "<k>" + s + "</k>" }),
+	Separator: lipgloss.NewStyle().Transform(func(s string) string { return "<s>" + s + "</s>" }),
+	Value:     lipgloss.NewStyle().Transform(func(s string) string { return "<v>" + s + "</v>" }),
+}
+
+var kvTestLines = []struct {
+	name string
+	in   string
+	want string
+}{
+	{
+		name: "plain",
+		in:   "nothing to see here: 12:30",
+		want: "nothing to see here: 12:30",
+	},
+	{
+		name: "logfmt",
+		in:   `time=2024-01-01T10:00:00Z level=INFO msg="request complete" status=200`,
+		want: `<k>time</k><s>=</s><v>2024-01-01T10:00:00Z</v> <k>level</k><s>=</s><v>INFO</v> <k>msg</k><s>=</s><v>"request complete"</v> <k>status</k><s>=</s><v>200</v>`,
+	},
+	{
+		name: "escaped-quote",
+		in:   `err="bad \"input\" value" retry=true`,
+		want: `<k>err</k><s>=</s><v>"bad \"input\" value"</v> <k>retry</k><s>=</s><v>true</v>`,
+	},
+	{
+		name: "empty-value",
+		in:   "key= other=1",
+		want: "<k>key</k><s>=</s> <k>other</k><s>=</s><v>1</v>",
+	},
+	{
+		name: "unterminated-quote",
+		in:   `msg="oops`,
+		want: `<k>msg</k><s>=</s><v>"oops</v>`,
+	},
+	{
+		name: "text-prefix",
+		in:   "INFO server started addr=:8080",
+		want: "INFO server started <k>addr</k><s>=</s><v>:8080</v>",
+	},
+	{
+		name: "json",
+		in:   `{"user": "alice", "id": 1, "ok":true, "tags": ["a", "b"], "meta": {"n": null}}`,
+		want: `{<k>"user"</k><s>:</s> <v>"alice"</v>, <k>"id"</k><s>:</s> <v>1</v>, <k>"ok"</k><s>:</s><v>true</v>, <k>"tags"</k><s>:</s> ["a", "b"], <k>"meta"</k><s>:</s> {<k>"n"</k><s>:</s> <v>null</v>}}`,
+	},
+	{
+		name: "mixed",
+		in:   `level=WARN payload={"retry": 3} done`,
+		want: `<k>level</k><s>=</s><v>WARN</v> <k>payload</k><s>=</s>{<k>"retry"</k><s>:</s> <v>3</v>} done`,
+	},
+	{
+		name: "existing-ansi",
+		in:   "\x1b[31mERROR\x1b[0m code=500",
+		want: "\x1b[31mERROR\x1b[0m <k>code</k><s>=</s><v>500</v>",
+	},
+}
+
+func TestHighlightKV(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range kvTestLines {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := HighlightKV(tt.in, markerKVTheme); got != tt.want {
+				t.Fatalf("HighlightKV(%q):\n got: %q\nwant: %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightKV_width(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range kvTestLines {
+		got := HighlightKV(tt.in, DefaultKVTheme)
+		if ansi.Strip(got) != ansi.Strip(tt.in) {
+			t.Errorf("%s: stripped output = %q, want %q", tt.name, ansi.Strip(got), ansi.Strip(tt.in))
+		}
+		if ansi.StringWidth(got) != ansi.StringWidth(tt.in) {
+			t.Errorf("%s: width = %d, want %d", tt.name, ansi.StringWidth(got), ansi.StringWidth(tt.in))
+		}
+	}
+}
@@ -5,55 +5,108 @@
 package formatter
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 const MaskReplacementValue = "***"
 
 // MaskValue recursively masks concrete values in the data structure.
 func MaskValue(v any) any {
+	return walkValue(v, func(any) any { return MaskReplacementValue })
+}
+
+// walkValue recursively converts maps and structs into map[string]any, and
+// slices and arrays into []any, dereferencing pointers, and replacing all
+// concrete (non-nil) values with the result of fn. Struct field names are taken
+// from their json tag if present, and fields tagged with json:"-", or which are
+// unexported, are skipped. Values which implement [json.Marshaler] or
+// [encoding.TextMarshaler] (e.g. [time.Time]), and structs with fields but none
+// exported, are treated as concrete values.
+func walkValue(v any, fn func(v any) any) any {
 	if v == nil {
 		return nil
 	}
 
 	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return nil
+	}
+	if isOpaqueValue(val) {
+		return fn(v)
+	}
+
 	switch val.Kind() { //nolint:exhaustive
 	case reflect.Map:
 		result := make(map[string]any)
 		for _, key := range val.MapKeys() {
 			keyStr := fmt.Sprintf("%v", key.Interface())
-			result[keyStr] = MaskValue(val.MapIndex(key).Interface())
+			result[keyStr] = walkValue(val.MapIndex(key).Interface(), fn)
 		}
 		return result
 	case reflect.Slice, reflect.Array:
 		result := make([]any, val.Len())
 		for i := range val.Len() {
-			result[i] = MaskValue(val.Index(i).Interface())
+			result[i] = walkValue(val.Index(i).Interface(), fn)
 		}
 		return result
 	case reflect.Struct:
 		result := make(map[string]any)
 		typ := val.Type()
 		for i := range val.NumField() {
-			field := val.Field(i)
-			fieldType := typ.Field(i)
-			fieldName := fieldType.Name
-			if jsonTag := fieldType.Tag.Get("json"); jsonTag != "" {
-				if jsonTag == "-" {
-					continue // Skip fields with json:"-" tag
-				}
-				fieldName = jsonTag
+			if name, ok := fieldName(typ.Field(i)); ok {
+				result[name] = walkValue(val.Field(i).Interface(), fn)
 			}
-			result[fieldName] = MaskValue(field.Interface())
 		}
 		return result
 	case reflect.Ptr:
 		if val.IsNil() {
 			return nil
 		}
-		return MaskValue(val.Elem().Interface())
+		return walkValue(val.Elem().Interface(), fn)
 	default:
-		return MaskReplacementValue
+		return fn(v)
+	}
+}
+
+// fieldName returns the name of the struct field, taken from its json tag if
+// present. ok is false if the field is unexported, or tagged with json:"-".
+func fieldName(field reflect.StructField) (name string, ok bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(jsonTag, ","); name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isOpaqueValue reports whether the value should be treated as a single concrete
+// value by [walkValue], rather than walked into.
+func isOpaqueValue(val reflect.Value) bool {
+	typ := val.Type()
+	if typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+		return true
+	}
+	if typ.Kind() != reflect.Struct || typ.NumField() == 0 {
+		return false
+	}
+	for i := range typ.NumField() {
+		if typ.Field(i).IsExported() {
+			return false
+		}
 	}
+	return true
 }
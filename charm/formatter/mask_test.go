@@ -7,6 +7,7 @@ package formatter
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestMaskValue(t *testing.T) {
@@ -256,6 +257,55 @@ func TestMaskValue(t *testing.T) {
 			},
 			expected: map[string]any{},
 		},
+		{
+			name: "struct with json tag options",
+			input: struct {
+				Name  string `json:"name,omitempty"`
+				Token string `json:",omitempty"`
+			}{
+				Name:  "test",
+				Token: "secret",
+			},
+			expected: map[string]any{
+				"name":  MaskReplacementValue,
+				"Token": MaskReplacementValue,
+			},
+		},
+		{
+			name: "struct with unexported fields",
+			input: struct {
+				Name   string
+				secret string
+			}{
+				Name:   "test",
+				secret: "secret",
+			},
+			expected: map[string]any{
+				"Name": MaskReplacementValue,
+			},
+		},
+		{
+			name: "struct with marshaler fields",
+			input: struct {
+				Created time.Time  `json:"created"`
+				Updated *time.Time `json:"updated"`
+			}{
+				Created: time.Now(),
+			},
+			expected: map[string]any{
+				"created": MaskReplacementValue,
+				"updated": nil,
+			},
+		},
+		{
+			name: "struct with only unexported fields",
+			input: struct {
+				secret string
+			}{
+				secret: "secret",
+			},
+			expected: MaskReplacementValue,
+		},
 	}
 
 	for _, tt := range tests {
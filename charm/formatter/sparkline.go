@@ -0,0 +1,81 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkLevels are the block characters used by [Sparkline], lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns a single-line chart of the provided values (e.g. request
+// latency over time), with one block character (▁ to █) per value, scaled
+// between the smallest and largest value, e.g:
+//
+//	fmt.Println(formatter.Sparkline([]float64{1, 5, 2, 8, 3}, 0))
+//	// ▁▅▂█▃
+//
+// If width is greater than 0 and there are more values than width, values are
+// downsampled by averaging consecutive values, so the result is width characters
+// wide. Fewer values are never stretched to fill width. If all values are the
+// same, they are rendered at the middle level. NaN and infinite values are
+// rendered as a space, and ignored when scaling. Returns an empty string if
+// there are no values.
+func Sparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = downsample(values, width)
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if isFinite(v) {
+			low = min(low, v)
+			high = max(high, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case !isFinite(v):
+			b.WriteRune(' ')
+		case high == low:
+			b.WriteRune(sparkLevels[len(sparkLevels)/2-1])
+		default:
+			level := int((v - low) / (high - low) * float64(len(sparkLevels)-1))
+			b.WriteRune(sparkLevels[level])
+		}
+	}
+	return b.String()
+}
+
+// downsample averages values into width buckets of (roughly) equal size. NaN and
+// infinite values are ignored, and buckets without any other values are NaN.
+func downsample(values []float64, width int) []float64 {
+	out := make([]float64, width)
+	for i := range out {
+		var sum float64
+		var n int
+		for _, v := range values[i*len(values)/width : (i+1)*len(values)/width] {
+			if isFinite(v) {
+				sum += v
+				n++
+			}
+		}
+
+		out[i] = math.NaN()
+		if n > 0 {
+			out[i] = sum / float64(n)
+		}
+	}
+	return out
+}
+
+// isFinite returns true if v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{name: "nil", values: nil, want: ""},
+		{name: "empty-width", values: []float64{}, width: 10, want: ""},
+		{name: "single", values: []float64{42}, want: "▄"},
+		{name: "constant", values: []float64{5, 5, 5}, want: "▄▄▄"},
+		{name: "levels", values: []float64{1, 2, 3, 4, 5, 6, 7, 8}, want: "▁▂▃▄▅▆▇█"},
+		{name: "mixed", values: []float64{1, 5, 2, 8, 3}, want: "▁▅▂█▃"},
+		{name: "negative", values: []float64{-10, 0, 10}, want: "▁▄█"},
+		{name: "fractional", values: []float64{0.1, 0.15, 0.2}, want: "▁▄█"},
+		{name: "non-finite", values: []float64{1, math.NaN(), 8, math.Inf(1)}, want: "▁ █ "},
+		{name: "all-non-finite", values: []float64{math.NaN(), math.Inf(-1)}, want: "  "},
+		{name: "fits-width", values: []float64{1, 8}, width: 5, want: "▁█"},
+		{name: "downsample", values: []float64{1, 1, 8, 8}, width: 2, want: "▁█"},
+		{name: "downsample-average", values: []float64{0, 2, 4, 6, 14, 14}, width: 3, want: "▁▃█"},
+		{name: "downsample-uneven", values: []float64{1, 2, 3, 4, 5, 6, 7}, width: 3, want: "▁▄█"},
+		{name: "downsample-non-finite", values: []float64{math.NaN(), math.NaN(), 1, 3, 8, 8}, width: 3, want: " ▁█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
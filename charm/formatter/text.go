@@ -61,6 +61,20 @@ func TruncReset(s string) string {
 	return strings.ReplaceAll(s, ANSIReset, "")
 }
 
+// StripANSI removes all ANSI escape sequences (e.g. colors, cursor movement,
+// OSC sequences such as hyperlinks, etc) from a string. Useful for logging
+// rendered output. See also [TruncReset] which only removes reset sequences.
+func StripANSI(s string) string {
+	return ansi.Strip(s)
+}
+
+// PlainWidth returns the display width of a string, ignoring any ANSI escape
+// sequences, and accounting for wide-characters (such as East-Asian characters
+// and emojis).
+func PlainWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
 // TruncPath dynamically truncates a path to a given length, prioritizing keeping
 // both start and end segments when possible.
 func TruncPath(s string, length int) string {
@@ -226,3 +226,35 @@ func TestPadMinimum(t *testing.T) {
 		})
 	}
 }
+
+func TestStripANSI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		width    int
+	}{
+		{name: "plain", input: "hello", expected: "hello", width: 5},
+		{name: "csi-color", input: "\x1b[1;31mred\x1b[0m text", expected: "red text", width: 8},
+		{name: "csi-reset", input: "a" + ANSIReset + "b", expected: "ab", width: 2},
+		{name: "csi-cursor", input: "\x1b[2Jcleared\x1b[H", expected: "cleared", width: 7},
+		{name: "osc-title", input: "\x1b]0;window title\x07body", expected: "body", width: 4},
+		{name: "osc-hyperlink", input: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", expected: "link", width: 4},
+		{name: "wide-characters", input: "\x1b[32m日本\x1b[m", expected: "日本", width: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := StripANSI(tt.input); result != tt.expected {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+			if width := PlainWidth(tt.input); width != tt.width {
+				t.Errorf("PlainWidth(%q) = %d, want %d", tt.input, width, tt.width)
+			}
+		})
+	}
+}
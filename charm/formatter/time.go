@@ -6,6 +6,8 @@ package formatter
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 )
 
@@ -76,3 +78,156 @@ func Duration(d time.Duration, rel int) string {
 
 	return out
 }
+
+// shortUnits are the units used by [DurationShort], from most to least
+// significant.
+var shortUnits = []struct {
+	suffix string
+	size   time.Duration
+}{
+	{"y", 365 * 24 * time.Hour},
+	{"mo", 30 * 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// TimeRelativeShort formats a time.Time as a compact relative string, without
+// a relative prefix/suffix (e.g. "3d", "2h5m", "now", "n/a" for zero value). See
+// [DurationShort].
+func TimeRelativeShort(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = time.Since(t)
+	}
+	return DurationShort(d)
+}
+
+// DurationShort formats a duration in a compact form, using the most
+// significant unit, and the next unit if it's non-zero (e.g. "3d", "2h5m",
+// "1y2mo"). Units are years (y), months (mo), weeks (w), days (d), hours (h),
+// minutes (m) and seconds (s), where a month is 30 days, and a year is 365 days.
+// Negative durations are formatted the same as positive ones, and durations
+// under a second are formatted as "now".
+func DurationShort(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Second {
+		return "now"
+	}
+
+	for i, unit := range shortUnits {
+		if i == len(shortUnits)-1 {
+			return fmt.Sprintf("%d%s", d.Round(unit.size)/unit.size, unit.suffix)
+		}
+		next := shortUnits[i+1]
+
+		// Round to the precision of the next unit, so e.g. 59m59.6s is "1h".
+		if d < unit.size {
+			if d.Round(next.size) < unit.size {
+				continue
+			}
+			d = unit.size
+		}
+
+		n := d / unit.size
+		rem := (d % unit.size).Round(next.size) / next.size
+		if rem >= unit.size/next.size {
+			n++
+			rem = 0
+		}
+
+		out := fmt.Sprintf("%d%s", n, unit.suffix)
+		if rem > 0 {
+			out += fmt.Sprintf("%d%s", rem, next.suffix)
+		}
+		return out
+	}
+
+	return "now"
+}
+
+// parseUnits are the units supported by [ParseDuration], in addition to those
+// supported by [time.ParseDuration].
+var parseUnits = map[string]time.Duration{
+	"y":  365 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// ParseDuration parses a duration string, like [time.ParseDuration], however also
+// supports years (y), months (mo), weeks (w) and days (d), where a month is 30
+// days, and a year is 365 days (the same as [DurationShort]). Units can be mixed,
+// e.g. "1w2d3h", "1.5d" or "-2w".
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+
+	var neg bool
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+
+	isNum := func(c byte) bool { return c == '.' || ('0' <= c && c <= '9') }
+
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && isNum(s[i]) {
+			i++
+		}
+		j := i
+		for j < len(s) && !isNum(s[j]) {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+
+		value, unit := s[:i], s[i:j]
+		s = s[j:]
+
+		var d time.Duration
+		if size, ok := parseUnits[unit]; ok {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+			if f*float64(size) >= math.MaxInt64 {
+				return 0, fmt.Errorf("invalid duration %q: overflow", orig)
+			}
+			d = time.Duration(f * float64(size))
+		} else {
+			var err error
+			d, err = time.ParseDuration(value + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: unknown unit %q", orig, unit)
+			}
+		}
+
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("invalid duration %q: overflow", orig)
+		}
+		total += d
+	}
+
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
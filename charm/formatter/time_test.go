@@ -0,0 +1,120 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package formatter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationShort(t *testing.T) {
+	t.Parallel()
+
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name string
+		in   time.Duration
+		want string
+	}{
+		{name: "zero", in: 0, want: "now"},
+		{name: "sub-second", in: 999 * time.Millisecond, want: "now"},
+		{name: "one-second", in: time.Second, want: "1s"},
+		{name: "seconds-rounded", in: 42*time.Second + 600*time.Millisecond, want: "43s"},
+		{name: "negative", in: -42 * time.Second, want: "42s"},
+		{name: "minute", in: time.Minute, want: "1m"},
+		{name: "minutes-seconds", in: 2*time.Minute + 5*time.Second, want: "2m5s"},
+		{name: "rounds-up-unit", in: 59*time.Minute + 59*time.Second + 600*time.Millisecond, want: "1h"},
+		{name: "hours-minutes", in: 2*time.Hour + 5*time.Minute + 10*time.Second, want: "2h5m"},
+		{name: "days", in: 3 * day, want: "3d"},
+		{name: "days-hours", in: 3*day + 4*time.Hour, want: "3d4h"},
+		{name: "weeks-days", in: 16 * day, want: "2w2d"},
+		{name: "months", in: 60 * day, want: "2mo"},
+		{name: "months-weeks", in: 75 * day, want: "2mo2w"},
+		{name: "year", in: 365 * day, want: "1y"},
+		{name: "years-months", in: 3*365*day + 65*day, want: "3y2mo"},
+		{name: "years-rounds-up", in: 2*365*day - 2*day, want: "2y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DurationShort(tt.in); got != tt.want {
+				t.Errorf("DurationShort(%s) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRelativeShort(t *testing.T) {
+	t.Parallel()
+
+	if got := TimeRelativeShort(time.Time{}); got != "n/a" {
+		t.Errorf("zero time = %q, want %q", got, "n/a")
+	}
+	if got := TimeRelativeShort(time.Now().Add(-3*time.Hour - 30*time.Second)); got != "3h1m" && got != "3h" {
+		t.Errorf("past = %q, want %q", got, "3h")
+	}
+	if got := TimeRelativeShort(time.Now().Add(49 * time.Hour)); got != "2d1h" {
+		t.Errorf("future = %q, want %q", got, "2d1h")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "zero", in: "0", want: 0},
+		{name: "stdlib", in: "1h30m", want: 90 * time.Minute},
+		{name: "stdlib-fraction", in: "1.5s", want: 1500 * time.Millisecond},
+		{name: "stdlib-small", in: "10ms5us", want: 10*time.Millisecond + 5*time.Microsecond},
+		{name: "days", in: "7d", want: 7 * day},
+		{name: "weeks", in: "2w", want: 14 * day},
+		{name: "months", in: "2mo", want: 60 * day},
+		{name: "years", in: "1y", want: 365 * day},
+		{name: "fraction-days", in: "1.5d", want: 36 * time.Hour},
+		{name: "mixed", in: "1w2d3h", want: 9*day + 3*time.Hour},
+		{name: "mixed-all", in: "1y2mo1w1d1h1m1s", want: 365*day + 60*day + 8*day + time.Hour + time.Minute + time.Second},
+		{name: "negative", in: "-2d12h", want: -(2*day + 12*time.Hour)},
+		{name: "positive-sign", in: "+3d", want: 3 * day},
+		{name: "round-trip-short", in: DurationShort(16 * day), want: 16 * day},
+		{name: "empty", in: "", wantErr: true},
+		{name: "sign-only", in: "-", wantErr: true},
+		{name: "missing-unit", in: "5", wantErr: true},
+		{name: "missing-value", in: "d", wantErr: true},
+		{name: "unknown-unit", in: "3x", wantErr: true},
+		{name: "invalid-number", in: "1.2.3d", wantErr: true},
+		{name: "dot-only", in: ".d", wantErr: true},
+		{name: "spaces", in: "1d 2h", wantErr: true},
+		{name: "overflow", in: "300y", wantErr: true},
+		{name: "overflow-sum", in: "290y290y", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDuration(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDuration(%q) = %s, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
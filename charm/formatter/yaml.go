@@ -6,31 +6,42 @@ package formatter
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/goccy/go-yaml"
 )
 
 // ToYAML will convert the provided data value into a YAML string, optionally
-// masking the values if the mask flag is true.
+// masking the values if the mask flag is true. See [WriteYAML] for writing
+// directly to an [io.Writer].
 func ToYAML(data any, mask bool, indent int) string {
-	if data == nil {
-		return "null"
+	var b strings.Builder
+	if err := WriteYAML(&b, data, mask, indent); err != nil {
+		return fmt.Sprintf("error: %v", err)
 	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
 
-	indent = max(indent, 2)
-
-	var output any
-	if mask {
-		output = MaskValue(data)
-	} else {
-		output = data
+// WriteYAML is the same as [ToYAML], however it encodes the data value directly
+// to w (followed by a newline), rather than returning a string. This is useful
+// when writing large values to files, as the output doesn't have to be copied
+// into a string first. Note that if mask is true, a masked copy of the data value
+// is built first (see [MaskValue]), as the YAML encoder builds the full document
+// in memory before writing it regardless (unlike [WriteJSON]).
+func WriteYAML(w io.Writer, data any, mask bool, indent int) error {
+	if data == nil {
+		_, err := io.WriteString(w, "null\n")
+		return err
 	}
 
-	b, err := yaml.MarshalWithOptions(output, yaml.Indent(indent), yaml.UseJSONMarshaler())
-	if err != nil {
-		return fmt.Sprintf("error: %v", err)
+	if mask {
+		data = MaskValue(data)
 	}
 
-	return strings.TrimSuffix(string(b), "\n")
+	return yaml.NewEncoder(
+		w,
+		yaml.Indent(max(indent, 2)),
+		yaml.UseJSONMarshaler(),
+	).Encode(data)
 }
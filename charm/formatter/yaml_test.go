@@ -5,6 +5,7 @@
 package formatter
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -190,3 +191,27 @@ value: "***"`,
 		})
 	}
 }
+
+func TestWriteYAML(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{"name": "test", "nested": map[string]any{"value": 123}}
+
+	for _, mask := range []bool{false, true} {
+		var b strings.Builder
+		if err := WriteYAML(&b, input, mask, 4); err != nil {
+			t.Fatalf("WriteYAML() error = %v", err)
+		}
+		if want := ToYAML(input, mask, 4) + "\n"; b.String() != want {
+			t.Errorf("WriteYAML(mask=%v) = %q, want %q", mask, b.String(), want)
+		}
+	}
+
+	var b strings.Builder
+	if err := WriteYAML(&b, nil, true, 2); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	if b.String() != "null\n" {
+		t.Errorf("WriteYAML(nil) = %q, want %q", b.String(), "null\n")
+	}
+}
@@ -19,6 +19,7 @@ import (
 type model struct {
 	width  int
 	height int
+	debug  bool
 }
 
 func (m model) Init() tea.Cmd {
@@ -40,6 +41,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			return m, tea.Quit
+		case "d":
+			m.debug = !m.debug
+			return m, nil
 		}
 	}
 	return m, nil
@@ -54,12 +58,13 @@ func (m model) View() tea.View {
 	footer := lipgloss.NewStyle().
 		Foreground(charmtone.Oyster).
 		AlignVertical(lipgloss.Bottom).
-		Render("Press any key to swap the cards, or q to quit.")
+		Render("Press any key to swap the cards, d to toggle debug, or q to quit.")
 
-	layout.RenderView(
+	layout.RenderDebug(
 		&view,
 		m.width,
 		m.height,
+		m.debug,
 		layout.Stack(
 			layout.Vertical(
 				layout.Horizontal(
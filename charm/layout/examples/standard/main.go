@@ -66,9 +66,21 @@ func (m model) View() tea.View {
 		m.width,
 		m.height,
 		layout.Vertical(
-			layout.BottomPadding(1, layout.Columns(
-				layout.NewCell(m.sidebar).Size(20), // .Percent(0.3).HideSize(20),
-				layout.NewCell(m.reader),
+			layout.BottomPadding(1, layout.Responsive(
+				layout.Breakpoint{
+					// Hide the sidebar on narrow terminals.
+					MinWidth: 0,
+					Layout:   func(_, _ int) any { return m.reader },
+				},
+				layout.Breakpoint{
+					MinWidth: 80,
+					Layout: func(_, _ int) any {
+						return layout.Columns(
+							layout.NewCell(m.sidebar).Size(20),
+							layout.NewCell(m.reader),
+						)
+					},
+				},
 			)),
 			m.statusbar,
 		),
@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/lrstanley/x/charm/layout"
 )
 
 type readerModel struct {
@@ -43,9 +44,9 @@ func (m *readerModel) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
-func (m *readerModel) View(availableWidth, availableHeight int) string {
-	return m.style.
-		Width(availableWidth).
-		Height(availableHeight).
-		Render(m.items[m.selected])
+func (m *readerModel) View(availableWidth, availableHeight int) layout.Layout {
+	return layout.Frame(
+		m.style.Width(availableWidth).Height(availableHeight),
+		layout.TextLayer("", m.items[m.selected], lipgloss.NewStyle()),
+	)
 }
@@ -33,10 +33,11 @@ type sidebarModel struct {
 	uuid
 	items []*item
 	style lipgloss.Style
+	focus *layout.FocusManager
 }
 
 func newSidebar() *sidebarModel {
-	return &sidebarModel{
+	m := &sidebarModel{
 		items: []*item{
 			{text: "Home", selected: true},
 			{text: "Settings"},
@@ -45,7 +46,10 @@ func newSidebar() *sidebarModel {
 		style: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(charmtone.Oyster),
+		focus: layout.NewFocusManager(),
 	}
+	m.focus.Focus(m.items[0].UUID())
+	return m
 }
 
 func (m *sidebarModel) Init() tea.Cmd {
@@ -54,10 +58,8 @@ func (m *sidebarModel) Init() tea.Cmd {
 
 func (m *sidebarModel) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
-	case layout.LayerMouseMsg:
-		if _, ok := msg.MouseMsg.(tea.MouseClickMsg); !ok || msg.Mouse().Button != tea.MouseLeft {
-			return nil
-		}
+	case layout.FocusMsg:
+		// Focus changes from both tab/shift+tab and mouse clicks.
 		for i, item := range m.items {
 			if item.UUID() == msg.LayerID {
 				return func() tea.Msg {
@@ -71,20 +73,20 @@ func (m *sidebarModel) Update(msg tea.Msg) tea.Cmd {
 		}
 		return nil
 	}
-	return nil
+	return m.focus.Update(msg)
 }
 
 func (m *sidebarModel) View() layout.Layout {
 	layers := make([]any, 0, len(m.items))
 
 	for _, item := range m.items {
-		layers = append(layers, item)
+		layers = append(layers, m.focus.Focusable(item))
 	}
 
 	layers = append(layers, layout.Space()) // TODO: this doesn't work.
 
-	return layout.Frame(
+	return m.focus.Root(layout.Frame(
 		m.style,
 		layout.Vertical(layers...),
-	)
+	))
 }
@@ -0,0 +1,185 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// FocusMsg is sent by [FocusManager.Update] when the focused layer changes.
+type FocusMsg struct {
+	// LayerID is the ID of the newly focused layer, or empty if nothing is focused.
+	LayerID string
+	// PreviousLayerID is the ID of the previously focused layer, or empty if
+	// nothing was focused.
+	PreviousLayerID string
+}
+
+// FocusManager tracks keyboard focus across layers. Layers are marked as
+// focusable with [FocusManager.Focusable], and the focus order is the order in
+// which focusable layers were rendered (within [FocusManager.Root]), which for
+// most layouts is the reading order.
+//
+// The focus manager doesn't change how layers are rendered. Models should check
+// [FocusManager.IsFocused] with their layer ID when rendering, to render the
+// focused layer distinctly. Use [FocusManager.Update] in the Update method of
+// your model, to cycle focus with tab/shift+tab, and focus layers when clicked.
+//
+// A FocusManager is not concurrent-safe, and is intended to be used from the
+// Update and View methods of a [tea.Model].
+type FocusManager struct {
+	order     []string // Focusable layer IDs, from the last completed render.
+	rendering []string // Focusable layer IDs, collected during the current render.
+	focused   string
+}
+
+// NewFocusManager creates a new [FocusManager], with nothing focused.
+func NewFocusManager() *FocusManager {
+	return &FocusManager{}
+}
+
+var _ Layout = (*focusRootLayout)(nil)
+
+type focusRootLayout struct {
+	manager *FocusManager
+	child   any
+}
+
+// Root wraps the root of the layout tree (e.g. what is passed to [RenderView]),
+// so the focus order can be updated each time it is rendered. Only focusable
+// layers rendered within Root are part of the focus order.
+func (f *FocusManager) Root(child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &focusRootLayout{manager: f, child: child}
+}
+
+func (r *focusRootLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	r.manager.rendering = r.manager.rendering[:0]
+	layer := resolveLayer(r.child, availableWidth, availableHeight)
+	r.manager.order = slices.Clone(r.manager.rendering)
+	return layer
+}
+
+var _ Layout = (*focusableLayout)(nil)
+
+type focusableLayout struct {
+	manager *FocusManager
+	child   any
+}
+
+// Focusable marks the rendered layer of child as focusable. The layer must have
+// an ID (e.g. the model implements GetID, ID or UUID, or the layer has an ID
+// set), otherwise it is ignored.
+func (f *FocusManager) Focusable(child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &focusableLayout{manager: f, child: child}
+}
+
+func (r *focusableLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	layer := resolveLayer(r.child, availableWidth, availableHeight)
+	if layer != nil && layer.GetID() != "" && !slices.Contains(r.manager.rendering, layer.GetID()) {
+		r.manager.rendering = append(r.manager.rendering, layer.GetID())
+	}
+	return layer
+}
+
+// Order returns the IDs of all focusable layers, in focus order, as of the last
+// render.
+func (f *FocusManager) Order() []string {
+	return slices.Clone(f.order)
+}
+
+// Focused returns the ID of the focused layer, or an empty string if nothing is
+// focused.
+func (f *FocusManager) Focused() string {
+	return f.focused
+}
+
+// IsFocused returns true if the layer with the provided ID is focused.
+func (f *FocusManager) IsFocused(id string) bool {
+	return id != "" && f.focused == id
+}
+
+// Focus focuses the layer with the provided ID.
+func (f *FocusManager) Focus(id string) {
+	f.focused = id
+}
+
+// Blur removes focus, so nothing is focused.
+func (f *FocusManager) Blur() {
+	f.focused = ""
+}
+
+// Next focuses the next focusable layer, wrapping around to the first layer. If
+// nothing is focused (or the focused layer is no longer rendered), the first
+// layer is focused. Returns the ID of the focused layer.
+func (f *FocusManager) Next() string {
+	return f.move(1)
+}
+
+// Previous focuses the previous focusable layer, wrapping around to the last
+// layer. If nothing is focused (or the focused layer is no longer rendered), the
+// last layer is focused. Returns the ID of the focused layer.
+func (f *FocusManager) Previous() string {
+	return f.move(-1)
+}
+
+// move moves focus by delta positions in the focus order.
+func (f *FocusManager) move(delta int) string {
+	if len(f.order) == 0 {
+		return f.focused
+	}
+
+	i := slices.Index(f.order, f.focused)
+	switch {
+	case i < 0 && delta > 0:
+		i = 0
+	case i < 0:
+		i = len(f.order) - 1
+	default:
+		i = (i + delta + len(f.order)) % len(f.order)
+	}
+
+	f.focused = f.order[i]
+	return f.focused
+}
+
+// Update handles focus changes: tab and shift+tab cycle focus (see
+// [FocusManager.Next] and [FocusManager.Previous]), and left-clicking a focusable
+// layer focuses it. If the focus changed, a [FocusMsg] is returned.
+func (f *FocusManager) Update(msg tea.Msg) tea.Cmd {
+	previous := f.focused
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "tab":
+			f.Next()
+		case "shift+tab":
+			f.Previous()
+		}
+	case LayerMouseMsg:
+		click, ok := msg.MouseMsg.(tea.MouseClickMsg)
+		if ok && click.Button == tea.MouseLeft && slices.Contains(f.order, msg.LayerID) {
+			f.Focus(msg.LayerID)
+		}
+	}
+
+	if f.focused == previous {
+		return nil
+	}
+
+	focused := f.focused
+	return func() tea.Msg {
+		return FocusMsg{LayerID: focused, PreviousLayerID: previous}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func TestFocusManager(t *testing.T) {
+	t.Parallel()
+
+	fm := NewFocusManager()
+	root := fm.Root(Vertical(
+		fm.Focusable(lipgloss.NewLayer("a").ID("a")),
+		"not focusable",
+		Horizontal(
+			fm.Focusable(lipgloss.NewLayer("b").ID("b")),
+			fm.Focusable(lipgloss.NewLayer("no id")),
+			fm.Focusable(lipgloss.NewLayer("c").ID("c")),
+		),
+	))
+
+	_ = RenderString(20, 5, root)
+
+	if got, want := fm.Order(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("Order() = %v, want %v", got, want)
+	}
+
+	tab := tea.KeyPressMsg{Code: tea.KeyTab}
+	shiftTab := tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}
+
+	steps := []struct {
+		msg  tea.Msg
+		want string
+	}{
+		{msg: tab, want: "a"},
+		{msg: tab, want: "b"},
+		{msg: tab, want: "c"},
+		{msg: tab, want: "a"},
+		{msg: shiftTab, want: "c"},
+		{msg: LayerMouseMsg{MouseMsg: tea.MouseClickMsg{Button: tea.MouseLeft}, LayerID: "b"}, want: "b"},
+		{msg: LayerMouseMsg{MouseMsg: tea.MouseClickMsg{Button: tea.MouseLeft}, LayerID: "unknown"}, want: "b"},
+	}
+
+	for i, step := range steps {
+		previous := fm.Focused()
+		cmd := fm.Update(step.msg)
+
+		if got := fm.Focused(); got != step.want {
+			t.Fatalf("step %d: Focused() = %q, want %q", i, got, step.want)
+		}
+		if !fm.IsFocused(step.want) {
+			t.Fatalf("step %d: IsFocused(%q) = false", i, step.want)
+		}
+
+		if previous == step.want {
+			if cmd != nil {
+				t.Fatalf("step %d: expected no command when focus is unchanged", i)
+			}
+			continue
+		}
+
+		msg, ok := cmd().(FocusMsg)
+		if !ok || msg.LayerID != step.want || msg.PreviousLayerID != previous {
+			t.Fatalf("step %d: got %#v, want FocusMsg{%q, %q}", i, msg, step.want, previous)
+		}
+	}
+
+	// Re-rendering without the focused layer updates the order, and Next starts
+	// from the beginning.
+	_ = RenderString(20, 5, fm.Root(Vertical(
+		fm.Focusable(lipgloss.NewLayer("a").ID("a")),
+		fm.Focusable(lipgloss.NewLayer("c").ID("c")),
+	)))
+
+	if got, want := fm.Order(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("Order() = %v, want %v", got, want)
+	}
+	if got := fm.Next(); got != "a" {
+		t.Fatalf("Next() = %q, want %q", got, "a")
+	}
+
+	fm.Blur()
+	if got := fm.Previous(); got != "c" {
+		t.Fatalf("Previous() = %q, want %q", got, "c")
+	}
+}
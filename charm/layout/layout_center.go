@@ -35,3 +35,48 @@ func (r *centerLayout) Render(availableWidth, availableHeight int) *lipgloss.Lay
 		Y(max(0, (availableHeight-layer.Height())/2)).
 		Z(1)
 }
+
+var _ Layout = (*centerConstrainedLayout)(nil)
+
+type centerConstrainedLayout struct {
+	maxWidth  int
+	maxHeight int
+	child     any
+}
+
+// CenterConstrained creates a new layout that centers the provided child, like
+// [Center], however the child is resolved with at most maxWidth by maxHeight of
+// space. If the available space is smaller than the maximum (in either
+// dimension), the available space is passed through as-is, so the child shrinks
+// with the terminal. A maxWidth or maxHeight of 0 or less leaves that dimension
+// unconstrained.
+func CenterConstrained(maxWidth, maxHeight int, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &centerConstrainedLayout{maxWidth: maxWidth, maxHeight: maxHeight, child: child}
+}
+
+func (r *centerConstrainedLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if r.child == nil {
+		return nil
+	}
+
+	width, height := availableWidth, availableHeight
+	if r.maxWidth > 0 {
+		width = min(width, r.maxWidth)
+	}
+	if r.maxHeight > 0 {
+		height = min(height, r.maxHeight)
+	}
+
+	layer := resolveLayer(r.child, width, height)
+	if layer == nil {
+		return nil
+	}
+
+	return layer.
+		X(max(0, (availableWidth-layer.Width())/2)).
+		Y(max(0, (availableHeight-layer.Height())/2)).
+		Z(1)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+// fillModel fills all of the space it is given.
+type fillModel struct{}
+
+func (fillModel) View(availableWidth, availableHeight int) string {
+	line := strings.Repeat("#", availableWidth)
+	lines := make([]string, availableHeight)
+	for i := range lines {
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestCenterConstrained(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		maxWidth  int
+		maxHeight int
+		want      []string
+	}{
+		{
+			name:      "larger-than-max",
+			width:     8,
+			height:    6,
+			maxWidth:  4,
+			maxHeight: 2,
+			want:      []string{"", "", "  ####", "  ####"},
+		},
+		{
+			name:      "smaller-than-max",
+			width:     3,
+			height:    2,
+			maxWidth:  4,
+			maxHeight: 4,
+			want:      []string{"###", "###"},
+		},
+		{
+			name:      "narrower-than-max",
+			width:     3,
+			height:    6,
+			maxWidth:  4,
+			maxHeight: 2,
+			want:      []string{"", "", "###", "###"},
+		},
+		{
+			name:      "shorter-than-max",
+			width:     8,
+			height:    1,
+			maxWidth:  4,
+			maxHeight: 2,
+			want:      []string{"  ####"},
+		},
+		{
+			name:      "unconstrained-height",
+			width:     6,
+			height:    3,
+			maxWidth:  2,
+			maxHeight: 0,
+			want:      []string{"  ##", "  ##", "  ##"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layout := CenterConstrained(tt.maxWidth, tt.maxHeight, fillModel{})
+
+			canvas := lipgloss.NewCanvas(tt.width, tt.height)
+			canvas.Compose(lipgloss.NewCompositor(layout.Render(tt.width, tt.height)))
+			assertLines(t, canvas.Render(), tt.want)
+		})
+	}
+}
+
+func TestCenterConstrained_nil(t *testing.T) {
+	t.Parallel()
+
+	if CenterConstrained(10, 10, nil) != nil {
+		t.Fatal("expected nil layout for nil child")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var _ Layout = (*clipLayout)(nil)
+
+type clipLayout struct {
+	x, y  int
+	child any
+}
+
+// Clip creates a new layout which positions the provided child at the given
+// offset, cropping any part of the child which falls outside of the available
+// space (including negative offsets), rather than shifting the child back into
+// view. This is useful for content which is meant to be partially off-screen,
+// like a card peeking in from the left edge of a scrolling carousel. Wide
+// characters which are only partially visible are replaced with spaces.
+//
+// Note that the child is flattened into a single layer, so layer IDs of nested
+// layers (other than the child itself) are not preserved for mouse events.
+func Clip(x, y int, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &clipLayout{x: x, y: y, child: child}
+}
+
+func (r *clipLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if IsSpace(r.child) {
+		return nil
+	}
+
+	layer := resolveLayer(r.child, availableWidth, availableHeight)
+	if layer == nil {
+		return nil
+	}
+
+	left, top := max(0, -r.x), max(0, -r.y)
+	width := availableWidth - max(0, r.x)
+	height := availableHeight - max(0, r.y)
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	lines := strings.Split(lipgloss.NewCompositor(layer).Render(), "\n")
+	if top >= len(lines) {
+		return nil
+	}
+	lines = lines[top:min(len(lines), top+height)]
+
+	for i := range lines {
+		lines[i] = ansi.Truncate(cutLeft(lines[i], left), width, "")
+	}
+
+	return lipgloss.NewLayer(strings.Join(lines, "\n")).
+		ID(layer.GetID()).
+		X(max(0, r.x)).
+		Y(max(0, r.y))
+}
+
+// cutLeft removes the first n cells of s. Unlike [ansi.TruncateLeft], wide
+// characters which straddle the cut are replaced with spaces, rather than kept,
+// so the remaining content stays aligned.
+func cutLeft(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+
+	cut := ansi.TruncateLeft(s, n, "")
+	if extra := ansi.StringWidth(cut) - max(0, ansi.StringWidth(s)-n); extra > 0 {
+		return strings.Repeat(" ", extra) + ansi.TruncateLeft(s, n+extra, "")
+	}
+	return cut
+}
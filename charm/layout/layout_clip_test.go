@@ -0,0 +1,99 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestClip(t *testing.T) {
+	t.Parallel()
+
+	child := "abcdef\nghijkl\nmnopqr"
+
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "no-offset",
+			width:  10,
+			height: 5,
+			layout: Clip(0, 0, child),
+			want:   []string{"abcdef", "ghijkl", "mnopqr"},
+		},
+		{
+			name:   "negative-offset",
+			width:  10,
+			height: 5,
+			layout: Clip(-2, -1, child),
+			want:   []string{"ijkl", "opqr"},
+		},
+		{
+			name:   "positive-offset",
+			width:  5,
+			height: 3,
+			layout: Stack(fillModel{}, Clip(2, 1, child)),
+			want:   []string{"#####", "##abc", "##ghi"},
+		},
+		{
+			name:   "outside-right",
+			width:  10,
+			height: 5,
+			layout: Clip(10, 0, child),
+			want:   nil,
+		},
+		{
+			name:   "outside-top",
+			width:  10,
+			height: 5,
+			layout: Clip(0, -3, child),
+			want:   nil,
+		},
+		{
+			name:   "wide-left-edge",
+			width:  10,
+			height: 1,
+			layout: Clip(-1, 0, "日本語"),
+			want:   []string{" 本語"},
+		},
+		{
+			name:   "wide-right-edge",
+			width:  5,
+			height: 1,
+			layout: Clip(0, 0, "日本語"),
+			want:   []string{"日本"},
+		},
+		{
+			name:   "wide-both-edges",
+			width:  4,
+			height: 2,
+			layout: Clip(-1, 0, "日本語\nabcdef"),
+			want:   []string{" 本", "bcde"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertLines(t, RenderString(tt.width, tt.height, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestClip_preservesID(t *testing.T) {
+	t.Parallel()
+
+	layer := Clip(-1, 0, lipgloss.NewLayer("card").ID("card")).Render(10, 1)
+	if layer == nil || layer.GetID() != "card" {
+		t.Fatalf("layer = %v, want ID %q", layer, "card")
+	}
+}
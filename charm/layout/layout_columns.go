@@ -4,12 +4,17 @@
 
 package layout
 
-import "charm.land/lipgloss/v2"
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
 
 var _ Layout = (*columnsLayout)(nil)
 
 type columnsLayout struct {
-	cells []*Cell
+	cells   []*Cell
+	reverse bool
 }
 
 // Columns creates a new horizontal layout with the provided cells, where each cell
@@ -22,6 +27,18 @@ func Columns(cells ...*Cell) Layout {
 	return &columnsLayout{cells: cells}
 }
 
+// ColumnsReverse is the same as [Columns], but cells are arranged right to left
+// (e.g. for right-to-left UIs), so the first cell is the rightmost. If the cells
+// don't fill the available space, they are placed starting from the left edge, so
+// add a cell without a size or percentage as the last cell to anchor them to the
+// right edge instead.
+func ColumnsReverse(cells ...*Cell) Layout {
+	if len(cells) == 0 {
+		return nil
+	}
+	return &columnsLayout{cells: cells, reverse: true}
+}
+
 func (r *columnsLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
 	if len(r.cells) == 0 {
 		return nil
@@ -122,6 +139,11 @@ func (r *columnsLayout) Render(availableWidth, availableHeight int) *lipgloss.La
 		}
 	}
 
+	if r.reverse {
+		slices.Reverse(visibleCells)
+		slices.Reverse(sizes)
+	}
+
 	xOffset := 0
 	for i, cell := range visibleCells {
 		size := sizes[i]
@@ -4,7 +4,12 @@
 
 package layout
 
-import "charm.land/lipgloss/v2"
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
 
 var _ Layout = (*frameLayout)(nil)
 
@@ -48,3 +53,96 @@ func (r *frameLayout) Render(availableWidth, availableHeight int) *lipgloss.Laye
 			Render(),
 	).Z(1).AddLayers(layer.X(hFrame / 2).Y(vFrame / 2).Z(2))
 }
+
+var _ Layout = (*titledFrameLayout)(nil)
+
+type titledFrameLayout struct {
+	style  lipgloss.Style
+	title  string
+	footer string
+	child  any
+}
+
+// TitledFrame is similar to [Frame], however the title is overlaid on the top
+// border, and the footer (if any) on the bottom border. Both are truncated to fit
+// within the border. The style must have a top (or bottom) border for the title
+// (or footer) to be shown.
+func TitledFrame(style lipgloss.Style, title, footer string, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &titledFrameLayout{style: style, title: title, footer: footer, child: child}
+}
+
+func (r *titledFrameLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if r.child == nil {
+		return nil
+	}
+
+	hFrame := r.style.GetHorizontalFrameSize()
+	vFrame := r.style.GetVerticalFrameSize()
+
+	layer := resolveLayer(
+		r.child,
+		max(0, availableWidth-hFrame),
+		max(0, availableHeight-vFrame),
+	)
+	if layer == nil {
+		return nil
+	}
+
+	// Space between the left and right borders, excluding margins.
+	left := r.style.GetMarginLeft() + r.style.GetBorderLeftSize()
+	right := r.style.GetMarginRight() + r.style.GetBorderRightSize()
+
+	// Grow the frame to fit the title/footer where possible, otherwise they will be
+	// truncated.
+	width := layer.Width() + hFrame
+	if r.style.GetBorderTopSize() > 0 {
+		width = max(width, min(availableWidth, left+lipgloss.Width(r.title)+right+2))
+	}
+	if r.style.GetBorderBottomSize() > 0 {
+		width = max(width, min(availableWidth, left+lipgloss.Width(r.footer)+right+2))
+	}
+
+	// Margins are outside of the width/height of the style, and may not be the
+	// same on either side.
+	frame := lipgloss.NewLayer(
+		r.style.
+			Width(width - r.style.GetHorizontalMargins()).
+			Height(layer.Height() + vFrame - r.style.GetVerticalMargins()).
+			Render(),
+	).Z(1).AddLayers(
+		offsetLayer(
+			layer,
+			left+r.style.GetPaddingLeft(),
+			r.style.GetMarginTop()+r.style.GetBorderTopSize()+r.style.GetPaddingTop(),
+		).Z(2),
+	)
+
+	if r.title != "" && r.style.GetBorderTopSize() > 0 {
+		if label := borderLabel(r.title, frame.Width()-left-right); label != nil {
+			frame.AddLayers(label.X(left + 1).Y(r.style.GetMarginTop()).Z(3))
+		}
+	}
+
+	if r.footer != "" && r.style.GetBorderBottomSize() > 0 {
+		if label := borderLabel(r.footer, frame.Width()-left-right); label != nil {
+			frame.AddLayers(label.X(left + 1).Y(frame.Height() - r.style.GetMarginBottom() - 1).Z(3))
+		}
+	}
+
+	return frame
+}
+
+// borderLabel returns a layer containing the first line of label, truncated to
+// fit within width (leaving a single cell on either side, so the corners of the
+// border are still visible). Returns nil if there isn't enough space.
+func borderLabel(label string, width int) *lipgloss.Layer {
+	width -= 2
+	if width < 1 {
+		return nil
+	}
+	label, _, _ = strings.Cut(label, "\n")
+	return lipgloss.NewLayer(ansi.Truncate(label, width, truncateEllipsis))
+}
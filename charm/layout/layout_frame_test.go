@@ -0,0 +1,113 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestTitledFrame(t *testing.T) {
+	t.Parallel()
+
+	border := lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+
+	tests := []struct {
+		name   string
+		width  int
+		style  lipgloss.Style
+		title  string
+		footer string
+		want   []string
+	}{
+		{
+			name:  "short-title",
+			width: 20,
+			style: border,
+			title: "Hi",
+			want:  []string{"┌─Hi─┐", "│abc │", "└────┘"},
+		},
+		{
+			name:   "grows-to-fit",
+			width:  20,
+			style:  border,
+			title:  "Title",
+			footer: "Footer!",
+			want:   []string{"┌─Title───┐", "│abc      │", "└─Footer!─┘"},
+		},
+		{
+			name:   "truncated",
+			width:  8,
+			style:  border,
+			title:  "A long title",
+			footer: "A long footer",
+			want:   []string{"┌─A l…─┐", "│abc   │", "└─A l…─┘"},
+		},
+		{
+			name:  "multiline-title",
+			width: 20,
+			style: border,
+			title: "one\ntwo",
+			want:  []string{"┌─one─┐", "│abc  │", "└─────┘"},
+		},
+		{
+			name:   "no-top-border",
+			width:  20,
+			style:  border.BorderTop(false),
+			title:  "Title",
+			footer: "foot",
+			want:   []string{"│abc   │", "└─foot─┘"},
+		},
+		{
+			name:   "no-bottom-border",
+			width:  20,
+			style:  border.BorderBottom(false),
+			title:  "Title",
+			footer: "foot",
+			want:   []string{"┌─Title─┐", "│abc    │"},
+		},
+		{
+			name:   "margins",
+			width:  20,
+			style:  border.Margin(1, 2),
+			title:  "Title",
+			footer: "Foot",
+			want:   []string{"", "  ┌─Title─┐", "  │abc    │", "  └─Foot──┘"},
+		},
+		{
+			name:   "uneven-margins-and-padding",
+			width:  20,
+			style:  border.MarginLeft(3).PaddingLeft(1),
+			title:  "Title",
+			footer: "Foot",
+			want:   []string{"   ┌─Title─┐", "   │ abc   │", "   └─Foot──┘"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RenderString(tt.width, 10, TitledFrame(tt.style, tt.title, tt.footer, "abc"))
+			assertLines(t, got, tt.want)
+		})
+	}
+}
+
+func TestTitledFrame_renderTwice(t *testing.T) {
+	t.Parallel()
+
+	// Layers are owned by the caller, so framing must not move the layer itself.
+	child := lipgloss.NewLayer("abc")
+	layout := TitledFrame(lipgloss.NewStyle().Border(lipgloss.NormalBorder()), "Hi", "", child)
+
+	for range 3 {
+		assertLines(t, RenderString(10, 5, layout), []string{"┌─Hi─┐", "│abc │", "└────┘"})
+	}
+	if child.GetX() != 0 || child.GetY() != 0 {
+		t.Fatalf("child moved to %d,%d", child.GetX(), child.GetY())
+	}
+}
@@ -4,12 +4,17 @@
 
 package layout
 
-import "charm.land/lipgloss/v2"
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
 
 var _ Layout = (*horizontalLayout)(nil)
 
 type horizontalLayout struct {
 	children []any
+	reverse  bool
 }
 
 // Horizontal creates a new horizontal layout with the provided children.
@@ -21,6 +26,20 @@ func Horizontal(children ...any) Layout {
 	return &horizontalLayout{children: children}
 }
 
+// HorizontalReverse is the same as [Horizontal], but lays out the children from
+// right to left (e.g. for right-to-left UIs), so the first child is the rightmost.
+// Children are still sized in the order they are provided, so the first child has
+// priority over the available space. Like [Horizontal], children are placed
+// starting from the left edge, so add a [Space] as the last child to anchor them
+// to the right edge instead.
+func HorizontalReverse(children ...any) Layout {
+	children = filterNil(children)
+	if len(children) == 0 {
+		return nil
+	}
+	return &horizontalLayout{children: children, reverse: true}
+}
+
 func (r *horizontalLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
 	if len(r.children) == 0 {
 		return nil
@@ -53,6 +72,10 @@ func (r *horizontalLayout) Render(availableWidth, availableHeight int) *lipgloss
 		return layers[0].Z(1)
 	}
 
+	if r.reverse {
+		slices.Reverse(layers)
+	}
+
 	xOffset := 0
 	spaceIndex := 0
 	spaceDistrib := calculateSpaceDistribution(spaces, max(0, availableWidth-totalFixedWidth))
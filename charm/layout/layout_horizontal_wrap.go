@@ -0,0 +1,69 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import "charm.land/lipgloss/v2"
+
+var _ Layout = (*horizontalWrapLayout)(nil)
+
+type horizontalWrapLayout struct {
+	children []any
+}
+
+// HorizontalWrap creates a new horizontal layout with the provided children,
+// which wraps children onto a new row when they would overflow the available
+// width (e.g. a list of tags/chips). Each row is as tall as its tallest child.
+// Unlike [Horizontal], spaces are ignored.
+func HorizontalWrap(children ...any) Layout {
+	children = filterNil(children)
+	if len(children) == 0 {
+		return nil
+	}
+	return &horizontalWrapLayout{children: children}
+}
+
+func (r *horizontalWrapLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if len(r.children) == 0 {
+		return nil
+	}
+
+	layers := make([]*lipgloss.Layer, 0, len(r.children))
+
+	var xOffset, yOffset, rowHeight int
+	for _, child := range r.children {
+		if IsSpace(child) {
+			continue // Spaces are ignored in HorizontalWrap.
+		}
+
+		layer := resolveLayer(child, availableWidth, max(0, availableHeight-yOffset))
+		if layer == nil {
+			continue
+		}
+
+		width := layer.GetX() + layer.Width()
+		if xOffset > 0 && xOffset+width > availableWidth {
+			xOffset = 0
+			yOffset += rowHeight
+			rowHeight = 0
+		}
+
+		moved := offsetLayer(layer, xOffset, yOffset).Z(2)
+
+		xOffset += width
+		rowHeight = max(rowHeight, moved.GetY()-yOffset+moved.Height())
+		layers = append(layers, moved)
+	}
+
+	switch len(layers) {
+	case 0:
+		return nil
+	case 1:
+		return layers[0].Z(1)
+	}
+
+	return lipgloss.NewLayer("").
+		Z(1).
+		AddLayers(layers...)
+}
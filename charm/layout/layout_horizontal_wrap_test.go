@@ -0,0 +1,88 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestHorizontalWrap(t *testing.T) {
+	t.Parallel()
+
+	tags := []any{"[alpha]", "[beta]", Space(), "[gamma]", "[delta]", "[epsilon]", "tall\nchip", "[z]"}
+
+	tests := []struct {
+		name  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "single-row",
+			width: 80,
+			want:  []string{"[alpha][beta][gamma][delta][epsilon]tall[z]", "                                    chip"},
+		},
+		{
+			name:  "multiple-rows",
+			width: 20,
+			want: []string{
+				"[alpha][beta][gamma]",
+				"[delta][epsilon]tall",
+				"                chip",
+				"[z]",
+			},
+		},
+		{
+			name:  "child-wider-than-available",
+			width: 5,
+			want: []string{
+				"[alpha]",
+				"[beta]",
+				"[gamma]",
+				"[delta]",
+				"[epsilon]",
+				"tall",
+				"chip",
+				"[z]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RenderString(tt.width, 10, HorizontalWrap(tags...))
+			lines := strings.Split(got, "\n")
+			for i := range lines {
+				lines[i] = strings.TrimRight(lines[i], " ")
+			}
+			for i := range tt.want {
+				tt.want[i] = strings.TrimRight(tt.want[i], " ")
+			}
+
+			if strings.Join(lines, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("HorizontalWrap() =\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestHorizontalWrap_RenderTwice(t *testing.T) {
+	t.Parallel()
+
+	// Layers are owned by the caller, so wrapping must not move the layer itself.
+	chip := lipgloss.NewLayer("[chip]").X(1)
+	layout := HorizontalWrap("[alpha]", chip)
+
+	for range 3 {
+		assertLines(t, RenderString(10, 5, layout), []string{"[alpha]", " [chip]"})
+	}
+	if chip.GetX() != 1 || chip.GetY() != 0 || chip.GetZ() != 0 {
+		t.Fatalf("chip moved to %d,%d,%d", chip.GetX(), chip.GetY(), chip.GetZ())
+	}
+}
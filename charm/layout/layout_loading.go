@@ -0,0 +1,104 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// DefaultSpinnerFrames are the default spinner frames used by
+	// [LoadingOverlay].
+	DefaultSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+	// DefaultLoadingStyle is the default style of the spinner and message box
+	// used by [LoadingOverlay].
+	DefaultLoadingStyle = lipgloss.NewStyle().
+				Padding(0, 1).
+				Border(lipgloss.RoundedBorder())
+)
+
+var _ Layout = (*loadingLayout)(nil)
+
+type loadingLayout struct {
+	style      lipgloss.Style
+	frames     []string
+	background any
+	message    string
+	frame      int
+}
+
+// LoadingOverlay creates a new layout which renders the background at the full
+// available size, with a spinner and message centered on top of it. The spinner
+// uses [DefaultSpinnerFrames], and the frame shown is frame modulo the number of
+// frames, so the model should increment frame on each tick (e.g. using
+// [tea.Tick]). Uses [DefaultLoadingStyle], see [LoadingOverlayWithStyle] to
+// customize the style and frames.
+//
+// The background is flattened into a single layer, so it isn't interactive
+// while loading.
+func LoadingOverlay(background any, message string, frame int) Layout {
+	return LoadingOverlayWithStyle(DefaultLoadingStyle, DefaultSpinnerFrames, background, message, frame)
+}
+
+// LoadingOverlayWithStyle is the same as [LoadingOverlay], but with a custom
+// style for the spinner and message box, and custom spinner frames. If frames
+// is empty, [DefaultSpinnerFrames] is used.
+func LoadingOverlayWithStyle(style lipgloss.Style, frames []string, background any, message string, frame int) Layout {
+	if len(frames) == 0 {
+		frames = DefaultSpinnerFrames
+	}
+	return &loadingLayout{
+		style:      style,
+		frames:     frames,
+		background: background,
+		message:    message,
+		frame:      frame,
+	}
+}
+
+func (r *loadingLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if availableWidth <= 0 || availableHeight <= 0 {
+		return nil
+	}
+
+	// Always fill the available space, so the overlay is centered even without a
+	// background.
+	content := blankBlock(availableWidth, availableHeight)
+	if r.background != nil && !IsSpace(r.background) {
+		if layer := resolveLayer(r.background, availableWidth, availableHeight); layer != nil {
+			content = lipgloss.NewCompositor(layer).Render()
+		}
+	}
+	layers := []*lipgloss.Layer{lipgloss.NewLayer(content)}
+
+	overlay := lipgloss.NewLayer(r.style.Render(r.content())).Z(1)
+	layers = append(layers, overlay.
+		X(max(0, (availableWidth-overlay.Width())/2)).
+		Y(max(0, (availableHeight-overlay.Height())/2)))
+
+	return lipgloss.NewLayer("").
+		Z(1).
+		AddLayers(layers...)
+}
+
+// content returns the spinner and message. The spinner is padded to the width
+// of the widest frame, so the overlay doesn't shift between frames.
+func (r *loadingLayout) content() string {
+	var frameWidth int
+	for _, f := range r.frames {
+		frameWidth = max(frameWidth, ansi.StringWidth(f))
+	}
+
+	frame := r.frames[((r.frame%len(r.frames))+len(r.frames))%len(r.frames)]
+	out := frame + strings.Repeat(" ", frameWidth-ansi.StringWidth(frame))
+	if r.message != "" {
+		out += " " + r.message
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestLoadingOverlay(t *testing.T) {
+	t.Parallel()
+
+	background := Vertical("aaaaaaaaaaaa", "bbbbbbbbbbbb", "cccccccccccc")
+	frames := []string{"-", "\\\\", "|", "//"}
+
+	tests := []struct {
+		name  string
+		frame int
+		want  []string
+	}{
+		{
+			name:  "first-frame",
+			frame: 0,
+			want:  []string{"aaaaaaaaaaaa", "bb-  loadbbb", "cccccccccccc"},
+		},
+		{
+			name:  "wide-frame",
+			frame: 1,
+			want:  []string{"aaaaaaaaaaaa", "bb\\\\ loadbbb", "cccccccccccc"},
+		},
+		{
+			name:  "wraps-around",
+			frame: 6,
+			want:  []string{"aaaaaaaaaaaa", "bb|  loadbbb", "cccccccccccc"},
+		},
+		{
+			name:  "negative",
+			frame: -1,
+			want:  []string{"aaaaaaaaaaaa", "bb// loadbbb", "cccccccccccc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			layout := LoadingOverlayWithStyle(lipgloss.NewStyle(), frames, background, "load", tt.frame)
+			assertLines(t, RenderString(12, 3, layout), tt.want)
+		})
+	}
+}
+
+func TestLoadingOverlay_defaults(t *testing.T) {
+	t.Parallel()
+
+	assertLines(t, RenderString(14, 5, LoadingOverlay(nil, "wait", 0)), []string{
+		"",
+		"  ╭────────╮",
+		"  │ ⠋ wait │",
+		"  ╰────────╯",
+	})
+}
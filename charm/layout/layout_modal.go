@@ -0,0 +1,128 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// ModalBackdropLayerID is the layer ID of the dimmed background of a [Modal],
+	// so clicking outside of the dialog produces a [LayerMouseMsg] with this ID.
+	ModalBackdropLayerID = "modal:backdrop"
+
+	// ModalDialogLayerID is the layer ID used for parts of a [Modal] dialog which
+	// don't have a layer ID of their own, so clicks inside the dialog are never
+	// reported as [ModalBackdropLayerID].
+	ModalDialogLayerID = "modal:dialog"
+)
+
+// DefaultModalBackdropStyle is the default style used to dim the background of
+// a [Modal].
+var DefaultModalBackdropStyle = lipgloss.NewStyle().
+	Faint(true).
+	Foreground(lipgloss.Color("240"))
+
+var _ Layout = (*modalLayout)(nil)
+
+type modalLayout struct {
+	style      lipgloss.Style
+	background any
+	dialog     any
+}
+
+// Modal creates a new layout which renders the background at the full available
+// size, dimmed with [DefaultModalBackdropStyle], with the dialog centered on top
+// of it. See [ModalWithStyle] for a custom backdrop style.
+//
+// The background is flattened into a single layer with the ID
+// [ModalBackdropLayerID], so clicking anywhere outside of the dialog produces a
+// [LayerMouseMsg] with that ID (e.g. to close the dialog on outside-click).
+func Modal(background, dialog any) Layout {
+	return ModalWithStyle(DefaultModalBackdropStyle, background, dialog)
+}
+
+// ModalWithStyle is like [Modal], but with a custom style used to dim the
+// background. Existing styling of the background is stripped before the style
+// is applied.
+func ModalWithStyle(style lipgloss.Style, background, dialog any) Layout {
+	if background == nil && dialog == nil {
+		return nil
+	}
+	return &modalLayout{style: style, background: background, dialog: dialog}
+}
+
+func (r *modalLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if availableWidth <= 0 || availableHeight <= 0 {
+		return nil
+	}
+
+	var content string
+	if r.background != nil && !IsSpace(r.background) {
+		if layer := resolveLayer(r.background, availableWidth, availableHeight); layer != nil {
+			content = lipgloss.NewCompositor(layer).Render()
+		}
+	}
+
+	// Z-indexes are absolute, and nested layers of the dialog can't be shifted,
+	// so the backdrop and catcher go below any Z-index a layout would use.
+	backdrop := lipgloss.NewLayer(r.dim(content, availableWidth, availableHeight)).
+		ID(ModalBackdropLayerID).
+		Z(-2)
+
+	if r.dialog == nil || IsSpace(r.dialog) {
+		return backdrop
+	}
+
+	dialog := resolveLayer(r.dialog, availableWidth, availableHeight)
+	if dialog == nil {
+		return backdrop
+	}
+
+	dialog = offsetLayer(
+		dialog,
+		max(0, (availableWidth-dialog.Width())/2),
+		max(0, (availableHeight-dialog.Height())/2),
+	).Z(max(1, dialog.GetZ()))
+
+	// Invisible layer directly below the dialog, which catches clicks on parts of
+	// the dialog that don't have a layer ID.
+	catcher := lipgloss.NewLayer(blankBlock(dialog.Width(), dialog.Height())).
+		ID(ModalDialogLayerID).
+		X(dialog.GetX()).
+		Y(dialog.GetY()).
+		Z(-1)
+
+	return lipgloss.NewLayer("").
+		Z(1).
+		AddLayers(backdrop, catcher, dialog)
+}
+
+// dim renders the content with the backdrop style, padded/cropped to the
+// provided dimensions.
+func (r *modalLayout) dim(content string, width, height int) string {
+	lines := strings.Split(ansi.Strip(content), "\n")
+
+	out := make([]string, height)
+	for i := range out {
+		var line string
+		if i < len(lines) {
+			line = ansi.Truncate(lines[i], width, "")
+		}
+		out[i] = r.style.Render(line + strings.Repeat(" ", width-ansi.StringWidth(line)))
+	}
+	return strings.Join(out, "\n")
+}
+
+// blankBlock returns a block of spaces with the provided dimensions.
+func blankBlock(width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	return strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", width)+"\n", height), "\n")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestModal(t *testing.T) {
+	t.Parallel()
+
+	got := RenderString(8, 4, Modal(fillModel{}, "ab\ncd"))
+	assertLines(t, ansi.Strip(got), []string{"########", "###ab###", "###cd###", "########"})
+
+	// The backdrop is dimmed, but the dialog isn't.
+	lines := strings.Split(got, "\n")
+	if !strings.Contains(lines[0], "\x1b[") {
+		t.Fatalf("backdrop should be styled, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "ab") {
+		t.Fatalf("dialog should be drawn as-is, got %q", lines[1])
+	}
+}
+
+func TestModal_withoutDialog(t *testing.T) {
+	t.Parallel()
+
+	got := RenderString(4, 2, ModalWithStyle(lipgloss.NewStyle(), "hello\nworld", nil))
+	assertLines(t, got, []string{"hell", "worl"})
+}
+
+func TestModal_hitTesting(t *testing.T) {
+	t.Parallel()
+
+	button := lipgloss.NewLayer("ok").ID("button")
+	dialog := Vertical("title", button)
+
+	comp := lipgloss.NewCompositor(Modal(fillModel{}, dialog).Render(9, 4))
+
+	tests := []struct {
+		x, y int
+		want string
+	}{
+		{x: 0, y: 0, want: ModalBackdropLayerID},
+		{x: 8, y: 3, want: ModalBackdropLayerID},
+		{x: 1, y: 1, want: ModalBackdropLayerID},
+		{x: 2, y: 1, want: ModalDialogLayerID},
+		{x: 6, y: 1, want: ModalDialogLayerID},
+		{x: 2, y: 2, want: "button"},
+		{x: 4, y: 2, want: ModalDialogLayerID},
+		{x: 7, y: 2, want: ModalBackdropLayerID},
+	}
+
+	for _, tt := range tests {
+		if got := comp.Hit(tt.x, tt.y).ID(); got != tt.want {
+			t.Errorf("Hit(%d, %d) = %q, want %q", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestModal_renderTwice(t *testing.T) {
+	t.Parallel()
+
+	// Layers are owned by the caller, so centering must not move the layer itself.
+	dialog := lipgloss.NewLayer("ab").X(1)
+	layout := ModalWithStyle(lipgloss.NewStyle(), nil, dialog)
+
+	for range 3 {
+		assertLines(t, RenderString(6, 3, layout), []string{"", "   ab"})
+	}
+	if dialog.GetX() != 1 || dialog.GetY() != 0 || dialog.GetZ() != 0 {
+		t.Fatalf("dialog moved to %d,%d,%d", dialog.GetX(), dialog.GetY(), dialog.GetZ())
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"math"
+
+	"charm.land/lipgloss/v2"
+)
+
+var (
+	_ Layout = (*xPercentLayout)(nil)
+	_ Layout = (*yPercentLayout)(nil)
+)
+
+type xPercentLayout struct {
+	percent float64
+	child   any
+}
+
+// XPercent creates a new layout that offsets the provided child from the left by
+// a percentage (0-1) of the available width, resolved at render time. Unlike
+// [lipgloss.Layer.X], this keeps the child positioned relative to the available
+// space when the terminal is resized. Any existing X offset of the child is
+// added to the resolved offset.
+func XPercent(percent float64, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &xPercentLayout{percent: clamp(percent, 0, 1), child: child}
+}
+
+func (r *xPercentLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if IsSpace(r.child) {
+		return nil
+	}
+
+	offset := percentOf(r.percent, availableWidth)
+
+	layer := resolveLayer(r.child, availableWidth-offset, availableHeight)
+	if layer == nil {
+		return nil
+	}
+
+	return offsetLayer(layer, offset, 0)
+}
+
+type yPercentLayout struct {
+	percent float64
+	child   any
+}
+
+// YPercent creates a new layout that offsets the provided child from the top by
+// a percentage (0-1) of the available height, resolved at render time. Unlike
+// [lipgloss.Layer.Y], this keeps the child positioned relative to the available
+// space when the terminal is resized. Any existing Y offset of the child is
+// added to the resolved offset.
+func YPercent(percent float64, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &yPercentLayout{percent: clamp(percent, 0, 1), child: child}
+}
+
+func (r *yPercentLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if IsSpace(r.child) {
+		return nil
+	}
+
+	offset := percentOf(r.percent, availableHeight)
+
+	layer := resolveLayer(r.child, availableWidth, availableHeight-offset)
+	if layer == nil {
+		return nil
+	}
+
+	return offsetLayer(layer, 0, offset)
+}
+
+// percentOf returns the percentage (0-1) of size, rounded down.
+func percentOf(percent float64, size int) int {
+	return max(0, int(math.Floor(percent*float64(size))))
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestXPercent(t *testing.T) {
+	t.Parallel()
+
+	marker := lipgloss.NewLayer("X").X(1)
+	layout := Stack("..........", XPercent(0.5, marker))
+
+	// Rendering multiple times (e.g. on each frame) must not move the layer.
+	for range 3 {
+		assertLines(t, RenderString(10, 1, layout), []string{"......X..."})
+	}
+	if marker.GetX() != 1 {
+		t.Fatalf("marker moved to x=%d", marker.GetX())
+	}
+
+	// Resized.
+	assertLines(t, RenderString(4, 1, Stack("....", XPercent(0.5, marker))), []string{"...X"})
+}
+
+func TestYPercent(t *testing.T) {
+	t.Parallel()
+
+	marker := lipgloss.NewLayer("Y")
+	layout := Stack(".\n.\n.\n.", YPercent(0.5, marker))
+
+	for range 3 {
+		assertLines(t, RenderString(1, 4, layout), []string{".", ".", "Y", "."})
+	}
+	if marker.GetY() != 0 {
+		t.Fatalf("marker moved to y=%d", marker.GetY())
+	}
+}
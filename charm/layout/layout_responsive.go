@@ -0,0 +1,75 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"cmp"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Breakpoint is a layout used by [Responsive], when the available width is at
+// least MinWidth.
+type Breakpoint struct {
+	// MinWidth is the minimum available width (inclusive) for the breakpoint to
+	// be used.
+	MinWidth int
+
+	// Layout returns the child to render (anything supported as a child of other
+	// layouts, e.g. a [Layout], model or string), given the available space. It's
+	// only invoked when the breakpoint is selected.
+	Layout func(availableWidth, availableHeight int) any
+}
+
+var _ Layout = (*responsiveLayout)(nil)
+
+type responsiveLayout struct {
+	breakpoints []Breakpoint // Sorted by MinWidth, descending.
+}
+
+// Responsive creates a new layout which renders the layout of the breakpoint
+// with the largest MinWidth that fits within the available width, e.g. to hide
+// a sidebar below 80 columns, and stack content below 40 columns:
+//
+//	layout.Responsive(
+//		layout.Breakpoint{MinWidth: 0, Layout: stacked},
+//		layout.Breakpoint{MinWidth: 40, Layout: contentOnly},
+//		layout.Breakpoint{MinWidth: 80, Layout: withSidebar},
+//	)
+//
+// The order of the breakpoints doesn't matter. If multiple breakpoints have the
+// same MinWidth, the first is used. If no breakpoint fits (e.g. there isn't one
+// with a MinWidth of 0), nothing is rendered.
+func Responsive(breakpoints ...Breakpoint) Layout {
+	breakpoints = slices.DeleteFunc(slices.Clone(breakpoints), func(b Breakpoint) bool {
+		return b.Layout == nil
+	})
+	if len(breakpoints) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(breakpoints, func(a, b Breakpoint) int {
+		return cmp.Compare(b.MinWidth, a.MinWidth)
+	})
+	return &responsiveLayout{breakpoints: breakpoints}
+}
+
+func (r *responsiveLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	// Sorted with a stable sort, so the first of multiple breakpoints with the
+	// same MinWidth is found first.
+	for _, b := range r.breakpoints {
+		if availableWidth < b.MinWidth {
+			continue
+		}
+
+		child := b.Layout(availableWidth, availableHeight)
+		if child == nil || IsSpace(child) {
+			return nil
+		}
+		return resolveLayer(child, availableWidth, availableHeight)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import "testing"
+
+func TestResponsive(t *testing.T) {
+	t.Parallel()
+
+	fixed := func(s string) func(int, int) any {
+		return func(_, _ int) any { return s }
+	}
+
+	// Intentionally out of order.
+	layout := Responsive(
+		Breakpoint{MinWidth: 80, Layout: fixed("wide")},
+		Breakpoint{MinWidth: 0, Layout: fixed("stacked")},
+		Breakpoint{MinWidth: 40, Layout: fixed("medium")},
+		Breakpoint{MinWidth: 40, Layout: fixed("medium-duplicate")},
+	)
+
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{width: 10, want: "stacked"},
+		{width: 39, want: "stacked"},
+		{width: 40, want: "medium"},
+		{width: 79, want: "medium"},
+		{width: 80, want: "wide"},
+		{width: 200, want: "wide"},
+	}
+
+	for _, tt := range tests {
+		assertLines(t, RenderString(tt.width, 5, layout), []string{tt.want})
+	}
+}
+
+func TestResponsive_availableSpace(t *testing.T) {
+	t.Parallel()
+
+	var gotWidth, gotHeight int
+	layout := Responsive(Breakpoint{
+		MinWidth: 5,
+		Layout: func(availableWidth, availableHeight int) any {
+			gotWidth, gotHeight = availableWidth, availableHeight
+			return fillModel{}
+		},
+	})
+
+	assertLines(t, RenderString(6, 2, layout), []string{"######", "######"})
+	if gotWidth != 6 || gotHeight != 2 {
+		t.Fatalf("layout invoked with %dx%d, want 6x2", gotWidth, gotHeight)
+	}
+}
+
+func TestResponsive_noMatch(t *testing.T) {
+	t.Parallel()
+
+	layout := Responsive(Breakpoint{MinWidth: 40, Layout: func(_, _ int) any { return "wide" }})
+	if layer := layout.Render(39, 10); layer != nil {
+		t.Fatalf("expected nil layer, got %q", layer.GetContent())
+	}
+
+	if Responsive() != nil || Responsive(Breakpoint{MinWidth: 10}) != nil {
+		t.Fatal("expected nil layout without breakpoints")
+	}
+}
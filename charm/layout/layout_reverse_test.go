@@ -0,0 +1,111 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "horizontal",
+			width:  10,
+			height: 1,
+			layout: HorizontalReverse("ab", "cd", "ef"),
+			want:   []string{"efcdab"},
+		},
+		{
+			name:   "horizontal-anchored",
+			width:  10,
+			height: 1,
+			layout: HorizontalReverse("ab", "cd", Space()),
+			want:   []string{"      cdab"},
+		},
+		{
+			name:   "vertical",
+			width:  5,
+			height: 5,
+			layout: VerticalReverse("a", "b", "c"),
+			want:   []string{"c", "b", "a"},
+		},
+		{
+			name:   "vertical-anchored",
+			width:  5,
+			height: 4,
+			layout: VerticalReverse("new", "old", Space()),
+			want:   []string{"", "", "old", "new"},
+		},
+		{
+			name:   "rows",
+			width:  5,
+			height: 4,
+			layout: RowsReverse(NewCell("a").Size(1), NewCell("b"), NewCell("c").Size(1)),
+			want:   []string{"c", "b", "", "a"},
+		},
+		{
+			name:   "columns",
+			width:  6,
+			height: 1,
+			layout: ColumnsReverse(NewCell("a").Size(2), NewCell("b"), NewCell("c").Size(1)),
+			want:   []string{"cb  a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Render onto a fixed-size canvas, so leading space (e.g. from anchoring)
+			// isn't cropped.
+			canvas := lipgloss.NewCanvas(tt.width, tt.height)
+			canvas.Compose(lipgloss.NewCompositor(tt.layout.Render(tt.width, tt.height)))
+			assertLines(t, canvas.Render(), tt.want)
+		})
+	}
+}
+
+func TestReverse_hitTesting(t *testing.T) {
+	t.Parallel()
+
+	first := lipgloss.NewLayer("11").ID("first")
+	second := lipgloss.NewLayer("222").ID("second")
+
+	layer := HorizontalReverse(first, second).Render(10, 1)
+	if layer.Width() != 5 || layer.Height() != 1 {
+		t.Fatalf("bounds = %dx%d, want 5x1", layer.Width(), layer.Height())
+	}
+
+	comp := lipgloss.NewCompositor(layer)
+	for x, want := range []string{"second", "second", "second", "first", "first", ""} {
+		if got := comp.Hit(x, 0).ID(); got != want {
+			t.Errorf("Hit(%d, 0) = %q, want %q", x, got, want)
+		}
+	}
+}
+
+func TestReverse_hitTestingAnchored(t *testing.T) {
+	t.Parallel()
+
+	first := lipgloss.NewLayer("new").ID("first")
+	second := lipgloss.NewLayer("old").ID("second")
+
+	comp := lipgloss.NewCompositor(VerticalReverse(first, second, Space()).Render(5, 4))
+	for y, want := range []string{"", "", "second", "first"} {
+		if got := comp.Hit(0, y).ID(); got != want {
+			t.Errorf("Hit(0, %d) = %q, want %q", y, got, want)
+		}
+	}
+}
@@ -4,12 +4,17 @@
 
 package layout
 
-import "charm.land/lipgloss/v2"
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
 
 var _ Layout = (*rowsLayout)(nil)
 
 type rowsLayout struct {
-	cells []*Cell
+	cells   []*Cell
+	reverse bool
 }
 
 // Rows creates a new vertical layout with the provided cells, where each cell
@@ -22,6 +27,18 @@ func Rows(cells ...*Cell) Layout {
 	return &rowsLayout{cells: cells}
 }
 
+// RowsReverse is the same as [Rows], but cells are arranged bottom to top (e.g.
+// for an activity feed, with the newest entry first), so the first cell is the
+// bottom-most. If the cells don't fill the available space, they are placed
+// starting from the top edge, so add a cell without a size or percentage as the
+// last cell to anchor them to the bottom edge instead.
+func RowsReverse(cells ...*Cell) Layout {
+	if len(cells) == 0 {
+		return nil
+	}
+	return &rowsLayout{cells: cells, reverse: true}
+}
+
 func (r *rowsLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
 	if len(r.cells) == 0 {
 		return nil
@@ -122,6 +139,11 @@ func (r *rowsLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer
 		}
 	}
 
+	if r.reverse {
+		slices.Reverse(visibleCells)
+		slices.Reverse(sizes)
+	}
+
 	yOffset := 0
 	for i, cell := range visibleCells {
 		size := sizes[i]
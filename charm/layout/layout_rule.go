@@ -0,0 +1,69 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// DefaultHorizontalRuleRune is the default rune used by [HorizontalRule].
+	DefaultHorizontalRuleRune = '─'
+
+	// DefaultVerticalRuleRune is the default rune used by [VerticalRule].
+	DefaultVerticalRuleRune = '│'
+)
+
+var _ Layout = (*ruleLayout)(nil)
+
+type ruleLayout struct {
+	style    lipgloss.Style
+	char     rune
+	vertical bool
+}
+
+// HorizontalRule creates a new layout which renders a 1-cell tall line spanning
+// the available width, using [DefaultHorizontalRuleRune]. See
+// [HorizontalRuleWithRune] to use a different rune. Useful as a separator between
+// stacked sections, e.g. in [Vertical] or [Rows] (with a [Cell.Size] of 1).
+func HorizontalRule(style lipgloss.Style) Layout {
+	return HorizontalRuleWithRune(style, DefaultHorizontalRuleRune)
+}
+
+// HorizontalRuleWithRune is the same as [HorizontalRule], but with a custom rune.
+func HorizontalRuleWithRune(style lipgloss.Style, char rune) Layout {
+	return &ruleLayout{style: style, char: char}
+}
+
+// VerticalRule creates a new layout which renders a 1-cell wide line spanning the
+// available height, using [DefaultVerticalRuleRune]. See [VerticalRuleWithRune]
+// to use a different rune. Useful as a separator between side-by-side sections,
+// e.g. in [Horizontal] or [Columns] (with a [Cell.Size] of 1).
+func VerticalRule(style lipgloss.Style) Layout {
+	return VerticalRuleWithRune(style, DefaultVerticalRuleRune)
+}
+
+// VerticalRuleWithRune is the same as [VerticalRule], but with a custom rune.
+func VerticalRuleWithRune(style lipgloss.Style, char rune) Layout {
+	return &ruleLayout{style: style, char: char, vertical: true}
+}
+
+func (r *ruleLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if availableWidth <= 0 || availableHeight <= 0 {
+		return nil
+	}
+
+	if r.vertical {
+		lines := make([]string, availableHeight)
+		for i := range lines {
+			lines[i] = r.style.Render(string(r.char))
+		}
+		return lipgloss.NewLayer(strings.Join(lines, "\n"))
+	}
+
+	return lipgloss.NewLayer(r.style.Render(strings.Repeat(string(r.char), availableWidth)))
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestHorizontalRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "default",
+			layout: HorizontalRule(lipgloss.NewStyle()),
+			want:   []string{"──────"},
+		},
+		{
+			name:   "custom-rune",
+			layout: HorizontalRuleWithRune(lipgloss.NewStyle(), '='),
+			want:   []string{"======"},
+		},
+		{
+			name:   "vertical",
+			layout: Vertical("top", HorizontalRule(lipgloss.NewStyle()), "bottom"),
+			want:   []string{"top", "──────", "bottom"},
+		},
+		{
+			name: "rows-zero-percent",
+			layout: Rows(
+				NewCell("top"),
+				NewCell(HorizontalRule(lipgloss.NewStyle())).Size(1),
+				NewCell("bottom"),
+			),
+			want: []string{"top", "", "──────", "bottom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertLines(t, RenderString(6, 5, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestVerticalRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "default",
+			layout: VerticalRule(lipgloss.NewStyle()),
+			want:   []string{"│", "│", "│"},
+		},
+		{
+			name:   "custom-rune",
+			layout: VerticalRuleWithRune(lipgloss.NewStyle(), '|'),
+			want:   []string{"|", "|", "|"},
+		},
+		{
+			name: "columns-zero-percent",
+			layout: Columns(
+				NewCell("ab"),
+				NewCell(VerticalRule(lipgloss.NewStyle())).Size(1),
+				NewCell("cd"),
+			),
+			want: []string{"ab   │cd", "     │", "     │"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertLines(t, RenderString(11, 3, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestRule_noSpace(t *testing.T) {
+	t.Parallel()
+
+	if layer := HorizontalRule(lipgloss.NewStyle()).Render(0, 1); layer != nil {
+		t.Fatal("expected nil layer with no width")
+	}
+	if layer := VerticalRule(lipgloss.NewStyle()).Render(1, 0); layer != nil {
+		t.Fatal("expected nil layer with no height")
+	}
+}
+
+// assertLines compares the rendered output against the wanted lines, ignoring
+// trailing whitespace and trailing empty lines.
+func assertLines(t *testing.T, got string, want []string) {
+	t.Helper()
+
+	lines := strings.Split(got, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
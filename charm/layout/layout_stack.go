@@ -6,21 +6,88 @@ package layout
 
 import "charm.land/lipgloss/v2"
 
+// StackSizing controls how the bounds of a stacked layout are calculated, which
+// are also the bounds that children are aligned within (see [StackAlign]).
+type StackSizing int
+
+const (
+	// StackSizeUnion sizes the stack to the union of the bounds of all children,
+	// including their X/Y offsets. This is the default used by [Stack].
+	StackSizeUnion StackSizing = iota
+
+	// StackSizeLargest sizes the stack to the width of the widest child, and the
+	// height of the tallest child, ignoring their X/Y offsets. Note that children
+	// which are offset past these bounds still extend the resulting layer.
+	StackSizeLargest
+)
+
 var _ Layout = (*stackLayout)(nil)
 
 type stackLayout struct {
+	sizing   StackSizing
 	children []any
 }
 
 // Stack creates a new stacked layout with the provided children, where each
 // child (left to right) is stacked on top of the previous one, with an increasing
-// Z-index.
+// Z-index. The stack is sized to the union of its children (see
+// [StackSizeUnion]), and children are positioned at the top-left of the stack,
+// unless wrapped with [StackAlign].
 func Stack(children ...any) Layout {
+	return StackSized(StackSizeUnion, children...)
+}
+
+// StackSized is like [Stack], however with explicit sizing of the stack, e.g.
+// [StackSizeLargest] to size the stack to its largest child, regardless of child
+// offsets.
+func StackSized(sizing StackSizing, children ...any) Layout {
 	children = filterNil(children)
 	if len(children) == 0 {
 		return nil
 	}
-	return &stackLayout{children: children}
+	return &stackLayout{sizing: sizing, children: children}
+}
+
+var _ Layout = (*stackAlignLayout)(nil)
+
+type stackAlignLayout struct {
+	horizontal lipgloss.Position
+	vertical   lipgloss.Position
+	child      any
+}
+
+// StackAlign aligns the provided child within the bounds of the stack it's a
+// direct child of (see [Stack] and [StackSized]), using the horizontal and
+// vertical positions (0-1, e.g. [lipgloss.Center] or [lipgloss.Right]), rather
+// than the top-left corner. Any existing X/Y offset of the child is added to the
+// aligned position. For example, to overlay a badge on the top-right corner of a
+// card:
+//
+//	layout.Stack(card, layout.StackAlign(lipgloss.Right, lipgloss.Top, badge))
+//
+// Outside of a stack, the child is rendered as-is.
+func StackAlign(horizontal, vertical lipgloss.Position, child any) Layout {
+	if child == nil {
+		return nil
+	}
+	return &stackAlignLayout{
+		horizontal: clamp(horizontal, 0, 1),
+		vertical:   clamp(vertical, 0, 1),
+		child:      child,
+	}
+}
+
+func (r *stackAlignLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if IsSpace(r.child) {
+		return nil
+	}
+	return resolveLayer(r.child, availableWidth, availableHeight)
+}
+
+// stackEntry is a resolved child of a stack.
+type stackEntry struct {
+	layer *lipgloss.Layer
+	align *stackAlignLayout // nil if the child isn't aligned.
 }
 
 func (r *stackLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
@@ -28,7 +95,7 @@ func (r *stackLayout) Render(availableWidth, availableHeight int) *lipgloss.Laye
 		return nil
 	}
 
-	layers := make([]*lipgloss.Layer, 0, len(r.children))
+	entries := make([]stackEntry, 0, len(r.children))
 
 	for _, child := range r.children {
 		if IsSpace(child) {
@@ -38,13 +105,40 @@ func (r *stackLayout) Render(availableWidth, availableHeight int) *lipgloss.Laye
 		if layer == nil {
 			continue
 		}
-		layers = append(layers, layer)
+		align, _ := child.(*stackAlignLayout)
+		entries = append(entries, stackEntry{layer: layer, align: align})
 	}
 
-	switch len(layers) {
-	case 0:
+	if len(entries) == 0 {
 		return nil
-	case 1:
+	}
+
+	// Bounds that aligned children are positioned within.
+	var width, height int
+	for _, e := range entries {
+		w, h := e.layer.Width(), e.layer.Height()
+		if r.sizing == StackSizeUnion && e.align == nil {
+			w += max(0, e.layer.GetX())
+			h += max(0, e.layer.GetY())
+		}
+		width = max(width, w)
+		height = max(height, h)
+	}
+
+	layers := make([]*lipgloss.Layer, len(entries))
+	for i, e := range entries {
+		layers[i] = e.layer
+		if e.align == nil {
+			continue
+		}
+		layers[i] = offsetLayer(
+			e.layer,
+			int(float64(width-e.layer.Width())*float64(e.align.horizontal)),
+			int(float64(height-e.layer.Height())*float64(e.align.vertical)),
+		)
+	}
+
+	if len(layers) == 1 {
 		return layers[0].Z(1)
 	}
 
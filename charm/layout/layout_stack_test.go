@@ -0,0 +1,128 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestStack_Align(t *testing.T) {
+	t.Parallel()
+
+	card := "######\n######\n######"
+
+	tests := []struct {
+		name   string
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "top-left-default",
+			layout: Stack(card, "B"),
+			want:   []string{"B#####", "######", "######"},
+		},
+		{
+			name:   "top-right",
+			layout: Stack(card, StackAlign(lipgloss.Right, lipgloss.Top, "B")),
+			want:   []string{"#####B", "######", "######"},
+		},
+		{
+			name:   "center",
+			layout: Stack(card, StackAlign(lipgloss.Center, lipgloss.Center, "BB")),
+			want:   []string{"######", "##BB##", "######"},
+		},
+		{
+			name:   "bottom-right",
+			layout: Stack(card, StackAlign(lipgloss.Right, lipgloss.Bottom, "B")),
+			want:   []string{"######", "######", "#####B"},
+		},
+		{
+			name:   "aligned-with-offset",
+			layout: Stack(card, StackAlign(lipgloss.Left, lipgloss.Bottom, lipgloss.NewLayer("B").X(1))),
+			want:   []string{"######", "######", "#B####"},
+		},
+		{
+			name:   "larger-aligned-child",
+			layout: Stack(StackAlign(lipgloss.Right, lipgloss.Bottom, "B"), card),
+			want:   []string{"######", "######", "######"},
+		},
+		{
+			name:   "outside-stack",
+			layout: Vertical(StackAlign(lipgloss.Right, lipgloss.Bottom, "B")),
+			want:   []string{"B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertLines(t, RenderString(20, 10, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestStackSized(t *testing.T) {
+	t.Parallel()
+
+	children := func() []any {
+		return []any{
+			"####\n####",
+			lipgloss.NewLayer("==").X(3).Y(1),
+			StackAlign(lipgloss.Right, lipgloss.Top, "B"),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		sizing     StackSizing
+		wantWidth  int
+		wantHeight int
+		want       []string
+	}{
+		{
+			name:       "union",
+			sizing:     StackSizeUnion,
+			wantWidth:  5,
+			wantHeight: 2,
+			want:       []string{"####B", "###=="},
+		},
+		{
+			name:       "largest",
+			sizing:     StackSizeLargest,
+			wantWidth:  5,
+			wantHeight: 2,
+			want:       []string{"###B", "###=="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layer := StackSized(tt.sizing, children()...).Render(20, 10)
+			if layer.Width() != tt.wantWidth || layer.Height() != tt.wantHeight {
+				t.Fatalf("size = %dx%d, want %dx%d", layer.Width(), layer.Height(), tt.wantWidth, tt.wantHeight)
+			}
+			assertLines(t, RenderString(20, 10, StackSized(tt.sizing, children()...)), tt.want)
+		})
+	}
+}
+
+func TestStack_AlignRenderTwice(t *testing.T) {
+	t.Parallel()
+
+	// Layers are owned by the caller, so aligning must not move the layer itself.
+	badge := lipgloss.NewLayer("B").Y(1)
+	layout := Stack("0123456789", StackAlign(lipgloss.Right, lipgloss.Top, badge))
+
+	for range 3 {
+		assertLines(t, RenderString(20, 10, layout), []string{"0123456789", "         B"})
+	}
+	if badge.GetX() != 0 || badge.GetY() != 1 {
+		t.Fatalf("badge moved to %d,%d", badge.GetX(), badge.GetY())
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var _ Layout = (*statusBarLayout)(nil)
+
+type statusBarLayout struct {
+	style  lipgloss.Style
+	left   string
+	center string
+	right  string
+}
+
+// StatusBar creates a new layout which renders a single line spanning the
+// available width, with the left segment aligned to the left, the right segment
+// aligned to the right, and the center segment centered (shifted towards either
+// side if needed, so it doesn't overlap the other segments). Segments are
+// separated by at least a single cell. Any segment can be empty. Only the first
+// line of each segment is used.
+//
+// When there isn't enough space, the center segment is truncated first, then the
+// right segment, and finally the left segment. The style is applied to the whole
+// line (e.g. for a background color), and its horizontal padding, borders and
+// margins are taken into account.
+func StatusBar(style lipgloss.Style, left, center, right string) Layout {
+	return &statusBarLayout{
+		style:  style,
+		left:   firstLine(left),
+		center: firstLine(center),
+		right:  firstLine(right),
+	}
+}
+
+func (r *statusBarLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	width := availableWidth - r.style.GetHorizontalFrameSize()
+	if width <= 0 || availableHeight <= 0 {
+		return nil
+	}
+
+	left, center, right := r.left, r.center, r.right
+	lw, cw, rw := ansi.StringWidth(left), ansi.StringWidth(center), ansi.StringWidth(right)
+
+	// Gaps required between the center segment and each side.
+	var gapLeft, gapRight int
+	if lw > 0 {
+		gapLeft = 1
+	}
+	if rw > 0 {
+		gapRight = 1
+	}
+
+	if cw > 0 && lw+gapLeft+cw+gapRight+rw > width {
+		center = ansi.Truncate(center, max(0, width-lw-gapLeft-gapRight-rw), truncateEllipsis)
+		cw = ansi.StringWidth(center)
+	}
+
+	if cw == 0 {
+		gap := 0
+		if lw > 0 && rw > 0 {
+			gap = 1
+		}
+
+		if lw+gap+rw > width {
+			right = ansi.Truncate(right, max(0, width-lw-gap), truncateEllipsis)
+			rw = ansi.StringWidth(right)
+			if rw == 0 {
+				left = ansi.Truncate(left, width, truncateEllipsis)
+				lw = ansi.StringWidth(left)
+			}
+		}
+
+		return lipgloss.NewLayer(r.style.Render(left + strings.Repeat(" ", width-lw-rw) + right))
+	}
+
+	x := (width - cw) / 2
+	x = max(x, lw+gapLeft)
+	x = min(x, width-rw-gapRight-cw)
+
+	return lipgloss.NewLayer(r.style.Render(
+		left +
+			strings.Repeat(" ", x-lw) +
+			center +
+			strings.Repeat(" ", width-rw-x-cw) +
+			right,
+	))
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	return s
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestStatusBar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		width  int
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "all-segments",
+			width:  21,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "main", "UTF-8"),
+			want:   []string{"NORMAL  main    UTF-8"},
+		},
+		{
+			name:   "center-shifted",
+			width:  16,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "ab", "x"),
+			want:   []string{"NORMAL ab      x"},
+		},
+		{
+			name:   "center-truncated",
+			width:  16,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "main.go", "UTF-8"),
+			want:   []string{"NORMAL ma… UTF-8"},
+		},
+		{
+			name:   "center-dropped",
+			width:  13,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "main.go", "UTF-8"),
+			want:   []string{"NORMAL  UTF-8"},
+		},
+		{
+			name:   "right-truncated",
+			width:  10,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "main.go", "UTF-8"),
+			want:   []string{"NORMAL UT…"},
+		},
+		{
+			name:   "left-truncated",
+			width:  4,
+			layout: StatusBar(lipgloss.NewStyle(), "NORMAL", "main.go", "UTF-8"),
+			want:   []string{"NOR…"},
+		},
+		{
+			name:   "center-only",
+			width:  10,
+			layout: StatusBar(lipgloss.NewStyle(), "", "main", ""),
+			want:   []string{"   main"},
+		},
+		{
+			name:   "right-only",
+			width:  10,
+			layout: StatusBar(lipgloss.NewStyle(), "", "", "UTF-8"),
+			want:   []string{"     UTF-8"},
+		},
+		{
+			name:   "padding",
+			width:  12,
+			layout: StatusBar(lipgloss.NewStyle().Padding(0, 1), "a", "b", "c"),
+			want:   []string{" a   b    c"},
+		},
+		{
+			name:   "multiline-segments",
+			width:  7,
+			layout: StatusBar(lipgloss.NewStyle(), "a\nb", "c\nd", "e\nf"),
+			want:   []string{"a  c  e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertLines(t, RenderString(tt.width, 1, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestStatusBar_noSpace(t *testing.T) {
+	t.Parallel()
+
+	if layer := StatusBar(lipgloss.NewStyle().Padding(0, 2), "a", "b", "c").Render(4, 1); layer != nil {
+		t.Fatal("expected nil layer")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+var _ Layout = (*tabsLayout)(nil)
+
+// tabLayerIDPrefix is the prefix of the layer ID of each tab in the tab strip.
+const tabLayerIDPrefix = "tab:"
+
+var (
+	// DefaultTabStyle is the default style used for inactive tabs in [Tabs].
+	DefaultTabStyle = lipgloss.NewStyle().Padding(0, 1).Faint(true)
+
+	// DefaultActiveTabStyle is the default style used for the active tab in [Tabs].
+	DefaultActiveTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Reverse(true)
+)
+
+// Tab is a single tab in a [Tabs] layout.
+type Tab struct {
+	// Label is the label shown in the tab strip.
+	Label string
+	// Child is the content shown when the tab is active.
+	Child any
+}
+
+type tabsLayout struct {
+	style       lipgloss.Style
+	activeStyle lipgloss.Style
+	active      int
+	tabs        []Tab
+}
+
+// Tabs creates a new layout with a horizontal tab strip, with the content of the
+// active tab (by index) below it. Each tab in the strip has a layer ID of
+// "tab:<index>", so clicking a tab produces a [LayerMouseMsg] which can be mapped
+// back to the tab index using [TabIndex]. Uses [DefaultTabStyle] and
+// [DefaultActiveTabStyle], see [TabsWithStyle] to customize them.
+func Tabs(active int, tabs ...Tab) Layout {
+	return TabsWithStyle(DefaultTabStyle, DefaultActiveTabStyle, active, tabs...)
+}
+
+// TabsWithStyle is the same as [Tabs], but allows customizing the style of the
+// inactive and active tabs.
+func TabsWithStyle(style, activeStyle lipgloss.Style, active int, tabs ...Tab) Layout {
+	if len(tabs) == 0 {
+		return nil
+	}
+	return &tabsLayout{
+		style:       style,
+		activeStyle: activeStyle,
+		active:      clamp(active, 0, len(tabs)-1),
+		tabs:        tabs,
+	}
+}
+
+// TabLayerID returns the layer ID of the tab with the provided index.
+func TabLayerID(index int) string {
+	return tabLayerIDPrefix + strconv.Itoa(index)
+}
+
+// TabIndex returns the tab index from a layer ID (e.g. [LayerMouseMsg.LayerID]),
+// and false if the layer ID isn't from a [Tabs] layout.
+func TabIndex(layerID string) (int, bool) {
+	v, ok := strings.CutPrefix(layerID, tabLayerIDPrefix)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(v)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+func (r *tabsLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	if len(r.tabs) == 0 {
+		return nil
+	}
+
+	layers := make([]*lipgloss.Layer, 0, len(r.tabs)+1)
+
+	var xOffset, stripHeight int
+	for i, tab := range r.tabs {
+		style := r.style
+		if i == r.active {
+			style = r.activeStyle
+		}
+
+		layer := lipgloss.NewLayer(style.Render(tab.Label)).
+			ID(TabLayerID(i)).
+			X(xOffset).
+			Z(2)
+		xOffset += layer.Width()
+		stripHeight = max(stripHeight, layer.Height())
+		layers = append(layers, layer)
+	}
+
+	if !IsSpace(r.tabs[r.active].Child) {
+		layer := resolveLayer(r.tabs[r.active].Child, availableWidth, max(0, availableHeight-stripHeight))
+		if layer != nil {
+			layers = append(layers, offsetLayer(layer, 0, stripHeight).Z(2))
+		}
+	}
+
+	return lipgloss.NewLayer("").
+		Z(1).
+		AddLayers(layers...)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestTabs_RenderTwice(t *testing.T) {
+	t.Parallel()
+
+	content := lipgloss.NewLayer("content")
+	layout := TabsWithStyle(
+		lipgloss.NewStyle(),
+		lipgloss.NewStyle(),
+		0,
+		Tab{Label: "[one]", Child: content},
+		Tab{Label: "[two]", Child: "other"},
+	)
+
+	// Rendering multiple times must not move the caller-owned layer.
+	for range 3 {
+		assertLines(t, RenderString(20, 5, layout), []string{"[one][two]", "content"})
+	}
+	if content.GetY() != 0 {
+		t.Fatalf("content moved to y=%d", content.GetY())
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var _ Layout = (*textLayout)(nil)
+
+type textLayout struct {
+	id    string
+	text  string
+	style lipgloss.Style
+}
+
+// TextLayer creates a new layout which renders text (e.g. body text of a reader
+// pane), wrapped to the available width each time it is rendered, so it reflows
+// when the available width changes, unlike pre-rendered content. Text is wrapped
+// at word boundaries (ANSI-aware, preserving styling), and words longer than the
+// available width are broken. The style is applied to the wrapped text, and its
+// horizontal padding, borders and margins are taken into account when wrapping.
+// Lines beyond the available height are cropped. The resulting layer has the
+// provided ID (if not empty), e.g. for mouse events.
+func TextLayer(id, text string, style lipgloss.Style) Layout {
+	return &textLayout{id: id, text: text, style: style}
+}
+
+func (r *textLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
+	width := availableWidth - r.style.GetHorizontalFrameSize()
+	if width <= 0 || availableHeight <= 0 {
+		return nil
+	}
+
+	wrapped := ansi.Wrap(strings.ReplaceAll(r.text, "\r\n", "\n"), width, "")
+	content := r.style.Render(wrapped)
+
+	if lines := strings.Split(content, "\n"); len(lines) > availableHeight {
+		content = strings.Join(lines[:availableHeight], "\n")
+	}
+
+	layer := lipgloss.NewLayer(content)
+	if r.id != "" {
+		layer.ID(r.id)
+	}
+	return layer
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestTextLayer(t *testing.T) {
+	t.Parallel()
+
+	text := "the quick brown fox jumps over the lazy dog"
+
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		layout Layout
+		want   []string
+	}{
+		{
+			name:   "wide",
+			width:  50,
+			height: 5,
+			layout: TextLayer("", text, lipgloss.NewStyle()),
+			want:   []string{"the quick brown fox jumps over the lazy dog"},
+		},
+		{
+			name:   "reflowed",
+			width:  16,
+			height: 5,
+			layout: TextLayer("", text, lipgloss.NewStyle()),
+			want:   []string{"the quick brown", "fox jumps over", "the lazy dog"},
+		},
+		{
+			name:   "long-words",
+			width:  4,
+			height: 5,
+			layout: TextLayer("", "abcdefghij", lipgloss.NewStyle()),
+			want:   []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:   "padding",
+			width:  12,
+			height: 5,
+			layout: TextLayer("", "aaa bbb ccc", lipgloss.NewStyle().PaddingLeft(2)),
+			want:   []string{"  aaa bbb", "  ccc"},
+		},
+		{
+			name:   "cropped",
+			width:  16,
+			height: 2,
+			layout: TextLayer("", text, lipgloss.NewStyle()),
+			want:   []string{"the quick brown", "fox jumps over"},
+		},
+		{
+			name:   "in-horizontal",
+			width:  10,
+			height: 5,
+			layout: Horizontal("|", TextLayer("", "one two three four", lipgloss.NewStyle()), "|"),
+			want:   []string{"|one two|", " three", " four"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertLines(t, RenderString(tt.width, tt.height, tt.layout), tt.want)
+		})
+	}
+}
+
+func TestTextLayer_id(t *testing.T) {
+	t.Parallel()
+
+	layer := TextLayer("reader", "text", lipgloss.NewStyle()).Render(10, 1)
+	if layer == nil || layer.GetID() != "reader" {
+		t.Fatalf("expected layer with ID %q", "reader")
+	}
+
+	if layer = TextLayer("reader", "text", lipgloss.NewStyle().Padding(0, 5)).Render(10, 1); layer != nil {
+		t.Fatal("expected nil layer without space for text")
+	}
+}
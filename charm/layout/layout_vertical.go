@@ -4,12 +4,17 @@
 
 package layout
 
-import "charm.land/lipgloss/v2"
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
 
 var _ Layout = (*verticalLayout)(nil)
 
 type verticalLayout struct {
 	children []any
+	reverse  bool
 }
 
 // Vertical creates a new vertical layout with the provided children.
@@ -21,6 +26,20 @@ func Vertical(children ...any) Layout {
 	return &verticalLayout{children: children}
 }
 
+// VerticalReverse is the same as [Vertical], but lays out the children from bottom
+// to top (e.g. for an activity feed, with the newest entry first), so the first
+// child is the bottom-most. Children are still sized in the order they are
+// provided, so the first child has priority over the available space. Like
+// [Vertical], children are placed starting from the top edge, so add a [Space] as
+// the last child to anchor them to the bottom edge instead.
+func VerticalReverse(children ...any) Layout {
+	children = filterNil(children)
+	if len(children) == 0 {
+		return nil
+	}
+	return &verticalLayout{children: children, reverse: true}
+}
+
 func (r *verticalLayout) Render(availableWidth, availableHeight int) *lipgloss.Layer {
 	if len(r.children) == 0 {
 		return nil
@@ -53,6 +72,10 @@ func (r *verticalLayout) Render(availableWidth, availableHeight int) *lipgloss.L
 		return layers[0].Z(1)
 	}
 
+	if r.reverse {
+		slices.Reverse(layers)
+	}
+
 	yOffset := 0
 	spaceIndex := 0
 	spaceDistrib := calculateSpaceDistribution(spaces, max(0, availableHeight-totalFixedHeight))
@@ -5,6 +5,8 @@
 package layout
 
 import (
+	"image"
+
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 )
@@ -28,6 +30,25 @@ func RenderString(width, height int, child any) string {
 	return lipgloss.NewCompositor(layer).Render()
 }
 
+// Measure returns the size of the provided child/layout/etc, as it would be
+// rendered with the available width and height, without compositing it, e.g. to
+// decide whether content fits (and if it should be truncated, wrapped or
+// scrolled) before rendering. The size includes all nested layers, but not the
+// X/Y offset of the child itself. Note that the child is still resolved into a
+// layer (i.e. layouts are rendered, and View methods of models are invoked).
+// Returns 0, 0 if the child doesn't render anything.
+func Measure(child any, availableWidth, availableHeight int) (width, height int) {
+	if child == nil || IsSpace(child) {
+		return 0, 0
+	}
+
+	layer := resolveLayer(child, availableWidth, availableHeight)
+	if layer == nil {
+		return 0, 0
+	}
+	return layer.Width(), layer.Height()
+}
+
 // RenderView renders the provided child/layout/etc onto an existing [tea.View],
 // including applying a callback to the view to handle mouse events, which will
 // send a downstream [LayerMouseMsg] to the model.
@@ -41,8 +62,74 @@ func RenderView(view *tea.View, width, height int, child any) {
 		return
 	}
 
+	renderView(view, lipgloss.NewCompositor(layer))
+}
+
+// RenderRegion is the same as [RenderView], however only the cells within the
+// dirty rectangle are updated, and all other cells of the view are left as they
+// were previously rendered. This is useful when only a single part of the screen
+// changed (e.g. a single panel), as only layers which overlap the dirty
+// rectangle are drawn. full is the bounds of the whole view (typically
+// image.Rect(0, 0, width, height)), and dirty is relative to the same origin
+// (and is clipped to full).
+//
+// Note the following constraints:
+//   - The whole layout tree is still resolved (as the position of each layer
+//     depends on its siblings), only drawing is limited to the dirty rectangle.
+//   - The view must contain a previous render of the same size (e.g. from
+//     [RenderView]), otherwise cells outside of the dirty rectangle are blank.
+//   - Wide characters (e.g. East-Asian characters and emojis) which cross the
+//     edge of the dirty rectangle may be cut off.
+//   - The mouse callback of the view is updated for the whole layout tree, like
+//     [RenderView].
+func RenderRegion(view *tea.View, full, dirty image.Rectangle, child any) {
+	if child == nil || full.Empty() {
+		return
+	}
+
+	dirty = dirty.Intersect(full).Sub(full.Min)
+	if dirty.Empty() {
+		return
+	}
+
+	layer := resolveLayer(child, full.Dx(), full.Dy())
+	if layer == nil {
+		return
+	}
 	comp := lipgloss.NewCompositor(layer)
 
+	// Only layers overlapping the dirty rectangle are drawn onto the fresh canvas.
+	fresh := lipgloss.NewCanvas(full.Dx(), full.Dy())
+	comp.Draw(fresh, dirty)
+
+	canvas := lipgloss.NewCanvas(full.Dx(), full.Dy())
+	if view.Content != "" {
+		canvas.Compose(lipgloss.NewLayer(view.Content))
+	}
+
+	for y := dirty.Min.Y; y < dirty.Max.Y; y++ {
+		for x := dirty.Min.X; x < dirty.Max.X; x++ {
+			canvas.SetCell(x, y, fresh.CellAt(x, y))
+		}
+	}
+
+	setMouseHandler(view, comp)
+	view.SetContent(canvas.Render())
+}
+
+// renderView renders the compositor onto the view, including the mouse callback.
+// See [RenderView].
+func renderView(view *tea.View, comp *lipgloss.Compositor) {
+	setMouseHandler(view, comp)
+
+	// printLayer(layer)
+	view.SetContent(comp.Render())
+}
+
+// setMouseHandler sets the mouse callback of the view (if mouse events are
+// enabled), which sends a [LayerMouseMsg] for the layer of the compositor under
+// the mouse.
+func setMouseHandler(view *tea.View, comp *lipgloss.Compositor) {
 	if view.MouseMode != tea.MouseModeNone {
 		view.OnMouse = func(msg tea.MouseMsg) tea.Cmd {
 			if hit := comp.Hit(msg.Mouse().X, msg.Mouse().Y); !hit.Empty() {
@@ -86,7 +173,4 @@ func RenderView(view *tea.View, width, height int, child any) {
 			return nil
 		}
 	}
-
-	// printLayer(layer)
-	view.SetContent(comp.Render())
 }
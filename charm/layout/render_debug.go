@@ -0,0 +1,102 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"image"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultDebugStyle is the default style used for the labels of the debug overlay
+// rendered by [RenderDebug].
+var DefaultDebugStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("0")).
+	Background(lipgloss.Color("11"))
+
+// RenderDebug is like [RenderView], but when debug is true, each visible layer
+// with an ID (those which would produce a [LayerMouseMsg]) is labeled at its
+// origin with its ID and bounds (e.g. "sidebar 20x10+0+1", as
+// WIDTHxHEIGHT+X+Y, truncated to the width of the layer), using
+// [DefaultDebugStyle]. Layers which are fully covered by other layers with an ID
+// aren't labeled. Labels don't affect mouse events. When debug is false, this is
+// identical to [RenderView], so it can be toggled at runtime (e.g. with a
+// keybind).
+func RenderDebug(view *tea.View, width, height int, debug bool, child any) {
+	if child == nil || width == 0 || height == 0 {
+		return
+	}
+
+	layer := resolveLayer(child, width, height)
+	if layer == nil {
+		return
+	}
+
+	if !debug {
+		renderView(view, lipgloss.NewCompositor(layer))
+		return
+	}
+
+	labels := debugLabels(lipgloss.NewCompositor(layer), width, height, layer.MaxZ()+1)
+	if len(labels) == 0 {
+		renderView(view, lipgloss.NewCompositor(layer))
+		return
+	}
+
+	renderView(view, lipgloss.NewCompositor(
+		lipgloss.NewLayer("").AddLayers(append([]*lipgloss.Layer{layer}, labels...)...),
+	))
+}
+
+// debugLabels returns a label layer for each visible layer with an ID, at the
+// provided Z-index.
+func debugLabels(comp *lipgloss.Compositor, width, height, z int) []*lipgloss.Layer {
+	type hit struct {
+		id     string
+		bounds image.Rectangle
+	}
+
+	// The compositor doesn't expose the flattened layers, so use hit testing to
+	// find all layers which are visible.
+	var hits []hit
+	for y := range height {
+		for x := range width {
+			h := comp.Hit(x, y)
+			if h.Empty() {
+				continue
+			}
+			v := hit{id: h.ID(), bounds: h.Bounds()}
+			if !slices.Contains(hits, v) {
+				hits = append(hits, v)
+			}
+		}
+	}
+
+	labels := make([]*lipgloss.Layer, 0, len(hits))
+	for _, h := range hits {
+		label := fmt.Sprintf(
+			"%s %dx%d+%d+%d",
+			h.id,
+			h.bounds.Dx(),
+			h.bounds.Dy(),
+			h.bounds.Min.X,
+			h.bounds.Min.Y,
+		)
+
+		// Only show bounds if they fit within the layer, to reduce overlap with
+		// labels of adjacent layers.
+		maxWidth := max(h.bounds.Dx(), ansi.StringWidth(h.id))
+		maxWidth = min(maxWidth, width-h.bounds.Min.X)
+
+		labels = append(labels, lipgloss.NewLayer(
+			DefaultDebugStyle.Render(ansi.Truncate(label, max(1, maxWidth), truncateEllipsis)),
+		).X(h.bounds.Min.X).Y(h.bounds.Min.Y).Z(z))
+	}
+	return labels
+}
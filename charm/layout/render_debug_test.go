@@ -0,0 +1,86 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// debugPanels returns two side-by-side panels with layer IDs, along with an
+// unlabeled row below them.
+func debugPanels() Layout {
+	return Vertical(
+		Horizontal(
+			lipgloss.NewLayer(strings.Repeat("a", 14)+"\n"+strings.Repeat("a", 14)).ID("left"),
+			lipgloss.NewLayer(strings.Repeat("b", 15)+"\n"+strings.Repeat("b", 15)).ID("right"),
+		),
+		"footer",
+	)
+}
+
+// mouseLayerID returns the layer ID reported by the mouse handler of the view,
+// for a click at the provided position.
+func mouseLayerID(t *testing.T, view *tea.View, x, y int) string {
+	t.Helper()
+
+	cmd := view.OnMouse(tea.MouseClickMsg{X: x, Y: y, Button: tea.MouseLeft})
+	if cmd == nil {
+		return ""
+	}
+	msg, ok := cmd().(LayerMouseMsg)
+	if !ok {
+		t.Fatalf("expected LayerMouseMsg, got %T", cmd())
+	}
+	return msg.LayerID
+}
+
+func TestRenderDebug(t *testing.T) {
+	t.Parallel()
+
+	view := tea.View{MouseMode: tea.MouseModeCellMotion}
+	RenderDebug(&view, 30, 3, true, debugPanels())
+	assertLines(t, ansi.Strip(view.Content), []string{
+		"left 14x2+0+0aright 15x2+14+0",
+		"aaaaaaaaaaaaaabbbbbbbbbbbbbbb",
+		"footer",
+	})
+
+	// Labels are drawn on top, but aren't reported for mouse events.
+	plain := tea.View{MouseMode: tea.MouseModeCellMotion}
+	RenderView(&plain, 30, 3, debugPanels())
+
+	for y := range 3 {
+		for x := range 30 {
+			if got, want := mouseLayerID(t, &view, x, y), mouseLayerID(t, &plain, x, y); got != want {
+				t.Errorf("layer at %d,%d = %q, want %q", x, y, got, want)
+			}
+		}
+	}
+	if got := mouseLayerID(t, &view, 0, 0); got != "left" {
+		t.Errorf("layer at 0,0 = %q, want %q", got, "left")
+	}
+}
+
+func TestRenderDebug_disabled(t *testing.T) {
+	t.Parallel()
+
+	debug := tea.View{MouseMode: tea.MouseModeCellMotion}
+	RenderDebug(&debug, 30, 3, false, debugPanels())
+
+	plain := tea.View{MouseMode: tea.MouseModeCellMotion}
+	RenderView(&plain, 30, 3, debugPanels())
+
+	if debug.Content != plain.Content {
+		t.Fatalf("RenderDebug() =\n%s\nwant:\n%s", debug.Content, plain.Content)
+	}
+	if got := mouseLayerID(t, &debug, 20, 1); got != "right" {
+		t.Errorf("layer at 20,1 = %q, want %q", got, "right")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package layout
+
+import (
+	"image"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func TestRenderRegion(t *testing.T) {
+	t.Parallel()
+
+	panels := func(left, right string) Layout {
+		return Horizontal(
+			Vertical(left, left),
+			Vertical(right, right),
+		)
+	}
+
+	full := image.Rect(0, 0, 10, 2)
+
+	var view tea.View
+	RenderView(&view, full.Dx(), full.Dy(), panels("aaaaa", "bbbbb"))
+	assertLines(t, view.Content, []string{"aaaaabbbbb", "aaaaabbbbb"})
+
+	// Both panels changed, but only the right panel is marked as dirty, so the
+	// left panel must be left untouched.
+	RenderRegion(&view, full, image.Rect(5, 0, 10, 2), panels("ccccc", "ddddd"))
+	assertLines(t, view.Content, []string{"aaaaaddddd", "aaaaaddddd"})
+
+	// Partial rectangles, and rectangles extending past the view, are clipped.
+	RenderRegion(&view, full, image.Rect(3, 1, 20, 20), panels("eeeee", "fffff"))
+	assertLines(t, view.Content, []string{"aaaaaddddd", "aaaeefffff"})
+
+	// An empty dirty rectangle leaves the view untouched.
+	before := view.Content
+	RenderRegion(&view, full, image.Rectangle{}, panels("ggggg", "hhhhh"))
+	if view.Content != before {
+		t.Fatalf("expected view to be unchanged, got:\n%s", view.Content)
+	}
+}
+
+func TestRenderRegion_emptyView(t *testing.T) {
+	t.Parallel()
+
+	var view tea.View
+	RenderRegion(&view, image.Rect(0, 0, 6, 1), image.Rect(0, 0, 3, 1), "abcdef")
+	assertLines(t, view.Content, []string{"abc"})
+}
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		child      any
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "nil", child: nil},
+		{name: "space", child: Space()},
+		{name: "string", child: "hello\nworld!", wantWidth: 6, wantHeight: 2},
+		{name: "wide-runes", child: "日本", wantWidth: 4, wantHeight: 1},
+		{name: "layer", child: lipgloss.NewLayer("abc\nde\nf"), wantWidth: 3, wantHeight: 3},
+		{name: "layer-offset", child: lipgloss.NewLayer("abc").X(5).Y(2), wantWidth: 3, wantHeight: 1},
+		{
+			name: "layer-nested",
+			child: lipgloss.NewLayer("ab").AddLayers(
+				lipgloss.NewLayer("cd").X(3).Y(1),
+			),
+			wantWidth:  5,
+			wantHeight: 2,
+		},
+		{name: "layout", child: Vertical("abc", "defgh"), wantWidth: 5, wantHeight: 2},
+		{name: "layout-available-space", child: fillModel{}, wantWidth: 7, wantHeight: 4},
+		{name: "layout-wrapped", child: TextLayer("", "aaa bbb ccc", lipgloss.NewStyle()), wantWidth: 7, wantHeight: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w, h := Measure(tt.child, 7, 4)
+			if w != tt.wantWidth || h != tt.wantHeight {
+				t.Fatalf("Measure() = %dx%d, want %dx%d", w, h, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
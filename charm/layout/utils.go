@@ -29,6 +29,10 @@ import (
 // 	spew.Fdump(f, layer)
 // }
 
+// truncateEllipsis is the suffix used when truncating text to fit. Should be 1
+// character wide.
+const truncateEllipsis = "…"
+
 func clamp[T cmp.Ordered](value, min, max T) T {
 	if value < min {
 		return min
@@ -143,6 +147,15 @@ func resolveLayer(child any, availableWidth, availableHeight int) *lipgloss.Laye
 	}
 }
 
+// offsetLayer returns a shallow copy of the layer, moved by x and y relative to
+// its current position. Layers can be owned by the caller (see [resolveLayer])
+// and reused across renders, so relative offsets must not be applied to the
+// layer itself, otherwise they would accumulate on each render.
+func offsetLayer(layer *lipgloss.Layer, x, y int) *lipgloss.Layer {
+	moved := *layer
+	return moved.X(layer.GetX() + x).Y(layer.GetY() + y)
+}
+
 func calculateSpaceDistribution(numSpaces, remainingSpace int) []int {
 	if numSpaces <= 0 {
 		return nil
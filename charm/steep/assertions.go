@@ -7,6 +7,7 @@ package steep
 import (
 	"bytes"
 	"context"
+	"image"
 	"iter"
 	"reflect"
 	"regexp"
@@ -174,6 +175,42 @@ func WaitNotMatch(tb testing.TB, model Viewable, pattern string, opts ...Option)
 	}, opts...)
 }
 
+// WaitMatches waits until the latest view output matches every regular
+// expression in patterns. Each pattern is compiled with [regexp.Compile]; a
+// compile error fails the test immediately. Patterns are matched against the
+// whole view, use the "(?m)" flag to anchor "^" and "$" per-line.
+//
+// See also [Harness.WaitMatches], [WaitMatch], [AssertMatches], and
+// [RequireMatches].
+func WaitMatches(tb testing.TB, model Viewable, patterns []string, opts ...Option) string {
+	tb.Helper()
+	opts = append(opts, withReason("WaitMatches(%q)", patterns))
+	res := compilePatterns(tb, patterns)
+	return WaitViewFunc(tb, model, func(str string) bool {
+		for _, re := range res {
+			if !re.MatchString(str) {
+				return false
+			}
+		}
+		return true
+	}, opts...)
+}
+
+// compilePatterns compiles each pattern with [regexp.Compile], failing the test
+// immediately if any pattern is invalid.
+func compilePatterns(tb testing.TB, patterns []string) []*regexp.Regexp {
+	tb.Helper()
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			tb.Fatalf("invalid regexp: %v", err)
+		}
+		res = append(res, re)
+	}
+	return res
+}
+
 // WaitSettle waits until the rendered view string has not changed for the
 // configured settle timeout. The model must implement [Viewable]; each check calls
 // View() and compares the string to the previous sample.
@@ -402,6 +439,45 @@ func RequireMatch(tb testing.TB, view Viewable, pattern string, opts ...Option)
 	}
 }
 
+// AssertMatches reports an error unless output matches every regular
+// expression in patterns. Each pattern is compiled with [regexp.Compile]; a
+// compile error fails the test immediately. Patterns are matched against the
+// whole view, use the "(?m)" flag to anchor "^" and "$" per-line.
+// It returns whether the output matched and allows the test to continue.
+//
+// See also [Harness.AssertMatches], [AssertMatch], [WaitMatches], and
+// [RequireMatches].
+func AssertMatches(tb testing.TB, view Viewable, patterns []string, opts ...Option) bool {
+	tb.Helper()
+	res := compilePatterns(tb, patterns)
+	cfg := collectOptions(opts...)
+	out := view()
+	if cfg.stripANSI {
+		out = xansi.StripANSI(out)
+	}
+	matched := true
+	for _, re := range res {
+		if !re.MatchString(out) {
+			cfg.Errorf(tb, "expected output to match %q\noutput:\n%s", re.String(), out)
+			matched = false
+		}
+	}
+	return matched
+}
+
+// RequireMatches fails the test immediately unless output matches every regular
+// expression in patterns.
+//
+// See also [Harness.RequireMatches], [AssertMatches], [RequireMatch], and
+// [WaitMatches].
+func RequireMatches(tb testing.TB, view Viewable, patterns []string, opts ...Option) {
+	tb.Helper()
+
+	if !AssertMatches(tb, view, patterns, opts...) {
+		tb.FailNow()
+	}
+}
+
 // AssertNotMatch reports an error if output matches the regular expression
 // pattern. pattern is compiled with [regexp.Compile]; a compile error fails
 // the test immediately.
@@ -549,6 +625,55 @@ func Dimensions(out string, opts ...Option) (w, h int) {
 	return xansi.Dimensions(out)
 }
 
+// Region returns the content of out within rect, where X is the visible column
+// and Y is the row (Max is exclusive), which pairs well with the coordinate model
+// of layout packages. Rows are cut by visible column, and are ANSI-aware. Rows
+// outside of the output are omitted, and empty or inverted rectangles return an
+// empty string.
+//
+// See also [AssertRegion] and [Harness.AssertRegion].
+func Region(out string, rect image.Rectangle, opts ...Option) string {
+	cfg := collectOptions(opts...)
+	if cfg.stripANSI {
+		out = xansi.StripANSI(out)
+	}
+	return xansi.Region(out, rect)
+}
+
+// AssertRegion reports an error unless every substring in contents appears
+// in the output within rect (see [Region]). This is useful for asserting content
+// of a specific area of the screen (e.g. a sidebar), without matching the whole
+// output. It returns whether the output matched and allows the test to continue.
+//
+// See also [Harness.AssertRegion], [RequireRegion], [Region], and
+// [AssertStrings].
+func AssertRegion(tb testing.TB, view Viewable, rect image.Rectangle, contents []string, opts ...Option) bool {
+	tb.Helper()
+
+	cfg := collectOptions(opts...)
+	out := Region(view(), rect, opts...)
+	matched := true
+	for _, sub := range contents {
+		if !strings.Contains(out, sub) {
+			cfg.Errorf(tb, "expected region %v to contain %q\nregion:\n%s", rect, sub, out)
+			matched = false
+		}
+	}
+	return matched
+}
+
+// RequireRegion fails the test immediately unless every substring in contents
+// appears in the output within rect.
+//
+// See also [Harness.RequireRegion], [AssertRegion], and [RequireStrings].
+func RequireRegion(tb testing.TB, view Viewable, rect image.Rectangle, contents []string, opts ...Option) {
+	tb.Helper()
+
+	if !AssertRegion(tb, view, rect, contents, opts...) {
+		tb.FailNow()
+	}
+}
+
 // MessageCollector exposes messages observed by a test harness. [Harness] implements
 // this interface.
 type MessageCollector interface {
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"image"
 	"iter"
 	"reflect"
 	"slices"
@@ -281,6 +282,28 @@ func TestAssertMatch_fail(t *testing.T) {
 	}
 }
 
+func TestAssertMatches(t *testing.T) {
+	t.Parallel()
+	v := func() string { return "title\nstatus: 42 items\nfooter" }
+	RequireMatches(t, v, []string{`status: \d+ items`, `(?m)^footer$`})
+
+	st := &softTB{TB: t}
+	if AssertMatches(st, v, []string{`title`, `^status`, `\d{3}`}) {
+		t.Fatal("expected false")
+	}
+	if st.nErrors != 2 {
+		t.Fatalf("error calls = %d, want 2", st.nErrors)
+	}
+}
+
+func TestWaitMatches(t *testing.T) {
+	t.Parallel()
+	h := NewComponentHarness(t, &swapViewModel{}, WithWindowSize(80, 1))
+	h.SendProgram(appendMsg("flip")).
+		WaitMatches([]string{`all`, `f\w+e`}, WithTimeout(2*time.Second), WithCheck(5*time.Millisecond)).
+		AssertMatches([]string{`^all fine`})
+}
+
 func TestAssertNotMatch_fail(t *testing.T) {
 	t.Parallel()
 	st := &softTB{TB: t}
@@ -314,6 +337,25 @@ func TestAssertHeight_fail(t *testing.T) {
 	}
 }
 
+func TestAssertRegion(t *testing.T) {
+	t.Parallel()
+	v := func() string { return "Home    | Settings\nAbout   | \x1b[1mProfile\x1b[0m" }
+	sidebar := image.Rect(0, 0, 8, 2)
+
+	if !AssertRegion(t, v, sidebar, []string{"Home", "About"}) {
+		t.Fatal("expected sidebar to contain Home and About")
+	}
+	RequireRegion(t, v, image.Rect(10, 0, 18, 2), []string{"Settings", "Profile"}, WithANSI(false))
+
+	st := &softTB{TB: t}
+	if AssertRegion(st, v, sidebar, []string{"Home", "Settings"}) {
+		t.Fatal("expected false")
+	}
+	if st.nErrors != 1 {
+		t.Fatalf("error calls = %d, want 1", st.nErrors)
+	}
+}
+
 func TestPackageRequireString_andAssertHelpers_ok(t *testing.T) {
 	t.Parallel()
 	v := func() string { return "alpha beta" }
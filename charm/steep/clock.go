@@ -0,0 +1,127 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package steep
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Clock is a deterministic, manually advanced clock for models that depend on
+// time (animations, spinners, debounce, etc). Models under test should use
+// [Clock.Tick], [Clock.Every] and [Clock.Now] in place of [tea.Tick], [tea.Every]
+// and [time.Now], and tests step time forward with [Clock.Advance] (or
+// [Harness.AdvanceTime] when passed through [WithClock]).
+//
+// Commands created through [tea.Tick], [tea.Every], or any other wall-clock
+// timer are opaque to the harness and are NOT intercepted.
+//
+// A zero Clock is not usable, use [NewClock].
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*clockTimer
+	done   chan struct{}
+	once   sync.Once
+}
+
+type clockTimer struct {
+	at    time.Time
+	fired chan time.Time
+}
+
+// NewClock returns a new [Clock] starting at start. If start is zero, the clock
+// starts at the Unix epoch, so output which depends on the time is stable across
+// test runs.
+func NewClock(start time.Time) *Clock {
+	if start.IsZero() {
+		start = time.Unix(0, 0).UTC()
+	}
+	return &Clock{
+		now:  start,
+		done: make(chan struct{}),
+	}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Tick is the [Clock] equivalent of [tea.Tick]. The timer is registered when
+// Tick is called (rather than when the returned command runs), and fires once
+// the clock has been advanced by at least d.
+func (c *Clock) Tick(d time.Duration, fn func(time.Time) uv.Event) tea.Cmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.schedule(c.now.Add(d), fn)
+}
+
+// Every is the [Clock] equivalent of [tea.Every]. The timer fires when the clock
+// reaches the next multiple of d (relative to the zero time), like [tea.Every].
+func (c *Clock) Every(d time.Duration, fn func(time.Time) uv.Event) tea.Cmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.schedule(c.now.Truncate(d).Add(d), fn)
+}
+
+// schedule registers a timer firing at "at". Caller must hold [Clock.mu].
+func (c *Clock) schedule(at time.Time, fn func(time.Time) uv.Event) tea.Cmd {
+	t := &clockTimer{at: at, fired: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+
+	return func() uv.Event {
+		select {
+		case ts := <-t.fired:
+			return fn(ts)
+		case <-c.done:
+			return nil
+		}
+	}
+}
+
+// Pending returns the number of timers which have not yet fired.
+func (c *Clock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Advance moves the clock forward by d, firing all timers which are due, in
+// order of their deadline. Timers registered while firing (e.g. a model
+// re-scheduling a tick) are only fired by later calls to Advance.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	slices.SortStableFunc(c.timers, func(a, b *clockTimer) int {
+		return a.at.Compare(b.at)
+	})
+
+	var pending []*clockTimer
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.fired <- t.at
+	}
+	c.timers = pending
+}
+
+// Stop releases all commands still waiting on a timer. They return a nil
+// message. [NewHarness] calls Stop during test cleanup for a clock provided
+// through [WithClock].
+func (c *Clock) Stop() {
+	c.once.Do(func() { close(c.done) })
+}
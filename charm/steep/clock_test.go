@@ -0,0 +1,92 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package steep
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+func TestClock_advanceFiresDueTimers(t *testing.T) {
+	t.Parallel()
+	c := NewClock(time.Time{})
+	start := c.Now()
+
+	late := c.Tick(2*time.Second, func(ts time.Time) uv.Event { return ts })
+	early := c.Tick(time.Second, func(ts time.Time) uv.Event { return ts })
+
+	if c.Pending() != 2 {
+		t.Fatalf("pending = %d, want 2", c.Pending())
+	}
+
+	c.Advance(time.Second)
+	if got, _ := early().(time.Time); !got.Equal(start.Add(time.Second)) {
+		t.Fatalf("early fired at %v, want %v", got, start.Add(time.Second))
+	}
+	if c.Pending() != 1 {
+		t.Fatalf("pending = %d, want 1", c.Pending())
+	}
+
+	c.Advance(time.Second)
+	if got, _ := late().(time.Time); !got.Equal(start.Add(2 * time.Second)) {
+		t.Fatalf("late fired at %v, want %v", got, start.Add(2*time.Second))
+	}
+}
+
+func TestClock_stopReleasesCommands(t *testing.T) {
+	t.Parallel()
+	c := NewClock(time.Time{})
+	cmd := c.Every(time.Minute, func(ts time.Time) uv.Event { return ts })
+	c.Stop()
+	c.Stop()
+	if got := cmd(); got != nil {
+		t.Fatalf("got %v, want nil", got)
+	}
+}
+
+type clockTickMsg struct{}
+
+type clockCounterModel struct {
+	clock *Clock
+	n     int
+}
+
+func (m *clockCounterModel) View() string {
+	return "frame=" + strconv.Itoa(m.n)
+}
+
+func (m *clockCounterModel) Init() tea.Cmd {
+	return m.clock.Tick(time.Second, func(time.Time) uv.Event { return clockTickMsg{} })
+}
+
+func (m *clockCounterModel) Update(msg uv.Event) tea.Cmd {
+	if _, ok := msg.(clockTickMsg); ok {
+		m.n++
+		return m.clock.Tick(time.Second, func(time.Time) uv.Event { return clockTickMsg{} })
+	}
+	return nil
+}
+
+func TestHarness_AdvanceTime(t *testing.T) {
+	t.Parallel()
+	clock := NewClock(time.Time{})
+	h := NewComponentHarness(t, &clockCounterModel{clock: clock}, WithWindowSize(80, 1), WithClock(clock))
+
+	h.WaitString("frame=0")
+	WaitViewFunc(t, h.View, func(string) bool { return clock.Pending() == 1 })
+
+	h.AdvanceTime(500 * time.Millisecond).
+		WaitSettleMessages(WithSettle(20 * time.Millisecond)).
+		AssertString("frame=0").
+		AdvanceTime(500 * time.Millisecond).
+		WaitString("frame=1")
+
+	WaitViewFunc(t, h.View, func(string) bool { return clock.Pending() == 1 })
+	h.AdvanceTime(time.Second).WaitString("frame=2")
+}
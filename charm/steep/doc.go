@@ -13,4 +13,13 @@
 //
 // Use NewComponentHarness for components that expose View() string and an Update
 // method through the asynchronous [tea.Program] runtime.
+//
+// Both root models and components are wrapped by the harness, so every message
+// passed to Update is recorded regardless of which constructor was used. Message
+// helpers such as [WaitMessage], [AssertHasMessage] and [FilterMessagesType]
+// therefore work uniformly through the [MessageCollector] implemented by [Harness]:
+//
+//	h := steep.NewHarness(t, model)
+//	h.SendProgram(refreshMsg{})
+//	msg := steep.WaitMessage[refreshMsg](t, h)
 package steep
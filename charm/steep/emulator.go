@@ -617,6 +617,24 @@ func (h *Harness) RightClick(x, y int) *Harness {
 	return h.MouseClick(uv.MouseRight, x, y)
 }
 
+// MouseClickRect simulates a full press and release at the center of rect. This
+// is useful for clicking a rendered component (e.g. a layer, button, tab, etc)
+// when its bounds are known, without hard-coding cell coordinates. An empty rect
+// fails the test immediately.
+func (h *Harness) MouseClickRect(button uv.MouseButton, rect uv.Rectangle) *Harness {
+	h.tb.Helper()
+	if rect.Empty() {
+		h.tb.Fatalf("cannot click empty rectangle %v", rect)
+	}
+	return h.MouseClick(button, rect.Min.X+rect.Dx()/2, rect.Min.Y+rect.Dy()/2)
+}
+
+// LeftClickRect is [Harness.MouseClickRect] with the left mouse button.
+func (h *Harness) LeftClickRect(rect uv.Rectangle) *Harness {
+	h.tb.Helper()
+	return h.MouseClickRect(uv.MouseLeft, rect)
+}
+
 // MouseDrag simulates a drag from (x1, y1) to (x2, y2).
 func (h *Harness) MouseDrag(button uv.MouseButton, x1, y1, x2, y2 int) *Harness {
 	h.tb.Helper()
@@ -564,6 +564,25 @@ func TestHarness_TerminalMouseClick_deliversPressAndRelease(t *testing.T) {
 	}
 }
 
+func TestHarness_TerminalLeftClickRect_clicksCenter(t *testing.T) {
+	t.Parallel()
+	h := newTerminalHarness(t, 12, 6)
+	WaitMessageWhere(t, h, func(msg uv.Event) bool {
+		_, ok := msg.(tea.WindowSizeMsg)
+		return ok
+	})
+	enableEmulatorMouseReporting(t, h)
+	n := len(slices.Collect(h.MessageHistory()))
+
+	h.LeftClickRect(uv.Rect(2, 1, 5, 3))
+	WaitSettleMessages(t, h)
+
+	got := collectMouseTail(t, h, n)
+	if len(got) != 2 || got[0].kind != "click" || got[0].X != 4 || got[0].Y != 2 {
+		t.Fatalf("got %#v, want click+release at (4, 2)", got)
+	}
+}
+
 func TestHarness_TerminalLeftMiddleRightClick_buttons(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
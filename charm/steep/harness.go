@@ -52,6 +52,10 @@ func NewHarness(tb testing.TB, model tea.Model, opts ...Option) *Harness {
 		cfg = collectOptions(opts...)
 	}
 
+	if cfg.clock != nil {
+		tb.Cleanup(cfg.clock.Stop)
+	}
+
 	h := &Harness{
 		tb:       tb,
 		emulator: newEmulator(cfg.width, cfg.height),
@@ -193,6 +197,20 @@ func (h *Harness) FinalModel(opts ...Option) tea.Model {
 	return h.observer.currentModel()
 }
 
+// AdvanceTime advances the [Clock] provided through [WithClock] by d, firing any
+// timers which become due. Messages from fired timers are delivered
+// asynchronously, so pair this with a wait, e.g. [Harness.WaitString] or
+// [Harness.WaitSettleMessages].
+func (h *Harness) AdvanceTime(d time.Duration) *Harness {
+	h.tb.Helper()
+	cfg := collectOptions(h.opts...)
+	if cfg.clock == nil {
+		h.tb.Fatalf("AdvanceTime requires a clock, see WithClock")
+	}
+	cfg.clock.Advance(d)
+	return h
+}
+
 // MessageHistory returns a copy of all messages observed by the underlying model.
 func (h *Harness) MessageHistory() iter.Seq[uv.Event] {
 	h.tb.Helper()
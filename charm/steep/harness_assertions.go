@@ -6,6 +6,7 @@ package steep
 
 import (
 	"errors"
+	"image"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -151,6 +152,17 @@ func (h *Harness) WaitNotMatch(pattern string, opts ...Option) *Harness {
 	return h
 }
 
+// WaitMatches waits until the latest view output matches every regular
+// expression in patterns.
+//
+// See also [WaitMatches], [Harness.WaitMatch], [Harness.AssertMatches], and
+// [Harness.RequireMatches].
+func (h *Harness) WaitMatches(patterns []string, opts ...Option) *Harness {
+	h.tb.Helper()
+	WaitMatches(h.tb, h.View, patterns, h.mergedOpts(opts...)...)
+	return h
+}
+
 // AssertString reports an error unless content appears in view output. It
 // allows the test to continue.
 //
@@ -269,6 +281,30 @@ func (h *Harness) RequireMatch(pattern string, opts ...Option) *Harness {
 	return h
 }
 
+// AssertMatches reports an error unless view output matches every regular
+// expression in patterns. It allows the test to continue.
+//
+// See also [AssertMatches], [Harness.RequireMatches], [Harness.AssertMatch], and
+// [Harness.WaitMatches].
+func (h *Harness) AssertMatches(patterns []string, opts ...Option) *Harness {
+	h.tb.Helper()
+	AssertMatches(h.tb, h.View, patterns, h.mergedOpts(opts...)...)
+	return h
+}
+
+// RequireMatches fails the test immediately unless view output matches every
+// regular expression in patterns.
+//
+// See also [RequireMatches], [Harness.AssertMatches], [Harness.RequireMatch], and
+// [Harness.WaitMatches].
+func (h *Harness) RequireMatches(patterns []string, opts ...Option) *Harness {
+	h.tb.Helper()
+	if !AssertMatches(h.tb, h.View, patterns, h.mergedOpts(opts...)...) {
+		h.tb.FailNow()
+	}
+	return h
+}
+
 // AssertNotMatch reports an error if view output matches the regular expression
 // pattern.
 //
@@ -363,6 +399,29 @@ func (h *Harness) RequireDimensions(width, height int, opts ...Option) *Harness
 	return h
 }
 
+// AssertRegion reports an error unless every substring in contents appears in
+// the view output within rect. It allows the test to continue.
+//
+// See also [AssertRegion], [Harness.RequireRegion], [Harness.AssertStrings], and
+// [Region].
+func (h *Harness) AssertRegion(rect image.Rectangle, contents []string, opts ...Option) *Harness {
+	h.tb.Helper()
+	AssertRegion(h.tb, h.View, rect, contents, h.mergedOpts(opts...)...)
+	return h
+}
+
+// RequireRegion fails the test immediately unless every substring in contents
+// appears in the view output within rect.
+//
+// See also [RequireRegion], [Harness.AssertRegion], and [Harness.RequireStrings].
+func (h *Harness) RequireRegion(rect image.Rectangle, contents []string, opts ...Option) *Harness {
+	h.tb.Helper()
+	if !AssertRegion(h.tb, h.View, rect, contents, h.mergedOpts(opts...)...) {
+		h.tb.FailNow()
+	}
+	return h
+}
+
 // WaitSettleMessages waits until no messages have been observed for the
 // configured settle timeout.
 //
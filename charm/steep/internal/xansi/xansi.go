@@ -9,6 +9,8 @@ package xansi
 
 import (
 	"bytes"
+	"image"
+	"math"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
@@ -168,3 +170,28 @@ func Dimensions[T string | []byte](out T) (w, h int) {
 	}
 	return width, height
 }
+
+// Region returns the cells of out within rect, where X is the visible column and
+// Y is the line (Max is exclusive). Lines are cut by visible column, preserving
+// ANSI escape sequences. Lines outside of the output are omitted, and empty or
+// inverted rectangles return an empty string.
+func Region(out string, rect image.Rectangle) string {
+	if out == "" {
+		return ""
+	}
+
+	lines := strings.Split(out, "\n")
+
+	// Clamp to the output, so negative or out of range bounds can't be used to
+	// index the lines.
+	rect = rect.Intersect(image.Rect(0, 0, math.MaxInt, len(lines)))
+	if rect.Empty() {
+		return ""
+	}
+
+	lines = lines[rect.Min.Y:rect.Max.Y]
+	for i := range lines {
+		lines[i] = ansi.Cut(lines[i], rect.Min.X, rect.Max.X)
+	}
+	return strings.Join(lines, "\n")
+}
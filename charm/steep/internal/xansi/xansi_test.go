@@ -6,6 +6,7 @@ package xansi
 
 import (
 	"bytes"
+	"image"
 	"testing"
 )
 
@@ -144,3 +145,31 @@ func TestDimensions(t *testing.T) {
 		}
 	})
 }
+
+func TestRegion(t *testing.T) {
+	t.Parallel()
+	out := "sidebar | main\nHome    | body\n\x1b[1mAbout\x1b[0m   | more"
+
+	tests := []struct {
+		name string
+		rect image.Rectangle
+		want string
+	}{
+		{name: "sidebar", rect: image.Rect(0, 1, 7, 3), want: "Home   \nAbout  "},
+		{name: "main", rect: image.Rect(10, 0, 14, 2), want: "main\nbody"},
+		{name: "past-end", rect: image.Rect(0, 5, 10, 8), want: ""},
+		{name: "empty", rect: image.Rect(2, 2, 2, 2), want: ""},
+		{name: "negative", rect: image.Rect(0, -5, 5, -2), want: ""},
+		{name: "negative-x", rect: image.Rect(-5, 0, -2, 2), want: ""},
+		{name: "partially-negative", rect: image.Rect(-3, -1, 4, 1), want: "side"},
+		{name: "inverted", rect: image.Rectangle{Min: image.Pt(5, 2), Max: image.Pt(0, 0)}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := StripANSI(Region(out, tt.rect)); got != tt.want {
+				t.Fatalf("Region(%v) = %q, want %q", tt.rect, got, tt.want)
+			}
+		})
+	}
+}
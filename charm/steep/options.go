@@ -67,6 +67,10 @@ type options struct {
 	// envVars are extra environment variables to set for the [tea.Program].
 	envVars []string
 
+	// clock is an optional deterministic clock, advanced through
+	// [Harness.AdvanceTime].
+	clock *Clock
+
 	// ctx is the parent context for blocking [WaitString]-style helpers.
 	ctx           context.Context
 	wasContextSet bool
@@ -271,3 +275,12 @@ func WithEnvVars(vars ...string) Option {
 		}
 	}
 }
+
+// WithClock provides a deterministic [Clock] to the [Harness], which can then be
+// stepped with [Harness.AdvanceTime]. The model under test must use the same
+// clock (e.g. [Clock.Tick] instead of [tea.Tick]) for time to be controlled.
+func WithClock(clock *Clock) Option {
+	return func(cfg *options) {
+		cfg.clock = clock
+	}
+}
@@ -5,6 +5,7 @@
 package snapshot
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -66,6 +67,28 @@ func TestRequireEqualLoopCounters(t *testing.T) {
 	}
 }
 
+func TestBoolFlag(t *testing.T) {
+	t.Parallel()
+
+	const name = "snapshot-test-update"
+	fs := flag.NewFlagSet(t.Name(), flag.ContinueOnError)
+	if boolFlag(fs, name) {
+		t.Fatal("undefined flag should report false")
+	}
+
+	fs.Bool(name, false, "")
+	if boolFlag(fs, name) {
+		t.Fatal("unset flag should report false")
+	}
+
+	if err := fs.Set(name, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if !boolFlag(fs, name) {
+		t.Fatal("set flag should report true")
+	}
+}
+
 func TestRequireEqualEscapesANSI(t *testing.T) {
 	t.Parallel()
 
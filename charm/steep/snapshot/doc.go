@@ -5,7 +5,19 @@
 // Package snapshot provides snapshot assertions for tests.
 //
 // Snapshots are stored under testdata. Set UPDATE_SNAPSHOTS=true to create or
-// update snapshots.
+// update snapshots:
+//
+//	UPDATE_SNAPSHOTS=true go test ./...
+//
+// Alternatively, if the test binary defines a boolean "-update" flag (a common
+// golden-file convention), it is honored as well:
+//
+//	var _ = flag.Bool("update", false, "update snapshots")
+//
+//	go test ./... -update
+//
+// Multiple snapshots can be captured within a single test, either by asserting
+// multiple times (each is numbered), or by naming them with [WithSuffix].
 //
 //	snapshot.AssertEqual(t, model.View())
 //	snapshot.RequireEqual(t, model.View())
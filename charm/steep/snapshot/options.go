@@ -6,6 +6,7 @@ package snapshot
 
 import (
 	"bytes"
+	"flag"
 	"os"
 	"strconv"
 	"strings"
@@ -14,7 +15,10 @@ import (
 	"github.com/lrstanley/x/charm/steep/internal/xansi"
 )
 
-const envUpdateSnapshots = "UPDATE_SNAPSHOTS"
+const (
+	envUpdateSnapshots  = "UPDATE_SNAPSHOTS"
+	flagUpdateSnapshots = "update"
+)
 
 type options struct {
 	// transforms holds user-added byte transforms ([WithTransform]) applied in
@@ -61,6 +65,9 @@ func collectOptions(tb testing.TB, opts ...Option) options {
 	if !cfg.update {
 		cfg.update, _ = strconv.ParseBool(os.Getenv(envUpdateSnapshots))
 	}
+	if !cfg.update {
+		cfg.update = boolFlag(flag.CommandLine, flagUpdateSnapshots)
+	}
 
 	for _, opt := range opts {
 		if opt != nil {
@@ -89,6 +96,19 @@ func collectOptions(tb testing.TB, opts ...Option) options {
 	return cfg
 }
 
+// boolFlag reports whether the boolean flag with the provided name is defined on
+// the flag set (usually [flag.CommandLine]) and set to true. The snapshot package
+// intentionally does not define the flag itself, to avoid conflicts with test
+// binaries that already define their own "-update" flag.
+func boolFlag(fs *flag.FlagSet, name string) bool {
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	v, _ := strconv.ParseBool(f.Value.String())
+	return v
+}
+
 // Option configures snapshot normalization before comparison.
 type Option func(*options)
 
@@ -103,7 +123,8 @@ func WithTransform(fn func([]byte) []byte) Option {
 
 // WithUpdate sets whether snapshots should be updated. Defaults to the
 // UPDATE_SNAPSHOTS environment variable (and UPDATE_SNAPS for compatibility with
-// those migrating from github.com/gkampitakis/go-snaps).
+// those migrating from github.com/gkampitakis/go-snaps), or an "-update" boolean
+// flag, if the test binary defines one.
 func WithUpdate(update bool) Option {
 	return func(cfg *options) {
 		cfg.update = update
@@ -3,9 +3,12 @@
 // the LICENSE file.
 
 // Package utils is the module root for HTTP client utilities built on
-// [net/http.RoundTripper]. Subpackages are httpccache (http client cache),
-// httpcconc (http client concurrency), httpcquery (struct to query encoding),
-// httpclog (http client log), and httpcretry (http client retry).
+// [net/http.RoundTripper]. Subpackages are httpcbreaker (http client circuit
+// breaker), httpccache (http client cache), httpcconc (http client concurrency),
+// httpcdeadline (http client deadline propagation and server timing),
+// httpcdecompress (http client decompress), httpcquery (struct to query
+// encoding), httpclog (http client log), httpcretry (http client retry), and
+// httpctrace (http client trace context propagation).
 //
 // Each subpackage is imported on its own; this package exists only for module
 // documentation.
@@ -1,3 +1,11 @@
 module github.com/lrstanley/x/http/utils
 
 go 1.26.0
+
+require (
+	github.com/andybalholm/brotli v1.2.0
+	github.com/klauspost/compress v1.18.0
+	golang.org/x/net v0.57.0
+)
+
+require golang.org/x/text v0.40.0 // indirect
@@ -0,0 +1,273 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package httpcbreaker (http client circuit breaker) provides a
+// [net/http.RoundTripper] that stops sending requests to a failing upstream for a
+// cooldown window, after a number of consecutive failures.
+//
+// When composing with httpcretry, the breaker should be the outermost transport,
+// so that a request which exhausted all retries counts as a single failure:
+//
+//	breaker := httpcbreaker.NewTransport(&httpcbreaker.Config{
+//		BaseTransport: httpcretry.NewTransport(&httpcretry.Config{}),
+//	})
+package httpcbreaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by the transport when the circuit is open (or half-open,
+// with a probe request already in-flight), without sending the request.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of the circuit breaker.
+type State int
+
+const (
+	// StateClosed is the normal state, where all requests are sent.
+	StateClosed State = iota
+	// StateOpen is the state after too many consecutive failures, where all
+	// requests fail fast with [ErrOpen] until the cooldown elapses.
+	StateOpen
+	// StateHalfOpen is the state after the cooldown elapses, where a single probe
+	// request is allowed through to determine if the upstream has recovered.
+	StateHalfOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// FailureFunc determines whether a request outcome counts as a failure.
+type FailureFunc func(ctx context.Context, resp *http.Response, err error) bool
+
+// DefaultFailure is the default [FailureFunc]. It counts network errors and 5xx
+// status codes as failures. It does not count [context.Canceled] or
+// [context.DeadlineExceeded] of the request context, as this is often
+// intentional cancellation from the parent caller.
+func DefaultFailure(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= 500
+}
+
+// StateChangeFunc is called when the breaker transitions between states. It is
+// invoked synchronously, so it should not block.
+type StateChangeFunc func(from, to State)
+
+// Config is the configuration for the circuit breaker transport.
+type Config struct {
+	// BaseTransport is the base transport to use (will be chained). Defaults to
+	// [net/http.DefaultTransport], which allows for connection reuse, HTTP proxy
+	// support, etc.
+	BaseTransport http.RoundTripper
+
+	// FailureThreshold is the number of consecutive failures before the circuit
+	// opens. Defaults to 5.
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before allowing a probe request.
+	// Defaults to 30 seconds.
+	Cooldown time.Duration
+
+	// IsFailure determines whether a request outcome counts as a failure. Defaults
+	// to [DefaultFailure].
+	IsFailure FailureFunc
+
+	// OnStateChange is an optional callback invoked on state transitions, useful
+	// for metrics or logging.
+	OnStateChange StateChangeFunc
+}
+
+// Validate validates the circuit breaker configuration, and sets defaults. Use
+// this to validate the configuration, before passing it to [NewTransport] or
+// [NewClient], as they will panic if the configuration is invalid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("config cannot be nil")
+	}
+
+	if c.BaseTransport == nil {
+		c.BaseTransport = http.DefaultTransport
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = 30 * time.Second
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = DefaultFailure
+	}
+
+	return nil
+}
+
+// Transport is a circuit breaker [net/http.RoundTripper].
+type Transport struct {
+	config *Config
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewTransport returns a circuit breaker [net/http.RoundTripper]. See also
+// [NewClient]. This will panic if the configuration is invalid, which can be
+// avoided by using [Config.Validate] first.
+func NewTransport(config *Config) *Transport {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return &Transport{config: config}
+}
+
+// NewClient returns an [http.Client] using a circuit breaker transport. See also
+// [NewTransport]. The default timeout is 60 seconds. This will panic if the
+// configuration is invalid, which can be avoided by using [Config.Validate] first.
+func NewClient(config *Config) *http.Client {
+	return &http.Client{
+		Timeout:   60 * time.Second,
+		Transport: NewTransport(config),
+	}
+}
+
+// State returns the current state of the breaker. An open breaker whose cooldown
+// has elapsed is reported as [StateHalfOpen].
+func (t *Transport) State() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state == StateOpen && time.Since(t.openedAt) >= t.config.Cooldown {
+		return StateHalfOpen
+	}
+	return t.state
+}
+
+// Failures returns the current number of consecutive failures.
+func (t *Transport) Failures() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failures
+}
+
+// setStateLocked transitions to state. Caller must hold [Transport.mu].
+func (t *Transport) setStateLocked(state State) {
+	if t.state == state {
+		return
+	}
+	from := t.state
+	t.state = state
+	if state == StateOpen {
+		t.openedAt = time.Now()
+	}
+	if t.config.OnStateChange != nil {
+		t.config.OnStateChange(from, state)
+	}
+}
+
+// allow reports whether a request may be sent, and whether it is the half-open
+// probe.
+func (t *Transport) allow() (ok, probe bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch t.state {
+	case StateClosed:
+		return true, false
+	case StateOpen:
+		if time.Since(t.openedAt) < t.config.Cooldown {
+			return false, false
+		}
+		t.setStateLocked(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if t.probing {
+			return false, false
+		}
+		t.probing = true
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// record records the outcome of a request.
+func (t *Transport) record(probe, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if probe {
+		t.probing = false
+	}
+
+	if !failed {
+		t.failures = 0
+		t.setStateLocked(StateClosed)
+		return
+	}
+
+	t.failures++
+	if probe || t.failures >= t.config.FailureThreshold {
+		t.setStateLocked(StateOpen)
+		t.openedAt = time.Now() // Also restarts the cooldown when already open.
+	}
+}
+
+// release releases the half-open probe (if any) without recording an outcome.
+func (t *Transport) release(probe bool) {
+	if !probe {
+		return
+	}
+	t.mu.Lock()
+	t.probing = false
+	t.mu.Unlock()
+}
+
+// RoundTrip implements [net/http.RoundTripper].
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ok, probe := t.allow()
+	if !ok {
+		return nil, ErrOpen
+	}
+
+	resp, err := t.config.BaseTransport.RoundTrip(req)
+
+	failed := t.config.IsFailure(req.Context(), resp, err)
+	if !failed && req.Context().Err() != nil {
+		// Cancellation by the caller says nothing about the health of the upstream,
+		// so don't let it close the circuit.
+		t.release(probe)
+		return resp, err
+	}
+
+	t.record(probe, failed)
+	return resp, err
+}
@@ -0,0 +1,245 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package httpcbreaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// funcRoundTripper adapts a function to [http.RoundTripper] for tests.
+type funcRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f funcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func statusTransport(calls *atomic.Int32, status *atomic.Int32) http.RoundTripper {
+	return funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{StatusCode: int(status.Load()), Body: http.NoBody, Request: req}, nil
+	})
+}
+
+func roundTrip(t *testing.T, tr http.RoundTripper) error {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	resp, err := tr.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	return err
+}
+
+func TestTransport_opensAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	var calls, status atomic.Int32
+	status.Store(http.StatusBadGateway)
+
+	var transitions []string
+	tr := NewTransport(&Config{
+		BaseTransport:    statusTransport(&calls, &status),
+		FailureThreshold: 3,
+		Cooldown:         time.Hour,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	for range 3 {
+		if err := roundTrip(t, tr); err != nil {
+			t.Fatalf("unexpected error before threshold: %v", err)
+		}
+	}
+	if tr.State() != StateOpen {
+		t.Fatalf("state = %v, want open", tr.State())
+	}
+	if tr.Failures() != 3 {
+		t.Fatalf("failures = %d, want 3", tr.Failures())
+	}
+
+	if err := roundTrip(t, tr); !errors.Is(err, ErrOpen) {
+		t.Fatalf("err = %v, want ErrOpen", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("base calls = %d, want 3 (open circuit must short-circuit)", calls.Load())
+	}
+	if len(transitions) != 1 || transitions[0] != "closed->open" {
+		t.Fatalf("transitions = %v", transitions)
+	}
+}
+
+func TestTransport_successResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	var calls, status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	tr := NewTransport(&Config{BaseTransport: statusTransport(&calls, &status), FailureThreshold: 2})
+
+	_ = roundTrip(t, tr)
+	status.Store(http.StatusOK)
+	_ = roundTrip(t, tr)
+	status.Store(http.StatusInternalServerError)
+	_ = roundTrip(t, tr)
+
+	if tr.State() != StateClosed {
+		t.Fatalf("state = %v, want closed", tr.State())
+	}
+	if tr.Failures() != 1 {
+		t.Fatalf("failures = %d, want 1", tr.Failures())
+	}
+}
+
+func TestTransport_halfOpenProbe(t *testing.T) {
+	t.Parallel()
+
+	var calls, status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	tr := NewTransport(&Config{
+		BaseTransport:    statusTransport(&calls, &status),
+		FailureThreshold: 1,
+		Cooldown:         20 * time.Millisecond,
+	})
+
+	_ = roundTrip(t, tr)
+	if tr.State() != StateOpen {
+		t.Fatalf("state = %v, want open", tr.State())
+	}
+
+	// Failed probe re-opens the circuit.
+	time.Sleep(25 * time.Millisecond)
+	if tr.State() != StateHalfOpen {
+		t.Fatalf("state = %v, want half-open", tr.State())
+	}
+	_ = roundTrip(t, tr)
+	if tr.State() != StateOpen {
+		t.Fatalf("state = %v, want open after failed probe", tr.State())
+	}
+	if err := roundTrip(t, tr); !errors.Is(err, ErrOpen) {
+		t.Fatalf("err = %v, want ErrOpen", err)
+	}
+
+	// Successful probe closes the circuit.
+	time.Sleep(25 * time.Millisecond)
+	status.Store(http.StatusOK)
+	if err := roundTrip(t, tr); err != nil {
+		t.Fatal(err)
+	}
+	if tr.State() != StateClosed {
+		t.Fatalf("state = %v, want closed after successful probe", tr.State())
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("base calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestTransport_singleProbeInFlight(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var fail atomic.Bool
+	fail.Store(true)
+
+	tr := NewTransport(&Config{
+		BaseTransport: funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+			if fail.Load() {
+				return nil, errors.New("boom")
+			}
+			started <- struct{}{}
+			<-release
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}),
+		FailureThreshold: 1,
+		Cooldown:         time.Millisecond,
+	})
+
+	if err := roundTrip(t, tr); err == nil {
+		t.Fatal("expected error")
+	}
+	time.Sleep(5 * time.Millisecond)
+	fail.Store(false)
+
+	done := make(chan error, 1)
+	go func() { done <- roundTrip(t, tr) }()
+	<-started
+
+	if err := roundTrip(t, tr); !errors.Is(err, ErrOpen) {
+		t.Fatalf("err = %v, want ErrOpen while probe is in-flight", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if tr.State() != StateClosed {
+		t.Fatalf("state = %v, want closed", tr.State())
+	}
+}
+
+func TestTransport_callerCancellationIsNotFailure(t *testing.T) {
+	t.Parallel()
+
+	tr := NewTransport(&Config{
+		BaseTransport: funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+			return nil, req.Context().Err()
+		}),
+		FailureThreshold: 1,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/", nil)
+	if _, err := tr.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if tr.State() != StateClosed || tr.Failures() != 0 {
+		t.Fatalf("state = %v failures = %d, want closed with no failures", tr.State(), tr.Failures())
+	}
+}
+
+func TestDefaultFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{name: "network-error", err: errors.New("dial"), want: true},
+		{name: "ok", resp: &http.Response{StatusCode: http.StatusOK}, want: false},
+		{name: "not-found", resp: &http.Response{StatusCode: http.StatusNotFound}, want: false},
+		{name: "too-many-requests", resp: &http.Response{StatusCode: http.StatusTooManyRequests}, want: false},
+		{name: "server-error", resp: &http.Response{StatusCode: http.StatusInternalServerError}, want: true},
+	}
+	for _, tt := range tests {
+		if got := DefaultFailure(ctx, tt.resp, tt.err); got != tt.want {
+			t.Errorf("%s: DefaultFailure = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStateString(t *testing.T) {
+	t.Parallel()
+	for state, want := range map[State]string{
+		StateClosed:   "closed",
+		StateOpen:     "open",
+		StateHalfOpen: "half-open",
+		State(99):     "unknown",
+	} {
+		if got := state.String(); got != want {
+			t.Errorf("State(%d).String() = %q, want %q", state, got, want)
+		}
+	}
+}
@@ -4,24 +4,44 @@
 
 // Package httpcconc (http client concurrency) provides a [net/http.RoundTripper]
 // that caps how many HTTP requests run at once; additional callers block until a
-// slot is free.
+// slot is free, or their request context is canceled.
 package httpcconc
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 )
 
-type transport struct {
+// Option configures the concurrency limiting transport.
+type Option func(*Transport)
+
+// WithOnWait sets a callback which is invoked every time a request has to queue
+// for a slot, with the number of requests currently waiting (including the
+// request being queued). This can be used to surface saturation in metrics. It is
+// invoked synchronously, so it should not block.
+func WithOnWait(fn func(waiting int)) Option {
+	return func(t *Transport) {
+		t.onWait = fn
+	}
+}
+
+// Transport is a [net/http.RoundTripper] which limits the number of concurrent
+// requests. See [NewTransport].
+type Transport struct {
 	base      http.RoundTripper // The underlying [net/http.RoundTripper] to delegate requests to.
 	semaphore chan struct{}     // The semaphore to limit concurrent requests.
+	onWait    func(waiting int) // Optional callback invoked when a request has to queue.
+
+	inFlight atomic.Int64 // Number of requests currently holding a slot.
+	waiting  atomic.Int64 // Number of requests currently queued for a slot.
 }
 
 // NewTransport returns a [net/http.RoundTripper] that limits the number of
 // concurrent requests. It wraps another [net/http.RoundTripper] and ensures that
 // only a maximum number of requests can be processed simultaneously, while
 // allowing unlimited goroutines to queue up.
-func NewTransport(maxConcurrent int, baseTransport http.RoundTripper) http.RoundTripper {
+func NewTransport(maxConcurrent int, baseTransport http.RoundTripper, opts ...Option) *Transport {
 	if baseTransport == nil {
 		baseTransport = http.DefaultTransport
 	}
@@ -30,26 +50,74 @@ func NewTransport(maxConcurrent int, baseTransport http.RoundTripper) http.Round
 		maxConcurrent = 1
 	}
 
-	return &transport{
+	t := &Transport{
 		base:      baseTransport,
 		semaphore: make(chan struct{}, maxConcurrent),
 	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+
+	return t
 }
 
 // NewClient returns an [http.Client] whose transport limits concurrent in-flight
 // requests. See [NewTransport]. The default timeout is 60 seconds.
-func NewClient(maxConcurrent int, baseTransport http.RoundTripper) *http.Client {
+func NewClient(maxConcurrent int, baseTransport http.RoundTripper, opts ...Option) *http.Client {
 	return &http.Client{
 		Timeout:   60 * time.Second,
-		Transport: NewTransport(maxConcurrent, baseTransport),
+		Transport: NewTransport(maxConcurrent, baseTransport, opts...),
+	}
+}
+
+// InFlight returns the number of requests currently holding a slot.
+func (cl *Transport) InFlight() int {
+	return int(cl.inFlight.Load())
+}
+
+// Waiting returns the number of requests currently queued, waiting for a slot.
+func (cl *Transport) Waiting() int {
+	return int(cl.waiting.Load())
+}
+
+// acquire acquires a semaphore slot, returning the request context error if it
+// is canceled while waiting.
+func (cl *Transport) acquire(req *http.Request) error {
+	select {
+	case cl.semaphore <- struct{}{}:
+		return nil
+	default:
+	}
+
+	waiting := cl.waiting.Add(1)
+	defer cl.waiting.Add(-1)
+
+	if cl.onWait != nil {
+		cl.onWait(int(waiting))
+	}
+
+	select {
+	case cl.semaphore <- struct{}{}:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
 	}
 }
 
 // RoundTrip implements [net/http.RoundTripper] interface. It acquires a semaphore slot
-// before making the request and releases it after the request completes.
-func (cl *transport) RoundTrip(req *http.Request) (*http.Response, error) {
-	cl.semaphore <- struct{}{}
+// before making the request and releases it after the request completes. If the
+// request context is canceled while waiting for a slot, the context error is
+// returned immediately, without ever holding a slot.
+func (cl *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := cl.acquire(req); err != nil {
+		return nil, err
+	}
+	cl.inFlight.Add(1)
 	defer func() {
+		cl.inFlight.Add(-1)
 		<-cl.semaphore
 	}()
 	return cl.base.RoundTrip(req)
@@ -5,6 +5,7 @@
 package httpcconc
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -193,6 +194,117 @@ func TestRoundTrip_releasesSemaphoreOnError(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_canceledWhileQueued(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var calls atomic.Int32
+	base := funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+
+	tr := NewTransport(1, base)
+
+	// Occupy the only slot.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com/", nil))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = resp.Body.Close()
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/", nil)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := tr.RoundTrip(req)
+		errs <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued request did not return promptly after cancellation")
+	}
+
+	close(release)
+	<-done
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("base RoundTrip calls = %d, want 1 (canceled request must not be sent)", got)
+	}
+}
+
+func TestTransport_inFlightAndWaiting(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	base := funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+
+	var maxWaiting atomic.Int32
+	tr := NewTransport(2, base, WithOnWait(func(waiting int) {
+		for {
+			old := maxWaiting.Load()
+			if int32(waiting) <= old || maxWaiting.CompareAndSwap(old, int32(waiting)) {
+				break
+			}
+		}
+	}))
+
+	const nReq = 5
+	var wg sync.WaitGroup
+	wg.Add(nReq)
+	for range nReq {
+		go func() {
+			defer wg.Done()
+			resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com/", nil))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+
+	deadline := time.After(5 * time.Second)
+	for tr.InFlight() != 2 || tr.Waiting() != nReq-2 {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out; inFlight=%d waiting=%d", tr.InFlight(), tr.Waiting())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if got := maxWaiting.Load(); got != nReq-2 {
+		t.Fatalf("max waiting reported to OnWait = %d, want %d", got, nReq-2)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if tr.InFlight() != 0 || tr.Waiting() != 0 {
+		t.Fatalf("after completion: inFlight=%d waiting=%d, want 0", tr.InFlight(), tr.Waiting())
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	t.Parallel()
 
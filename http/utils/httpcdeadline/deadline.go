@@ -0,0 +1,277 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package httpcdeadline (http client deadline) provides a [net/http.RoundTripper]
+// that propagates the request context deadline to the server through a header
+// (for coordinated timeouts across services), and parses the [Server-Timing]
+// response header into metrics which can be logged with [log/slog].
+//
+// [Server-Timing]: https://www.w3.org/TR/server-timing/
+package httpcdeadline
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderRequestDeadline is the default header used to propagate the request
+	// deadline.
+	HeaderRequestDeadline = "X-Request-Deadline"
+	// HeaderServerTiming is the Server-Timing response header.
+	HeaderServerTiming = "Server-Timing"
+)
+
+// ServerTiming is a single metric of a [Server-Timing] header.
+//
+// [Server-Timing]: https://www.w3.org/TR/server-timing/
+type ServerTiming struct {
+	// Name is the name of the metric, e.g. "db".
+	Name string
+	// Duration is the duration of the metric (the "dur" parameter), if provided.
+	Duration time.Duration
+	// Description is the description of the metric (the "desc" parameter), if
+	// provided.
+	Description string
+}
+
+// LogValue implements [log/slog.LogValuer]. Metrics with only a duration are
+// logged as the duration, otherwise as a group of the duration ("dur") and
+// description ("desc"), if set.
+func (m ServerTiming) LogValue() slog.Value {
+	if m.Description == "" {
+		return slog.DurationValue(m.Duration)
+	}
+	return slog.GroupValue(slog.Duration("dur", m.Duration), slog.String("desc", m.Description))
+}
+
+// ServerTimingAttrs returns an attribute for each metric, keyed by the metric
+// name. See [ServerTiming.LogValue] for how each metric is logged. For example:
+//
+//	timings, _ := httpcdeadline.ServerTimingFromResponse(resp)
+//	logger.Info("request complete", slog.GroupAttrs("server_timing", httpcdeadline.ServerTimingAttrs(timings)...))
+func ServerTimingAttrs(timings []ServerTiming) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(timings))
+	for _, m := range timings {
+		attrs = append(attrs, slog.Any(m.Name, m))
+	}
+	return attrs
+}
+
+// ParseServerTiming parses the values of one or more [Server-Timing] headers,
+// e.g. `db;dur=53, cache;desc="Cache Read";dur=23.2, miss`. Durations are in
+// milliseconds. Malformed metrics and parameters are skipped, and unknown
+// parameters are ignored.
+//
+// [Server-Timing]: https://www.w3.org/TR/server-timing/
+func ParseServerTiming(values ...string) []ServerTiming {
+	var timings []ServerTiming
+	for _, value := range values {
+		for metric := range splitUnquoted(value, ',') {
+			params := splitUnquoted(metric, ';')
+			var m ServerTiming
+
+			first := true
+			for param := range params {
+				param = strings.TrimSpace(param)
+				if first {
+					m.Name = param
+					first = false
+					continue
+				}
+
+				key, val, _ := strings.Cut(param, "=")
+				val = unquote(strings.TrimSpace(val))
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "dur":
+					if ms, err := strconv.ParseFloat(val, 64); err == nil && ms >= 0 {
+						m.Duration = time.Duration(ms * float64(time.Millisecond))
+					}
+				case "desc":
+					m.Description = val
+				}
+			}
+
+			if m.Name != "" {
+				timings = append(timings, m)
+			}
+		}
+	}
+	return timings
+}
+
+// splitUnquoted splits s by sep, ignoring separators within quoted strings.
+func splitUnquoted(s string, sep byte) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		quoted := false
+		start := 0
+		for i := 0; i < len(s); i++ {
+			switch {
+			case s[i] == '\\' && quoted:
+				i++
+			case s[i] == '"':
+				quoted = !quoted
+			case s[i] == sep && !quoted:
+				if !yield(s[start:i]) {
+					return
+				}
+				start = i + 1
+			}
+		}
+		yield(s[start:])
+	}
+}
+
+// unquote removes the quotes (and escapes) of a quoted-string, if quoted.
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+type contextKey struct{}
+
+// ServerTimingFromResponse returns the server timing metrics parsed by the
+// transport, if any. Metrics are attached to the context of the request of the
+// response (see [net/http.Response.Request]). Returns false if the response
+// wasn't returned by the transport, server timing parsing is disabled (see
+// [Config.DisableServerTiming]), or the server didn't send any metrics.
+func ServerTimingFromResponse(resp *http.Response) ([]ServerTiming, bool) {
+	if resp == nil || resp.Request == nil {
+		return nil, false
+	}
+	timings, ok := resp.Request.Context().Value(contextKey{}).([]ServerTiming)
+	return timings, ok
+}
+
+// Config is the configuration for the deadline transport.
+type Config struct {
+	// BaseTransport is the base transport to use (will be chained). Defaults to
+	// [net/http.DefaultTransport], which allows for connection reuse, HTTP proxy
+	// support, etc.
+	BaseTransport http.RoundTripper
+
+	// Header is the header used to propagate the request deadline. Defaults to
+	// [HeaderRequestDeadline].
+	Header string
+
+	// Relative sends the time remaining until the deadline, in milliseconds (e.g.
+	// "1500"), rather than the absolute deadline (in RFC 3339 format, in UTC, e.g.
+	// "2024-01-01T00:00:01.5Z"). Relative deadlines don't depend on the clocks of
+	// the client and server being in sync, but don't account for the time taken
+	// to send the request.
+	Relative bool
+
+	// DisableDeadline disables propagating the request deadline.
+	DisableDeadline bool
+
+	// DisableServerTiming disables parsing the Server-Timing response header. See
+	// [ServerTimingFromResponse].
+	DisableServerTiming bool
+}
+
+// Validate validates the deadline configuration, and sets defaults. Use this to
+// validate the configuration, before passing it to [NewTransport] or
+// [NewClient], as they will panic if the configuration is invalid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("config cannot be nil")
+	}
+
+	if c.BaseTransport == nil {
+		c.BaseTransport = http.DefaultTransport
+	}
+
+	if c.Header == "" {
+		c.Header = HeaderRequestDeadline
+	}
+
+	return nil
+}
+
+type transport struct {
+	config *Config
+}
+
+// NewTransport returns a [net/http.RoundTripper] that propagates the request
+// context deadline (if any) through a header, and parses the Server-Timing
+// response header (see [ServerTimingFromResponse]). See also [NewClient]. This
+// will panic if the configuration is invalid, which can be avoided by using
+// [Config.Validate] first.
+func NewTransport(config *Config) http.RoundTripper {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return &transport{config: config}
+}
+
+// NewClient returns an [http.Client] which propagates request deadlines, and
+// parses server timing metrics. See also [NewTransport]. The default timeout is
+// 60 seconds. This will panic if the configuration is invalid, which can be
+// avoided by using [Config.Validate] first.
+func NewClient(config *Config) *http.Client {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return &http.Client{
+		Timeout:   60 * time.Second,
+		Transport: NewTransport(config),
+	}
+}
+
+// RoundTrip implements [net/http.RoundTripper]. The original request is never
+// modified; when the deadline header is injected, a clone of the request is sent
+// instead. Deadline headers already present on the request are left untouched.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if deadline, ok := req.Context().Deadline(); ok && !t.config.DisableDeadline && req.Header.Get(t.config.Header) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(t.config.Header, t.formatDeadline(deadline))
+	}
+
+	resp, err := t.config.BaseTransport.RoundTrip(req)
+	if err != nil || t.config.DisableServerTiming {
+		return resp, err
+	}
+
+	timings := ParseServerTiming(resp.Header.Values(HeaderServerTiming)...)
+	if len(timings) > 0 {
+		if resp.Request == nil {
+			resp.Request = req
+		}
+		resp.Request = resp.Request.WithContext(context.WithValue(resp.Request.Context(), contextKey{}, timings))
+	}
+
+	return resp, nil
+}
+
+// formatDeadline formats the deadline for the deadline header.
+func (t *transport) formatDeadline(deadline time.Time) string {
+	if t.config.Relative {
+		return strconv.FormatInt(max(0, time.Until(deadline).Milliseconds()), 10)
+	}
+	return deadline.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,245 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package httpcdeadline
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// funcRoundTripper adapts a function to [http.RoundTripper] for tests.
+type funcRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f funcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// captureTransport records the request headers, and responds with the provided
+// Server-Timing header values.
+func captureTransport(got *http.Header, serverTiming ...string) http.RoundTripper {
+	return funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		*got = req.Header.Clone()
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: req}
+		for _, v := range serverTiming {
+			resp.Header.Add(HeaderServerTiming, v)
+		}
+		return resp, nil
+	})
+}
+
+func TestParseServerTiming(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []string
+		want   []ServerTiming
+	}{
+		{name: "empty", values: []string{""}, want: nil},
+		{name: "name-only", values: []string{"miss"}, want: []ServerTiming{{Name: "miss"}}},
+		{
+			name:   "duration",
+			values: []string{"db;dur=53"},
+			want:   []ServerTiming{{Name: "db", Duration: 53 * time.Millisecond}},
+		},
+		{
+			name:   "multiple",
+			values: []string{`db;dur=53, app;dur=47.2;desc="Application", miss`},
+			want: []ServerTiming{
+				{Name: "db", Duration: 53 * time.Millisecond},
+				{Name: "app", Duration: 47200 * time.Microsecond, Description: "Application"},
+				{Name: "miss"},
+			},
+		},
+		{
+			name:   "quoted-separators",
+			values: []string{`cache;desc="Read; hit, \"warm\"";dur=1`},
+			want:   []ServerTiming{{Name: "cache", Duration: time.Millisecond, Description: `Read; hit, "warm"`}},
+		},
+		{
+			name:   "multiple-headers",
+			values: []string{"a;dur=1", "b;DUR=2;unknown=x"},
+			want: []ServerTiming{
+				{Name: "a", Duration: time.Millisecond},
+				{Name: "b", Duration: 2 * time.Millisecond},
+			},
+		},
+		{
+			name:   "invalid-duration",
+			values: []string{"db;dur=abc, ;dur=1"},
+			want:   []ServerTiming{{Name: "db"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParseServerTiming(tt.values...); !slices.Equal(got, tt.want) {
+				t.Fatalf("ParseServerTiming(%q) = %+v, want %+v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerTimingAttrs(t *testing.T) {
+	t.Parallel()
+
+	attrs := ServerTimingAttrs([]ServerTiming{
+		{Name: "db", Duration: time.Second},
+		{Name: "app", Duration: time.Second, Description: "Application"},
+	})
+
+	want := []slog.Attr{
+		slog.Duration("db", time.Second),
+		slog.Group("app", slog.Duration("dur", time.Second), slog.String("desc", "Application")),
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("got %d attrs, want %d", len(attrs), len(want))
+	}
+	for i := range want {
+		attrs[i].Value = attrs[i].Value.Resolve()
+		if !attrs[i].Equal(want[i]) {
+			t.Errorf("attr %d = %v, want %v", i, attrs[i], want[i])
+		}
+	}
+}
+
+func TestNewTransport_deadline(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(t.Context(), deadline)
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		config Config
+		header http.Header
+		check  func(t *testing.T, value string)
+	}{
+		{
+			name: "absolute",
+			ctx:  ctx,
+			check: func(t *testing.T, value string) {
+				got, err := time.Parse(time.RFC3339Nano, value)
+				if err != nil || !got.Equal(deadline.UTC()) {
+					t.Fatalf("header = %q (%v), want %s", value, err, deadline.UTC().Format(time.RFC3339Nano))
+				}
+			},
+		},
+		{
+			name:   "relative",
+			ctx:    ctx,
+			config: Config{Relative: true},
+			check: func(t *testing.T, value string) {
+				ms, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || ms <= 0 || ms > time.Minute.Milliseconds() {
+					t.Fatalf("header = %q (%v), want remaining milliseconds", value, err)
+				}
+			},
+		},
+		{
+			name: "no-deadline",
+			ctx:  t.Context(),
+			check: func(t *testing.T, value string) {
+				if value != "" {
+					t.Fatalf("header = %q, want none", value)
+				}
+			},
+		},
+		{
+			name:   "disabled",
+			ctx:    ctx,
+			config: Config{DisableDeadline: true},
+			check: func(t *testing.T, value string) {
+				if value != "" {
+					t.Fatalf("header = %q, want none", value)
+				}
+			},
+		},
+		{
+			name:   "existing",
+			ctx:    ctx,
+			header: http.Header{HeaderRequestDeadline: {"keep"}},
+			check: func(t *testing.T, value string) {
+				if value != "keep" {
+					t.Fatalf("header = %q, want %q", value, "keep")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got http.Header
+			config := tt.config
+			config.BaseTransport = captureTransport(&got)
+
+			req := httptest.NewRequestWithContext(tt.ctx, http.MethodGet, "http://example.com", http.NoBody)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+
+			resp, err := NewTransport(&config).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			tt.check(t, got.Get(HeaderRequestDeadline))
+			if len(tt.header) == 0 && req.Header.Get(HeaderRequestDeadline) != "" {
+				t.Fatal("original request was modified")
+			}
+		})
+	}
+}
+
+func TestNewTransport_serverTiming(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add(HeaderServerTiming, "db;dur=12.5")
+		w.Header().Add(HeaderServerTiming, `app;desc="Application"`)
+	}))
+	t.Cleanup(srv.Close)
+
+	want := []ServerTiming{
+		{Name: "db", Duration: 12500 * time.Microsecond},
+		{Name: "app", Description: "Application"},
+	}
+
+	for _, disabled := range []bool{false, true} {
+		client := NewClient(&Config{DisableServerTiming: disabled})
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, http.NoBody)
+		req.RequestURI = ""
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		got, ok := ServerTimingFromResponse(resp)
+		if disabled {
+			if ok {
+				t.Fatalf("disabled: got timings %+v, want none", got)
+			}
+			continue
+		}
+		if !ok || !slices.Equal(got, want) {
+			t.Fatalf("got timings %+v (ok: %v), want %+v", got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package httpcdecompress (http client decompress) provides a
+// [net/http.RoundTripper] that advertises the configured content encodings via
+// the Accept-Encoding header, and transparently decompresses responses based on
+// the Content-Encoding header.
+//
+// Brotli, Zstandard, gzip and deflate are supported out of the box (see
+// [Brotli], [Zstd], [Gzip] and [Deflate]). Other encodings can be added with
+// [Config.Encodings], e.g:
+//
+//	transport := httpcdecompress.NewTransport(&httpcdecompress.Config{
+//		Encodings: []httpcdecompress.Encoding{
+//			{Name: "x-custom", NewReader: newCustomReader},
+//			httpcdecompress.Zstd,
+//			httpcdecompress.Gzip,
+//		},
+//	})
+//
+// When composing with httpclog, the decompress transport should be below the
+// logger (i.e. the BaseTransport of the logger), so that logged bodies and sizes
+// are of the decompressed response.
+package httpcdecompress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ReaderFunc returns a reader which decompresses r.
+type ReaderFunc func(r io.Reader) (io.ReadCloser, error)
+
+// Encoding is a content encoding supported by the transport.
+type Encoding struct {
+	// Name is the name of the encoding, as used in the Accept-Encoding and
+	// Content-Encoding headers (e.g. "gzip"). Case-insensitive.
+	Name string
+
+	// NewReader returns a reader which decompresses the response body.
+	NewReader ReaderFunc
+}
+
+var (
+	// Brotli is the Brotli [Encoding], using github.com/andybalholm/brotli.
+	Brotli = Encoding{
+		Name: "br",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(brotli.NewReader(r)), nil
+		},
+	}
+
+	// Zstd is the Zstandard [Encoding], using github.com/klauspost/compress/zstd.
+	Zstd = Encoding{
+		Name: "zstd",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			// A single goroutine is plenty for a response body, and avoids
+			// spinning up background decoders for each response.
+			d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			return d.IOReadCloser(), nil
+		},
+	}
+
+	// Gzip is the gzip [Encoding], using [compress/gzip].
+	Gzip = Encoding{
+		Name: "gzip",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	}
+
+	// Deflate is the deflate [Encoding], using [compress/zlib] (HTTP "deflate" is
+	// zlib-wrapped, see RFC 9110).
+	Deflate = Encoding{
+		Name: "deflate",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return zlib.NewReader(r)
+		},
+	}
+)
+
+// Config is the configuration for the decompress transport.
+type Config struct {
+	// BaseTransport is the base transport to use (will be chained). Defaults to
+	// [net/http.DefaultTransport], which allows for connection reuse, HTTP proxy
+	// support, etc.
+	BaseTransport http.RoundTripper
+
+	// Encodings are the supported content encodings, in order of preference (the
+	// order they are advertised in the Accept-Encoding header). Defaults to
+	// [Brotli], [Zstd], [Gzip] and [Deflate].
+	Encodings []Encoding
+}
+
+// Validate validates the decompress configuration, and sets defaults. Use this
+// to validate the configuration, before passing it to [NewTransport] or
+// [NewClient], as they will panic if the configuration is invalid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("config cannot be nil")
+	}
+
+	if c.BaseTransport == nil {
+		c.BaseTransport = http.DefaultTransport
+	}
+	if len(c.Encodings) == 0 {
+		c.Encodings = []Encoding{Brotli, Zstd, Gzip, Deflate}
+	}
+
+	for _, enc := range c.Encodings {
+		if enc.Name == "" || strings.ContainsAny(enc.Name, ", ") {
+			return errors.New("encoding name cannot be empty, or contain commas or spaces")
+		}
+		if enc.NewReader == nil {
+			return errors.New("encoding " + enc.Name + " has no reader")
+		}
+	}
+
+	return nil
+}
+
+// Transport is a [net/http.RoundTripper] which transparently decompresses
+// responses. See [NewTransport].
+type Transport struct {
+	config         *Config
+	acceptEncoding string
+}
+
+// NewTransport returns a [net/http.RoundTripper] which advertises the configured
+// encodings, and transparently decompresses responses. See also [NewClient].
+// This will panic if the configuration is invalid, which can be avoided by using
+// [Config.Validate] first.
+//
+// Requests which already have an Accept-Encoding or Range header are passed
+// through unmodified, and their responses are not decompressed, as the caller
+// likely wants the raw response. Decompressed responses have the
+// Content-Encoding and Content-Length headers removed,
+// [http.Response.ContentLength] set to -1, and [http.Response.Uncompressed] set
+// to true.
+func NewTransport(config *Config) *Transport {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+
+	names := make([]string, len(config.Encodings))
+	for i, enc := range config.Encodings {
+		names[i] = enc.Name
+	}
+
+	return &Transport{
+		config:         config,
+		acceptEncoding: strings.Join(names, ", "),
+	}
+}
+
+// NewClient returns an [http.Client] using a decompress transport. See also
+// [NewTransport]. The default timeout is 60 seconds. This will panic if the
+// configuration is invalid, which can be avoided by using [Config.Validate] first.
+func NewClient(config *Config) *http.Client {
+	return &http.Client{
+		Timeout:   60 * time.Second,
+		Transport: NewTransport(config),
+	}
+}
+
+// RoundTrip implements [net/http.RoundTripper].
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" {
+		return t.config.BaseTransport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", t.acceptEncoding)
+
+	resp, err := t.config.BaseTransport.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		return resp, err
+	}
+
+	readers, ok := t.readers(resp.Header.Values("Content-Encoding"))
+	if !ok || len(readers) == 0 {
+		return resp, nil
+	}
+
+	resp.Body = &body{body: resp.Body, readers: readers}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// readers returns the readers needed to decode the provided Content-Encoding
+// header values, in the order they need to be applied, and false if any of the
+// encodings are unsupported.
+func (t *Transport) readers(values []string) ([]ReaderFunc, bool) {
+	var readers []ReaderFunc
+	for _, value := range values {
+		for name := range strings.SplitSeq(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || strings.EqualFold(name, "identity") {
+				continue
+			}
+
+			idx := slices.IndexFunc(t.config.Encodings, func(enc Encoding) bool {
+				return strings.EqualFold(enc.Name, name)
+			})
+			if idx < 0 {
+				return nil, false
+			}
+			readers = append(readers, t.config.Encodings[idx].NewReader)
+		}
+	}
+
+	// Encodings are listed in the order they were applied, so decode in reverse.
+	slices.Reverse(readers)
+	return readers, true
+}
+
+// body lazily wraps the response body with the decoders on first read, so
+// responses without a body (e.g. HEAD requests) don't fail.
+type body struct {
+	body    io.ReadCloser
+	readers []ReaderFunc
+
+	r       io.Reader
+	closers []io.Closer
+	err     error
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	if b.r == nil && b.err == nil {
+		b.r = b.body
+		for _, newReader := range b.readers {
+			rc, err := newReader(b.r)
+			if err != nil {
+				b.err = err
+				break
+			}
+			b.closers = append(b.closers, rc)
+			b.r = rc
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.r.Read(p)
+}
+
+func (b *body) Close() error {
+	for _, c := range slices.Backward(b.closers) {
+		_ = c.Close()
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package httpcdecompress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return b
+}
+
+// encodedServer returns a server which responds with the provided body and
+// Content-Encoding, recording the Accept-Encoding header of the last request.
+func encodedServer(t *testing.T, encoding string, body []byte, accept *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*accept = r.Header.Get("Accept-Encoding")
+		if encoding != "" {
+			w.Header().Set("Content-Encoding", encoding)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, client *http.Client, method, url string, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), method, url, http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp, b
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	want := fixture(t, "fixture.txt")
+
+	tests := []struct {
+		name     string
+		encoding string
+		fixture  string
+	}{
+		{name: "brotli", encoding: "br", fixture: "fixture.txt.br"},
+		{name: "zstd", encoding: "zstd", fixture: "fixture.txt.zst"},
+		{name: "gzip", encoding: "gzip", fixture: "fixture.txt.gz"},
+		{name: "gzip-case-insensitive", encoding: "GZIP", fixture: "fixture.txt.gz"},
+		{name: "deflate", encoding: "deflate", fixture: "fixture.txt.zz"},
+		{name: "multiple", encoding: "gzip, zstd", fixture: "fixture.txt.gz.zst"},
+		{name: "identity", encoding: "", fixture: "fixture.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var accept string
+			srv := encodedServer(t, tt.encoding, fixture(t, tt.fixture), &accept)
+			resp, got := get(t, NewClient(nil), http.MethodGet, srv.URL, nil)
+
+			if accept != "br, zstd, gzip, deflate" {
+				t.Errorf("Accept-Encoding = %q, want %q", accept, "br, zstd, gzip, deflate")
+			}
+			if string(got) != string(want) {
+				t.Errorf("body = %q, want %q", got, want)
+			}
+			if tt.encoding == "" {
+				return
+			}
+			if v := resp.Header.Get("Content-Encoding"); v != "" {
+				t.Errorf("Content-Encoding = %q, want it removed", v)
+			}
+			if v := resp.Header.Get("Content-Length"); v != "" {
+				t.Errorf("Content-Length = %q, want it removed", v)
+			}
+			if resp.ContentLength != -1 || !resp.Uncompressed {
+				t.Errorf("ContentLength = %d, Uncompressed = %v, want -1, true", resp.ContentLength, resp.Uncompressed)
+			}
+		})
+	}
+}
+
+func TestTransport_customEncodings(t *testing.T) {
+	t.Parallel()
+
+	// Stand-in for a third-party decoder.
+	custom := Encoding{
+		Name: "x-custom",
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	}
+
+	var accept string
+	srv := encodedServer(t, "x-custom", fixture(t, "fixture.txt.gz"), &accept)
+	_, got := get(t, NewClient(&Config{Encodings: []Encoding{custom, Gzip}}), http.MethodGet, srv.URL, nil)
+
+	if accept != "x-custom, gzip" {
+		t.Errorf("Accept-Encoding = %q, want %q", accept, "x-custom, gzip")
+	}
+	if want := fixture(t, "fixture.txt"); string(got) != string(want) {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestTransport_unsupportedEncoding(t *testing.T) {
+	t.Parallel()
+
+	raw := fixture(t, "fixture.txt.gz")
+
+	var accept string
+	srv := encodedServer(t, "compress", raw, &accept)
+	resp, got := get(t, NewClient(nil), http.MethodGet, srv.URL, nil)
+
+	if resp.Header.Get("Content-Encoding") != "compress" {
+		t.Errorf("Content-Encoding = %q, want it preserved", resp.Header.Get("Content-Encoding"))
+	}
+	if string(got) != string(raw) {
+		t.Error("expected body to be returned as-is")
+	}
+}
+
+func TestTransport_callerAcceptEncoding(t *testing.T) {
+	t.Parallel()
+
+	raw := fixture(t, "fixture.txt.gz")
+
+	var accept string
+	srv := encodedServer(t, "gzip", raw, &accept)
+	resp, got := get(t, NewClient(nil), http.MethodGet, srv.URL, http.Header{"Accept-Encoding": {"gzip"}})
+
+	if accept != "gzip" {
+		t.Errorf("Accept-Encoding = %q, want %q", accept, "gzip")
+	}
+	if resp.Header.Get("Content-Encoding") != "gzip" || string(got) != string(raw) {
+		t.Error("expected response to be passed through without decompression")
+	}
+}
+
+func TestTransport_head(t *testing.T) {
+	t.Parallel()
+
+	var accept string
+	srv := encodedServer(t, "gzip", fixture(t, "fixture.txt.gz"), &accept)
+	_, got := get(t, NewClient(nil), http.MethodHead, srv.URL, nil)
+	if len(got) != 0 {
+		t.Errorf("body = %q, want empty", got)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	var nilConfig *Config
+	if err := nilConfig.Validate(); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if err := (&Config{Encodings: []Encoding{{Name: "br"}}}).Validate(); err == nil {
+		t.Error("expected error for encoding without reader")
+	}
+	if err := (&Config{Encodings: []Encoding{{Name: "a, b", NewReader: Gzip.NewReader}}}).Validate(); err == nil {
+		t.Error("expected error for invalid encoding name")
+	}
+}
@@ -8,8 +8,12 @@
 package httpclog
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"math/rand/v2"
+	"mime"
 	"net/http"
 	"net/http/httputil"
 	"os"
@@ -65,8 +69,88 @@ type Config struct {
 
 	// TraceResponseFunc is a function that determines whether to trace the response.
 	TraceResponseFunc func(resp *http.Response) bool
+
+	// TraceSampleRate is the fraction (0-1) of requests which should be traced,
+	// when tracing would otherwise be enabled (see [Config.Trace],
+	// [Config.TraceRequest], [Config.TraceResponse], etc). The sampling decision
+	// is made once per request, so a sampled request has both its request and
+	// response traced. Requests which aren't sampled are still logged as usual,
+	// just without the trace attributes. Defaults to 0, which (like 1) traces all
+	// requests.
+	TraceSampleRate float64
+
+	// PrettyJSONBodies will re-indent traced request/response bodies when the
+	// Content-Type is JSON (e.g. "application/json", or "application/*+json"),
+	// making them easier to read in logs. Bodies which aren't JSON, or fail to
+	// parse as JSON, are logged as-is.
+	PrettyJSONBodies bool
+
+	// SemanticConventions will use the OpenTelemetry HTTP semantic convention
+	// attribute keys (e.g. "http.method", "http.url", "http.status_code") instead
+	// of the default keys (e.g. "method", "url", "status"), so log pipelines which
+	// expect those keys work without a transform. Attributes without an HTTP
+	// semantic convention equivalent (e.g. "duration" and "error") are unchanged.
+	SemanticConventions bool
+
+	// SingleLine will log a single record per request, after the response was
+	// received (or the request failed), rather than a separate record for the
+	// request and the response. The record contains both the request and response
+	// attributes (and if tracing, both dumps), which halves the log volume, and
+	// keeps the request and response together in high-concurrency logs. As the
+	// request and response attributes share the same record, the header and
+	// content length keys are prefixed with "request-" and "response-" (unless
+	// [Config.SemanticConventions] is enabled, where the keys are already
+	// distinct).
+	SingleLine bool
+}
+
+// attrKeys are the attribute keys used when logging requests and responses.
+type attrKeys struct {
+	method                string
+	url                   string
+	userAgent             string
+	requestContentLength  string
+	requestHeaders        string
+	status                string
+	responseContentLength string
+	responseHeaders       string
 }
 
+var (
+	defaultAttrKeys = attrKeys{
+		method:                "method",
+		url:                   "url",
+		userAgent:             "user-agent",
+		requestContentLength:  "content-length",
+		requestHeaders:        "headers",
+		status:                "status",
+		responseContentLength: "content-length",
+		responseHeaders:       "headers",
+	}
+
+	singleLineAttrKeys = attrKeys{
+		method:                "method",
+		url:                   "url",
+		userAgent:             "user-agent",
+		requestContentLength:  "request-content-length",
+		requestHeaders:        "request-headers",
+		status:                "status",
+		responseContentLength: "response-content-length",
+		responseHeaders:       "response-headers",
+	}
+
+	semanticAttrKeys = attrKeys{
+		method:                "http.method",
+		url:                   "http.url",
+		userAgent:             "http.user_agent",
+		requestContentLength:  "http.request_content_length",
+		requestHeaders:        "http.request.header",
+		status:                "http.status_code",
+		responseContentLength: "http.response_content_length",
+		responseHeaders:       "http.response.header",
+	}
+)
+
 // Validate validates the logger configuration. Use this to validate the configuration,
 // before passing it to [NewTransport] or [NewClient], as they will panic
 // if the configuration is invalid.
@@ -95,6 +179,10 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.TraceSampleRate < 0 || c.TraceSampleRate > 1 {
+		return errors.New("trace sample rate must be between 0 and 1")
+	}
+
 	if len(c.Headers) == 1 && c.Headers[0] == "*" {
 		c.Headers = nil
 	} else if len(c.Headers) == 0 {
@@ -161,6 +249,28 @@ func NewClient(config *Config) *http.Client {
 	}
 }
 
+// keys returns the attribute keys to use, based on [Config.SemanticConventions]
+// and [Config.SingleLine].
+func (rt *transport) keys() *attrKeys {
+	switch {
+	case rt.config.SemanticConventions:
+		return &semanticAttrKeys
+	case rt.config.SingleLine:
+		return &singleLineAttrKeys
+	default:
+		return &defaultAttrKeys
+	}
+}
+
+// sampled reports whether the current request should be traced, based on
+// [Config.TraceSampleRate].
+func (rt *transport) sampled() bool {
+	if rt.config.TraceSampleRate <= 0 || rt.config.TraceSampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < rt.config.TraceSampleRate //nolint:gosec
+}
+
 func (rt *transport) shouldTraceRequest(req *http.Request) bool {
 	if rt.config.Trace || rt.config.TraceRequest {
 		return true
@@ -209,28 +319,34 @@ func getCallerPC(skip int) uintptr {
 }
 
 func (rt *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.config.SingleLine {
+		return rt.roundTripSingleLine(req)
+	}
+
 	ctx := req.Context()
 	handler := rt.config.Logger.Handler()
 
 	var r slog.Record
 
 	pc := getCallerPC(6)
+	sampled := rt.sampled()
+	keys := rt.keys()
 
 	if handler.Enabled(ctx, *rt.config.Level) {
 		r = slog.NewRecord(time.Now(), *rt.config.Level, "http request", pc)
 
 		r.AddAttrs(
-			slog.String("method", req.Method),
-			slog.String("url", req.URL.String()),
-			slog.String("user-agent", req.UserAgent()),
-			slog.Int64("content-length", req.ContentLength),
-			slog.GroupAttrs("headers", rt.headersAsAttrs(req.Header)...),
+			slog.String(keys.method, req.Method),
+			slog.String(keys.url, req.URL.String()),
+			slog.String(keys.userAgent, req.UserAgent()),
+			slog.Int64(keys.requestContentLength, req.ContentLength),
+			slog.GroupAttrs(keys.requestHeaders, rt.headersAsAttrs(req.Header)...),
 		)
 
-		if rt.shouldTraceRequest(req) {
+		if sampled && rt.shouldTraceRequest(req) {
 			b, err := httputil.DumpRequest(req, true)
 			if err == nil {
-				r.AddAttrs(slog.String("request", string(b)))
+				r.AddAttrs(slog.String("request", rt.formatDump(b, req.Header)))
 			}
 		}
 
@@ -245,16 +361,16 @@ func (rt *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		if handler.Enabled(ctx, slog.LevelError) {
 			r = slog.NewRecord(time.Now(), slog.LevelError, "http request failed", pc)
 			r.AddAttrs(
-				slog.String("url", req.URL.String()),
+				slog.String(keys.url, req.URL.String()),
 				slog.String("error", err.Error()),
 				slog.Duration("duration", duration),
 			)
 
-			if resp != nil && rt.shouldTraceResponse(resp) {
+			if sampled && resp != nil && rt.shouldTraceResponse(resp) {
 				var b []byte
 				b, err = httputil.DumpResponse(resp, true)
 				if err == nil {
-					r.AddAttrs(slog.String("response", string(b)))
+					r.AddAttrs(slog.String("response", rt.formatDump(b, resp.Header)))
 				}
 			}
 
@@ -266,18 +382,18 @@ func (rt *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if handler.Enabled(ctx, *rt.config.Level) {
 		r = slog.NewRecord(time.Now(), *rt.config.Level, "http response", pc)
 		r.AddAttrs(
-			slog.String("url", req.URL.String()),
-			slog.Int("status", resp.StatusCode),
+			slog.String(keys.url, req.URL.String()),
+			slog.Int(keys.status, resp.StatusCode),
 			slog.Duration("duration", duration),
-			slog.Int64("content-length", resp.ContentLength),
-			slog.GroupAttrs("headers", rt.headersAsAttrs(resp.Header)...),
+			slog.Int64(keys.responseContentLength, resp.ContentLength),
+			slog.GroupAttrs(keys.responseHeaders, rt.headersAsAttrs(resp.Header)...),
 		)
 
-		if rt.shouldTraceResponse(resp) {
+		if sampled && rt.shouldTraceResponse(resp) {
 			var b []byte
 			b, err = httputil.DumpResponse(resp, true)
 			if err == nil {
-				r.AddAttrs(slog.String("response", string(b)))
+				r.AddAttrs(slog.String("response", rt.formatDump(b, resp.Header)))
 			}
 		}
 
@@ -287,6 +403,125 @@ func (rt *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// roundTripSingleLine is the same as [transport.RoundTrip], however only a single
+// record is logged, once the response was received. See [Config.SingleLine].
+func (rt *transport) roundTripSingleLine(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	handler := rt.config.Logger.Handler()
+
+	pc := getCallerPC(7) // One more frame than RoundTrip.
+	sampled := rt.sampled()
+
+	enabled := handler.Enabled(ctx, *rt.config.Level)
+	errorEnabled := handler.Enabled(ctx, slog.LevelError)
+
+	// The request has to be dumped before it is sent, as the body is consumed.
+	var requestDump string
+	if (enabled || errorEnabled) && sampled && rt.shouldTraceRequest(req) {
+		b, err := httputil.DumpRequest(req, true)
+		if err == nil {
+			requestDump = rt.formatDump(b, req.Header)
+		}
+	}
+
+	started := time.Now()
+	resp, err := rt.config.BaseTransport.RoundTrip(req)
+	duration := time.Since(started)
+
+	if (err == nil && enabled) || (err != nil && errorEnabled) {
+		rt.logSingleLine(req, resp, err, duration, requestDump, sampled, pc)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// logSingleLine logs the combined record of the request and response (or error).
+// See [Config.SingleLine].
+func (rt *transport) logSingleLine(
+	req *http.Request,
+	resp *http.Response,
+	err error,
+	duration time.Duration,
+	requestDump string,
+	sampled bool,
+	pc uintptr,
+) {
+	keys := rt.keys()
+
+	level := *rt.config.Level
+	msg := "http request"
+	if err != nil {
+		level = slog.LevelError
+		msg = "http request failed"
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, pc)
+	r.AddAttrs(
+		slog.String(keys.method, req.Method),
+		slog.String(keys.url, req.URL.String()),
+		slog.String(keys.userAgent, req.UserAgent()),
+		slog.Int64(keys.requestContentLength, req.ContentLength),
+		slog.GroupAttrs(keys.requestHeaders, rt.headersAsAttrs(req.Header)...),
+	)
+
+	if err != nil {
+		r.AddAttrs(slog.String("error", err.Error()))
+	} else {
+		r.AddAttrs(
+			slog.Int(keys.status, resp.StatusCode),
+			slog.Int64(keys.responseContentLength, resp.ContentLength),
+			slog.GroupAttrs(keys.responseHeaders, rt.headersAsAttrs(resp.Header)...),
+		)
+	}
+	r.AddAttrs(slog.Duration("duration", duration))
+
+	if requestDump != "" {
+		r.AddAttrs(slog.String("request", requestDump))
+	}
+
+	if sampled && resp != nil && rt.shouldTraceResponse(resp) {
+		b, derr := httputil.DumpResponse(resp, true)
+		if derr == nil {
+			r.AddAttrs(slog.String("response", rt.formatDump(b, resp.Header)))
+		}
+	}
+
+	_ = rt.config.Logger.Handler().Handle(req.Context(), r)
+}
+
+// formatDump formats a request/response dump for logging, re-indenting the body
+// if [Config.PrettyJSONBodies] is enabled and the body is JSON.
+func (rt *transport) formatDump(dump []byte, headers http.Header) string {
+	if !rt.config.PrettyJSONBodies || !isJSONContentType(headers.Get("Content-Type")) {
+		return string(dump)
+	}
+
+	head, body, ok := bytes.Cut(dump, []byte("\r\n\r\n"))
+	if !ok || len(bytes.TrimSpace(body)) == 0 {
+		return string(dump)
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(body), "", "  "); err != nil {
+		return string(dump)
+	}
+
+	return string(head) + "\r\n\r\n" + buf.String()
+}
+
+// isJSONContentType reports whether the provided Content-Type header value is
+// JSON.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 func (rt *transport) headersAsAttrs(headers http.Header) []slog.Attr {
 	attrs := make([]slog.Attr, 0, len(headers))
 	for k, v := range headers {
@@ -7,6 +7,7 @@ package httpclog
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
@@ -170,6 +171,175 @@ func TestRoundTrip_LogsError(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_SemanticConventions(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{false, true} {
+		logger, buf := newTestLogger(t)
+
+		tr := NewTransport(&Config{
+			Logger:              logger,
+			SemanticConventions: enabled,
+			BaseTransport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode:    http.StatusCreated,
+					Header:        http.Header{"Content-Type": {"text/plain"}},
+					Body:          http.NoBody,
+					ContentLength: 0,
+					Request:       req,
+				}, nil
+			}),
+		})
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://example.com/", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("User-Agent", "httpclog-test/1")
+
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		semantic := []string{
+			`"http.method":"POST"`,
+			`"http.url":"https://example.com/"`,
+			`"http.user_agent":"httpclog-test/1"`,
+			`"http.request_content_length":0`,
+			`"http.status_code":201`,
+			`"http.response_content_length":0`,
+			`"http.response.header":{"Content-Type":"text/plain"}`,
+		}
+		legacy := []string{
+			`"method":"POST"`,
+			`"url":"https://example.com/"`,
+			`"user-agent":"httpclog-test/1"`,
+			`"status":201`,
+			`"headers":{"Content-Type":"text/plain"}`,
+		}
+
+		want, notWant := legacy, semantic
+		if enabled {
+			want, notWant = semantic, legacy
+		}
+
+		out := buf.String()
+		for _, s := range want {
+			if !strings.Contains(out, s) {
+				t.Errorf("semantic=%v: log should contain %s; got %q", enabled, s, out)
+			}
+		}
+		for _, s := range notWant {
+			if strings.Contains(out, s) {
+				t.Errorf("semantic=%v: log should not contain %s; got %q", enabled, s, out)
+			}
+		}
+	}
+}
+
+func TestRoundTrip_SingleLine(t *testing.T) {
+	t.Parallel()
+	logger, buf := newTestLogger(t)
+
+	tr := NewTransport(&Config{
+		Logger:     logger,
+		SingleLine: true,
+		Trace:      true,
+		BaseTransport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"text/plain"}},
+				Body:          io.NopCloser(strings.NewReader("pong")),
+				ContentLength: 4,
+				Request:       req,
+			}, nil
+		}),
+	})
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://example.com/", strings.NewReader("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("response body = %q, want %q", body, "pong")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a single log line, got %d: %q", len(lines), buf.String())
+	}
+
+	for _, s := range []string{
+		`"msg":"http request"`,
+		`"method":"POST"`,
+		`"url":"https://example.com/"`,
+		`"status":200`,
+		`"duration":`,
+		`"request-content-length":4`,
+		`"request-headers":{"Content-Type":"text/plain"}`,
+		`"response-content-length":4`,
+		`"response-headers":{"Content-Type":"text/plain"}`,
+		`"request":"POST / HTTP/1.1`,
+		`"response":"HTTP/1.1 200 OK`,
+		`ping`,
+		`pong`,
+	} {
+		if !strings.Contains(lines[0], s) {
+			t.Errorf("log should contain %s; got %q", s, lines[0])
+		}
+	}
+}
+
+func TestRoundTrip_SingleLineError(t *testing.T) {
+	t.Parallel()
+	logger, buf := newTestLogger(t)
+
+	tr := NewTransport(&Config{
+		Logger:     logger,
+		SingleLine: true,
+		BaseTransport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("roundtrip failed")
+		}),
+	})
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://example.invalid/", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = tr.RoundTrip(req); err == nil {
+		t.Fatal("expected error from base transport")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a single log line, got %d: %q", len(lines), buf.String())
+	}
+
+	for _, s := range []string{
+		`"level":"ERROR"`,
+		`"msg":"http request failed"`,
+		`"method":"GET"`,
+		`"error":"roundtrip failed"`,
+	} {
+		if !strings.Contains(lines[0], s) {
+			t.Errorf("log should contain %s; got %q", s, lines[0])
+		}
+	}
+	if strings.Contains(lines[0], `"status"`) {
+		t.Errorf("log should not contain a status; got %q", lines[0])
+	}
+}
+
 type roundTripFunc func(*http.Request) (*http.Response, error)
 
 func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -240,6 +410,158 @@ func TestRoundTrip_TraceResponseIncludesDump(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_PrettyJSONBodies(t *testing.T) {
+	t.Parallel()
+	logger, buf := newTestLogger(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"ok":true,"items":[1,2]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := NewTransport(&Config{
+		Logger:           logger,
+		BaseTransport:    http.DefaultTransport,
+		Trace:            true,
+		PrettyJSONBodies: true,
+	})
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader(`{"name":"foo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var request, response string
+	for line := range strings.Lines(buf.String()) {
+		var entry struct {
+			Request  string `json:"request"`
+			Response string `json:"response"`
+		}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		request += entry.Request
+		response += entry.Response
+	}
+
+	if !strings.HasSuffix(request, "\r\n\r\n{\n  \"name\": \"foo\"\n}") {
+		t.Errorf("request body should be indented; got %q", request)
+	}
+	if !strings.HasSuffix(response, "{\n  \"ok\": true,\n  \"items\": [\n    1,\n    2\n  ]\n}") {
+		t.Errorf("response body should be indented; got %q", response)
+	}
+}
+
+func TestConfigValidate_TraceSampleRate(t *testing.T) {
+	t.Parallel()
+	for _, rate := range []float64{-0.1, 1.1} {
+		c := &Config{TraceSampleRate: rate}
+		if err := c.Validate(); err == nil {
+			t.Errorf("rate %v: expected error", rate)
+		}
+	}
+}
+
+func TestRoundTrip_TraceSampleRate(t *testing.T) {
+	t.Parallel()
+	logger, buf := newTestLogger(t)
+
+	var calls int
+	tr := NewTransport(&Config{
+		Logger: logger,
+		BaseTransport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}),
+		Trace:           true,
+		TraceSampleRate: 0.5,
+	})
+
+	const total = 200
+	for range total {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://example.invalid/", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if calls != total {
+		t.Fatalf("base transport calls = %d, want %d", calls, total)
+	}
+
+	out := buf.String()
+	if n := strings.Count(out, `"msg":"http request"`); n != total {
+		t.Errorf("request log lines = %d, want %d", n, total)
+	}
+
+	requests := strings.Count(out, `"request":"GET `)
+	responses := strings.Count(out, `"response":"HTTP/`)
+	if requests != responses {
+		t.Errorf("traced requests = %d, traced responses = %d, want equal", requests, responses)
+	}
+	if requests == 0 || requests == total {
+		t.Errorf("traced requests = %d, want a sample of %d", requests, total)
+	}
+}
+
+func TestFormatDump(t *testing.T) {
+	t.Parallel()
+
+	rt := &transport{config: &Config{PrettyJSONBodies: true}}
+	tests := []struct {
+		name        string
+		contentType string
+		dump        string
+		want        string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			dump:        "POST / HTTP/1.1\r\n\r\n{\"a\":1}",
+			want:        "POST / HTTP/1.1\r\n\r\n{\n  \"a\": 1\n}",
+		},
+		{
+			name:        "invalid-json",
+			contentType: "application/json",
+			dump:        "POST / HTTP/1.1\r\n\r\n{\"a\":",
+			want:        "POST / HTTP/1.1\r\n\r\n{\"a\":",
+		},
+		{
+			name:        "not-json",
+			contentType: "text/plain",
+			dump:        "POST / HTTP/1.1\r\n\r\n{\"a\":1}",
+			want:        "POST / HTTP/1.1\r\n\r\n{\"a\":1}",
+		},
+		{
+			name:        "no-body",
+			contentType: "application/json",
+			dump:        "GET / HTTP/1.1\r\n\r\n",
+			want:        "GET / HTTP/1.1\r\n\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			headers := http.Header{"Content-Type": []string{tt.contentType}}
+			if got := rt.formatDump([]byte(tt.dump), headers); got != tt.want {
+				t.Errorf("formatDump() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRoundTrip_HeaderFilter(t *testing.T) {
 	t.Parallel()
 	logger, buf := newTestLogger(t)
@@ -10,12 +10,20 @@ package httpcretry
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"math"
+	"math/rand/v2"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
+
+	"golang.org/x/net/http2"
 )
 
 // BackoffFunc is a function that calculates the backoff duration based on the attempt
@@ -26,6 +34,32 @@ type BackoffFunc func(config *Config, attempt int, resp *http.Response) time.Dur
 // response and error.
 type PolicyFunc func(ctx context.Context, resp *http.Response, err error) bool
 
+// ResponsePolicyFunc is a function that determines whether to retry based on the
+// response, after [PolicyFunc] has decided not to retry a response (i.e. it can
+// be used to retry on application-level errors, like an HTTP 200 with an error
+// payload). The response body can be read, and will be restored for the caller
+// (see [Config.MaxResponsePolicyBodySize]). If an error is returned, the request
+// is not retried, and the error is returned to the caller.
+type ResponsePolicyFunc func(resp *http.Response) (retry bool, err error)
+
+// SleepFunc waits for the provided duration, returning early with an error if the
+// context is canceled. See [DefaultSleep].
+type SleepFunc func(ctx context.Context, d time.Duration) error
+
+// DefaultSleep is the default [SleepFunc], which waits using a real timer, and
+// returns ctx.Err() if the context is canceled before d elapses.
+func DefaultSleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // CallbackFunc is a function that is called right before a retry is attempted. The
 // request and response SHOULD NOT BE MODIFIED. This is useful for logging or other
 // side effects.
@@ -62,7 +96,9 @@ func LoggerCallback(logger *slog.Logger, level slog.Level) CallbackFunc {
 // DefaultPolicy is the default retry policy. It retries on network errors, 5xx status
 // codes, and 429 Too Many Requests. It does not retry on [context.Canceled] or
 // [context.DeadlineExceeded], as this is often intentional cancellation from the
-// parent caller.
+// parent caller. Network errors include connection resets and HTTP/2 GOAWAYs (see
+// [IsConnectionReset]), which [Config.ImmediateRetryOnReset] can retry without
+// backoff.
 func DefaultPolicy(ctx context.Context, resp *http.Response, err error) bool {
 	// Don't retry on [context.Canceled] or [context.DeadlineExceeded].
 	if ctx.Err() != nil {
@@ -80,21 +116,86 @@ func DefaultPolicy(ctx context.Context, resp *http.Response, err error) bool {
 	return false
 }
 
+// StatusCodePolicy returns a retry policy which retries on network errors, and the
+// provided status codes only. Like [DefaultPolicy], it does not retry on
+// [context.Canceled] or [context.DeadlineExceeded]. See also
+// [Config.RetryStatusCodes].
+func StatusCodePolicy(codes ...int) PolicyFunc {
+	codes = slices.Clone(codes)
+	return func(ctx context.Context, resp *http.Response, err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if err != nil {
+			return true
+		}
+
+		return slices.Contains(codes, resp.StatusCode)
+	}
+}
+
+// IsConnectionReset returns true if err is a connection reset by the peer
+// (ECONNRESET, typically wrapped in a [net.OpError]), or a graceful HTTP/2 GOAWAY
+// (with the NO_ERROR code) sent by the server. These happen when the server (or a
+// proxy/load balancer in front of it) drops the connection, e.g. during a
+// graceful shutdown or deploy, and unlike timeouts, are safe to retry immediately
+// for idempotent requests. GOAWAYs with any other code indicate an error, and
+// aren't treated as a reset. See [Config.ImmediateRetryOnReset].
+func IsConnectionReset(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var goAway http2.GoAwayError
+	if errors.As(err, &goAway) {
+		return goAway.ErrCode == http2.ErrCodeNo
+	}
+
+	// The HTTP/2 GOAWAY errors of the http2 transport bundled with net/http are
+	// unexported, so the only way to detect them is by their message. Messages
+	// without an error code are from graceful shutdowns.
+	msg := err.Error()
+	if !strings.Contains(msg, "GOAWAY") {
+		return false
+	}
+	_, code, ok := strings.Cut(msg, "ErrCode=")
+	return !ok || strings.HasPrefix(code, http2.ErrCodeNo.String())
+}
+
+// isIdempotent returns true if the request can safely be sent more than once,
+// i.e. it uses an idempotent method (see RFC 9110, section 9.2.2), or has an
+// idempotency key (like [net/http.Transport] checks).
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	_, ok := req.Header["Idempotency-Key"]
+	if !ok {
+		_, ok = req.Header["X-Idempotency-Key"]
+	}
+	return ok
+}
+
 // DefaultBackoff is the default backoff function. It uses exponential backoff with a
 // minimum and maximum duration. It also attempts to parse the [Retry-After] header from
 // the response and uses that as the backoff duration if it is present and valid. If
-// the [Retry-After] header is not present or invalid, it falls back to the exponential
-// backoff calculation.
+// the [Retry-After] header is not present or invalid, the RateLimit-Reset (IETF
+// [RateLimit header fields]) and X-RateLimit-Reset (e.g. GitHub) headers are used,
+// unless [Config.DisableRateLimitHeaders] is set. If none of these are present or
+// valid, it falls back to the exponential backoff calculation.
 //
 // [Retry-After]: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
+// [RateLimit header fields]: https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/
 func DefaultBackoff(config *Config, attempt int, resp *http.Response) time.Duration {
-	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
-		if retryAfter, ok := parseRetryAfterHeader(resp.Header["Retry-After"]); ok {
-			if retryAfter > config.MaxRateLimitDuration {
-				retryAfter = config.MaxRateLimitDuration
-			}
-			return retryAfter
-		}
+	if retryAfter, ok := rateLimitBackoff(config, resp); ok {
+		return retryAfter
 	}
 
 	mult := math.Pow(2, float64(attempt)) * float64(config.MinBackoff)
@@ -106,22 +207,139 @@ func DefaultBackoff(config *Config, attempt int, resp *http.Response) time.Durat
 	return sleep
 }
 
-func parseRetryAfterHeader(headers []string) (time.Duration, bool) {
-	if len(headers) == 0 {
+// DecorrelatedJitterBackoff returns a new stateful [BackoffFunc] which uses
+// "decorrelated jitter" (as described by AWS), where each backoff is a random
+// duration between [Config.MinBackoff] and 3x the previous backoff, capped at
+// [Config.MaxBackoff]:
+//
+//	sleep = min(MaxBackoff, random(MinBackoff, previous*3))
+//
+// Plain exponential backoff retries clients which failed at the same time at the
+// same times as well, so they keep contending with each other (e.g. after an
+// outage, or a burst of requests). Randomizing each backoff based on the
+// previous one spreads retries out, while still growing the backoff roughly
+// exponentially, which results in less contention, and fewer retries overall.
+//
+// Like [DefaultBackoff], the Retry-After and rate limit headers take precedence
+// when present. As the returned function tracks the previous backoff, it must
+// only be used for the retry loop of a single request, which is why it should
+// be set via [Config.NewBackoff] (rather than [Config.Backoff]), e.g:
+//
+//	httpcretry.NewClient(&httpcretry.Config{
+//		NewBackoff: httpcretry.DecorrelatedJitterBackoff,
+//	})
+func DecorrelatedJitterBackoff() BackoffFunc {
+	var previous time.Duration
+
+	return func(config *Config, _ int, resp *http.Response) time.Duration {
+		if retryAfter, ok := rateLimitBackoff(config, resp); ok {
+			return retryAfter
+		}
+
+		upper := max(previous, config.MinBackoff) * 3
+		if upper < config.MinBackoff { // Overflow.
+			upper = math.MaxInt64
+		}
+
+		sleep := config.MinBackoff
+		if upper > config.MinBackoff {
+			sleep += rand.N(upper - config.MinBackoff) //nolint:gosec
+		}
+
+		previous = min(sleep, config.MaxBackoff)
+		return previous
+	}
+}
+
+// rateLimitBackoff returns the backoff requested by the server, through the
+// Retry-After header, or the rate limit headers (unless disabled), capped at
+// [Config.MaxRateLimitDuration]. Only used for 429 and 503 responses.
+func rateLimitBackoff(config *Config, resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
 		return 0, false
 	}
 
-	retryAfter := headers[0]
+	retryAfter, ok := parseRetryAfterHeader(resp.Header["Retry-After"])
+	if !ok && !config.DisableRateLimitHeaders {
+		retryAfter, ok = parseRateLimitHeaders(resp.Header)
+	}
+	if !ok {
+		return 0, false
+	}
+	return min(retryAfter, config.MaxRateLimitDuration), true
+}
+
+// minEpochReset is the smallest rate limit reset value which is treated as a Unix
+// timestamp, rather than delta-seconds (roughly one year, in seconds).
+const minEpochReset = 365 * 24 * 60 * 60
+
+// parseRateLimitHeaders parses the RateLimit-Reset and X-RateLimit-Reset headers,
+// which can either be delta-seconds, or a Unix timestamp (in seconds). If the
+// accompanying remaining header reports requests are still available, the reset
+// is ignored.
+func parseRateLimitHeaders(header http.Header) (time.Duration, bool) {
+	for _, prefix := range []string{"Ratelimit-", "X-Ratelimit-"} {
+		reset := header.Get(prefix + "Reset")
+		if reset == "" {
+			continue
+		}
+
+		if remaining, err := strconv.Atoi(header.Get(prefix + "Remaining")); err == nil && remaining > 0 {
+			continue
+		}
+
+		value, err := strconv.ParseInt(reset, 10, 64)
+		if err != nil || value < 0 {
+			continue
+		}
+
+		if value < minEpochReset {
+			return time.Duration(value) * time.Second, true
+		}
+
+		until := time.Until(time.Unix(value, 0))
+		if until < 0 {
+			continue
+		}
+		return until, true
+	}
+	return 0, false
+}
+
+// parseRetryAfterHeader parses the Retry-After header values, returning the
+// first valid value. Servers sometimes send multiple values (either as multiple
+// headers, or comma-separated), so each is tried in order. Values can either be
+// (possibly fractional) delta-seconds, or an RFC1123 date. Negative values and
+// dates in the past are ignored.
+func parseRetryAfterHeader(headers []string) (time.Duration, bool) {
+	for _, header := range headers {
+		// RFC1123 dates contain a comma, so try the full value first.
+		if sleep, ok := parseRetryAfter(header); ok {
+			return sleep, true
+		}
+
+		for value := range strings.SplitSeq(header, ",") {
+			if sleep, ok := parseRetryAfter(value); ok {
+				return sleep, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a single Retry-After value.
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	retryAfter = strings.TrimSpace(retryAfter)
 	if retryAfter == "" {
 		return 0, false
 	}
 
-	// "Retry-After: <int>"
-	if sleep, err := strconv.Atoi(retryAfter); err == nil {
-		if sleep < 0 {
+	// "Retry-After: <seconds>", allowing fractional seconds.
+	if sleep, err := strconv.ParseFloat(retryAfter, 64); err == nil {
+		if sleep < 0 || math.IsNaN(sleep) || sleep*float64(time.Second) >= math.MaxInt64 {
 			return 0, false
 		}
-		return time.Duration(sleep) * time.Second, true
+		return time.Duration(sleep * float64(time.Second)), true
 	}
 
 	// "Retry-After: <rfc1123-date>"
@@ -129,10 +347,11 @@ func parseRetryAfterHeader(headers []string) (time.Duration, bool) {
 	if err != nil {
 		return 0, false
 	}
-	if until := time.Until(retryTime); until < 0 {
+	until := time.Until(retryTime)
+	if until < 0 {
 		return 0, false
 	}
-	return time.Until(retryTime), true
+	return until, true
 }
 
 // Config is the configuration for the retryable transport.
@@ -145,12 +364,26 @@ type Config struct {
 	// MaxRetries is the maximum number of retries to perform. Defaults to 4.
 	MaxRetries int
 
+	// MaxElapsed is the maximum total duration of a request, across all attempts
+	// and backoffs, measured from the start of the first attempt. If the next
+	// backoff would exceed it, no further attempts are made (even if MaxRetries
+	// hasn't been reached), and the last response/error is returned, rather than
+	// blocking for long periods of time (e.g. due to large Retry-After values).
+	// Defaults to 0, which doesn't limit the total duration.
+	MaxElapsed time.Duration
+
 	// MaxRateLimitDuration is the maximum duration to wait when the server returns
 	// 429 Too Many Requests. This can sometimes be a very long time, so depending on
 	// your usecase/application, you may not want to wait that long. Defaults to
 	// [RetryConfig.MaxBackoff].
 	MaxRateLimitDuration time.Duration
 
+	// DisableRateLimitHeaders disables using the RateLimit-Reset and
+	// X-RateLimit-Reset headers to calculate the backoff when the Retry-After header
+	// is not present (see [DefaultBackoff]). Useful for servers which send
+	// misconfigured rate limit headers.
+	DisableRateLimitHeaders bool
+
 	// MinBackoff is the minimum backoff duration. Defaults to 1 second.
 	MinBackoff time.Duration
 
@@ -162,6 +395,12 @@ type Config struct {
 	// backoff with the provided minimum and maximum duration.
 	Backoff BackoffFunc
 
+	// NewBackoff is an optional function which returns a new [BackoffFunc] for each
+	// request, which is only used for the retries of that request. This allows for
+	// stateful backoff functions (e.g. which track the previous backoff), like
+	// [DecorrelatedJitterBackoff]. Takes precedence over Backoff when set.
+	NewBackoff func() BackoffFunc
+
 	// DefaultPolicy is a function that determines whether to retry based on the context,
 	// response and error. Defaults to [DefaultPolicy], which retries on network errors,
 	// 5xx status codes, and 429 Too Many Requests. [DefaultPolicy] does not retry on
@@ -169,10 +408,47 @@ type Config struct {
 	// cancellation from the parent caller).
 	DefaultPolicy PolicyFunc
 
+	// RetryStatusCodes is an optional allowlist of status codes to retry on (e.g.
+	// 409 Conflict, or 425 Too Early), replacing the status code handling of
+	// [DefaultPolicy] (network errors are still retried). This is a shorthand for
+	// setting DefaultPolicy to [StatusCodePolicy], and is ignored if DefaultPolicy
+	// is set.
+	RetryStatusCodes []int
+
+	// ResponsePolicy is an optional function that determines whether to retry based
+	// on the response (including the body), when DefaultPolicy does not retry the
+	// response. See [ResponsePolicyFunc].
+	//
+	// Note that when set, up to MaxResponsePolicyBodySize bytes of every response
+	// body are buffered in memory before the response is returned to the caller.
+	ResponsePolicy ResponsePolicyFunc
+
+	// MaxResponsePolicyBodySize is the maximum number of bytes of the response body
+	// that is buffered and made available to ResponsePolicy. The rest of the body
+	// is still returned to the caller, but isn't visible to ResponsePolicy.
+	// Defaults to 64KiB.
+	MaxResponsePolicyBodySize int64
+
 	// RetryCallback is a function that is called right before a retry is attempted. The
 	// request and response SHOULD NOT BE MODIFIED. This is useful for logging or other
 	// side effects.
 	RetryCallback CallbackFunc
+
+	// ImmediateRetryOnReset retries idempotent requests (GET, HEAD, OPTIONS, TRACE,
+	// PUT, DELETE, or requests with an Idempotency-Key header) without any backoff,
+	// when the connection was reset or the server sent an HTTP/2 GOAWAY (see
+	// [IsConnectionReset]), as these are likely to succeed on a new connection.
+	// Only the first of consecutive resets is retried immediately, further resets
+	// back off as usual, so a server which keeps resetting connections isn't
+	// flooded with requests. Timeouts and other errors always back off.
+	ImmediateRetryOnReset bool
+
+	// Sleep is used to wait for the backoff duration between attempts. Defaults to
+	// [DefaultSleep]. Mostly useful for tests, to avoid waiting in real time (e.g.
+	// a no-op, or a function which records the requested durations). If Sleep
+	// returns an error (e.g. the request context was canceled), no further
+	// attempts are made, and the error is returned.
+	Sleep SleepFunc
 }
 
 func (c *Config) Validate() error {
@@ -202,12 +478,91 @@ func (c *Config) Validate() error {
 		c.Backoff = DefaultBackoff
 	}
 	if c.DefaultPolicy == nil {
-		c.DefaultPolicy = DefaultPolicy
+		if len(c.RetryStatusCodes) > 0 {
+			c.DefaultPolicy = StatusCodePolicy(c.RetryStatusCodes...)
+		} else {
+			c.DefaultPolicy = DefaultPolicy
+		}
+	}
+	if c.MaxResponsePolicyBodySize <= 0 {
+		c.MaxResponsePolicyBodySize = 64 * 1024
+	}
+	if c.Sleep == nil {
+		c.Sleep = DefaultSleep
 	}
 
 	return nil
 }
 
+// AttemptResult is the outcome of a single attempt of a request.
+type AttemptResult struct {
+	// Attempt is the attempt number, starting at 0 for the initial request.
+	Attempt int
+
+	// StatusCode is the status code of the response, or 0 if no response was
+	// received.
+	StatusCode int
+
+	// Err is the error returned by the attempt (either by the base transport, or by
+	// [Config.ResponsePolicy]), if any.
+	Err error
+}
+
+// RetryError is returned by the transport when a request fails with an error
+// after being retried at least once. It contains the outcome of every attempt,
+// so callers can tell intermittent failures (e.g. a 503 followed by a network
+// error) apart from persistent ones. Use [errors.As] to access it.
+//
+// [errors.Is] and [errors.As] match against the errors of all attempts, which
+// includes the error of the last attempt.
+type RetryError struct {
+	attempts []AttemptResult
+}
+
+// Attempts returns the outcome of every attempt, in order.
+func (e *RetryError) Attempts() []AttemptResult {
+	return slices.Clone(e.attempts)
+}
+
+// Last returns the error of the last attempt.
+func (e *RetryError) Last() error {
+	return e.attempts[len(e.attempts)-1].Err
+}
+
+// Error implements [error].
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", len(e.attempts), e.Last())
+}
+
+// Unwrap returns the errors of all attempts which failed with an error.
+func (e *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.attempts))
+	for _, attempt := range e.attempts {
+		if attempt.Err != nil {
+			errs = append(errs, attempt.Err)
+		}
+	}
+	return errs
+}
+
+// newAttemptResult returns the outcome of an attempt.
+func newAttemptResult(attempt int, resp *http.Response, err error) AttemptResult {
+	result := AttemptResult{Attempt: attempt, Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	return result
+}
+
+// wrapAttempts wraps the error of the last attempt in a [RetryError], if the
+// request was retried.
+func wrapAttempts(attempts []AttemptResult, err error) error {
+	if err == nil || len(attempts) < 2 {
+		return err
+	}
+	return &RetryError{attempts: attempts}
+}
+
 // NewTransport creates a new [net/http.RoundTripper] that retries requests based on
 // the provided config.
 func NewTransport(config *Config) http.RoundTripper {
@@ -238,11 +593,47 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 
 	// Send the request.
+	start := time.Now()
 	resp, err := t.config.BaseTransport.RoundTrip(req)
 	retries := 0
 
-	for t.config.DefaultPolicy(req.Context(), resp, err) && retries < t.config.MaxRetries {
-		backoff := t.config.Backoff(t.config, retries, resp)
+	var attempts []AttemptResult
+	var resets int // Consecutive connection resets.
+
+	backoffFn := t.config.Backoff
+	if t.config.NewBackoff != nil {
+		backoffFn = t.config.NewBackoff()
+	}
+
+	for {
+		retry, perr := t.shouldRetry(req, resp, err)
+		if perr != nil {
+			attempts = append(attempts, newAttemptResult(retries, resp, perr))
+			if resp != nil && resp.Body != nil {
+				_ = resp.Body.Close()
+			}
+			return nil, wrapAttempts(attempts, perr)
+		}
+
+		attempts = append(attempts, newAttemptResult(retries, resp, err))
+		if !retry || retries >= t.config.MaxRetries {
+			break
+		}
+
+		backoff := backoffFn(t.config, retries, resp)
+
+		if t.config.ImmediateRetryOnReset && IsConnectionReset(err) && isIdempotent(req) {
+			resets++
+			if resets == 1 {
+				backoff = 0
+			}
+		} else {
+			resets = 0
+		}
+
+		if t.config.MaxElapsed > 0 && time.Since(start)+backoff > t.config.MaxElapsed {
+			break
+		}
 
 		if t.config.RetryCallback != nil {
 			t.config.RetryCallback(req.Context(), retries, backoff, req, resp, err)
@@ -260,14 +651,53 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 
 		// Wait for the backoff duration.
-		time.Sleep(backoff)
+		if serr := t.config.Sleep(req.Context(), backoff); serr != nil {
+			return nil, serr
+		}
 
 		// Send the request again.
 		resp, err = t.config.BaseTransport.RoundTrip(req)
 		retries++
 	}
 
-	return resp, err
+	return resp, wrapAttempts(attempts, err)
+}
+
+// shouldRetry determines whether to retry the request, using [Config.DefaultPolicy]
+// and [Config.ResponsePolicy].
+func (t *transport) shouldRetry(req *http.Request, resp *http.Response, err error) (bool, error) {
+	if t.config.DefaultPolicy(req.Context(), resp, err) {
+		return true, nil
+	}
+
+	if err != nil || resp == nil || t.config.ResponsePolicy == nil {
+		return false, nil
+	}
+
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return t.config.ResponsePolicy(resp)
+	}
+
+	// Buffer the start of the body for the policy, and restore the full body
+	// afterwards, so the caller can still read all of it.
+	body := resp.Body
+	buf, rerr := io.ReadAll(io.LimitReader(body, t.config.MaxResponsePolicyBodySize))
+	if rerr != nil {
+		// Let the caller observe the read error when reading the body.
+		resp.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(buf), body), Closer: body}
+		return false, nil
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(buf))
+	retry, err := t.config.ResponsePolicy(resp)
+	resp.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(buf), body), Closer: body}
+	return retry, err
+}
+
+// readCloser combines a reader with the closer of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 // NewClient is identical to [NewTransport], but returns a higher-level [http.Client]
@@ -280,8 +710,13 @@ func NewClient(config *Config) *http.Client {
 	if err != nil {
 		panic(err)
 	}
+	timeout := max(config.MaxRateLimitDuration, config.MaxBackoff) * time.Duration(config.MaxRetries)
+	if config.MaxElapsed > 0 {
+		timeout = min(timeout, config.MaxElapsed)
+	}
+
 	return &http.Client{
-		Timeout:   max(config.MaxRateLimitDuration, config.MaxBackoff)*time.Duration(config.MaxRetries) + 5*time.Second,
+		Timeout:   timeout + 5*time.Second,
 		Transport: NewTransport(config),
 	}
 }
@@ -6,13 +6,25 @@ package httpcretry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"testing/synctest"
 	"time"
+
+	"golang.org/x/net/http2"
 )
 
 func ExampleNewClient() { //nolint:testableexamples
@@ -60,6 +72,15 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		{name: "negative-seconds", headers: []string{"-10"}, backoff: 0, ok: false},
 		{name: "valid-rfc1123", headers: []string{time.Now().Add(30 * time.Second).Format(time.RFC1123)}, backoff: 30 * time.Second, ok: true},
 		{name: "negative-rfc1123", headers: []string{time.Now().Add(-30 * time.Second).Format(time.RFC1123)}, backoff: 0, ok: false},
+		{name: "fractional-seconds", headers: []string{"1.5"}, backoff: 1500 * time.Millisecond, ok: true},
+		{name: "negative-fractional-seconds", headers: []string{"-1.5"}, backoff: 0, ok: false},
+		{name: "non-finite-seconds", headers: []string{"NaN", "Inf"}, backoff: 0, ok: false},
+		{name: "whitespace", headers: []string{" 10 "}, backoff: 10 * time.Second, ok: true},
+		{name: "multi-value", headers: []string{"invalid", "-5", "20"}, backoff: 20 * time.Second, ok: true},
+		{name: "multi-value-first-valid", headers: []string{"5", "20"}, backoff: 5 * time.Second, ok: true},
+		{name: "comma-separated", headers: []string{"invalid, 15, 30"}, backoff: 15 * time.Second, ok: true},
+		{name: "comma-separated-invalid", headers: []string{"invalid, -1"}, backoff: 0, ok: false},
+		{name: "multi-value-rfc1123", headers: []string{"soon", time.Now().Add(30 * time.Second).Format(time.RFC1123)}, backoff: 30 * time.Second, ok: true},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +107,73 @@ func TestParseRetryAfterHeader(t *testing.T) {
 	}
 }
 
+func TestParseRateLimitHeaders(t *testing.T) {
+	epoch := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		backoff time.Duration
+		ok      bool
+	}{
+		{name: "no-headers", headers: nil, ok: false},
+		{name: "ietf-delta-seconds", headers: map[string]string{"RateLimit-Reset": "15"}, backoff: 15 * time.Second, ok: true},
+		{name: "ietf-remaining-zero", headers: map[string]string{"RateLimit-Reset": "15", "RateLimit-Remaining": "0"}, backoff: 15 * time.Second, ok: true},
+		{name: "ietf-remaining-nonzero", headers: map[string]string{"RateLimit-Reset": "15", "RateLimit-Remaining": "3"}, ok: false},
+		{name: "ietf-invalid", headers: map[string]string{"RateLimit-Reset": "soon"}, ok: false},
+		{name: "ietf-negative", headers: map[string]string{"RateLimit-Reset": "-5"}, ok: false},
+		{name: "github-epoch", headers: map[string]string{"X-RateLimit-Reset": epoch(30 * time.Second), "X-RateLimit-Remaining": "0"}, backoff: 30 * time.Second, ok: true},
+		{name: "github-epoch-past", headers: map[string]string{"X-RateLimit-Reset": epoch(-30 * time.Second)}, ok: false},
+		{name: "ietf-preferred", headers: map[string]string{"RateLimit-Reset": "5", "X-RateLimit-Reset": epoch(time.Minute)}, backoff: 5 * time.Second, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+
+			backoff, ok := parseRateLimitHeaders(header)
+			if ok != tt.ok {
+				t.Fatalf("parseRateLimitHeaders() ok = %v, want %v", ok, tt.ok)
+			}
+
+			// Epoch cases are second-granular, so allow some tolerance.
+			const margin = 2 * time.Second
+			if backoff < tt.backoff-margin || backoff > tt.backoff+margin {
+				t.Errorf("parseRateLimitHeaders() backoff = %v, want %v", backoff, tt.backoff)
+			}
+		})
+	}
+}
+
+func TestDefaultBackoff_rateLimitHeaders(t *testing.T) {
+	config := &Config{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, MaxRateLimitDuration: time.Minute}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("RateLimit-Reset", "10")
+
+	if got := DefaultBackoff(config, 0, resp); got != 10*time.Second {
+		t.Errorf("DefaultBackoff() = %v, want 10s", got)
+	}
+
+	resp.Header.Set("RateLimit-Reset", "3600")
+	if got := DefaultBackoff(config, 0, resp); got != time.Minute {
+		t.Errorf("DefaultBackoff() = %v, want capped at 1m", got)
+	}
+
+	config.DisableRateLimitHeaders = true
+	if got := DefaultBackoff(config, 0, resp); got != time.Millisecond {
+		t.Errorf("DefaultBackoff() = %v, want exponential backoff when disabled", got)
+	}
+}
+
 func hstatus(t *testing.T, code int) http.HandlerFunc {
 	t.Helper()
 	return func(w http.ResponseWriter, _ *http.Request) {
@@ -123,6 +211,7 @@ func fastTestConfig() *Config {
 		MinBackoff:           1 * time.Millisecond,
 		MaxBackoff:           50 * time.Millisecond,
 		MaxRateLimitDuration: 50 * time.Millisecond,
+		Sleep:                func(context.Context, time.Duration) error { return nil },
 	}
 }
 
@@ -170,6 +259,103 @@ func mockServer(t *testing.T, handlers []http.HandlerFunc, overflow bool) *httpt
 	return srv
 }
 
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{MinBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("failed to validate config: %v", err)
+	}
+
+	seen := make(map[time.Duration]struct{})
+	var capped int
+
+	for range 1000 {
+		backoff := DecorrelatedJitterBackoff()
+		previous := config.MinBackoff
+
+		for attempt := range 10 {
+			sleep := backoff(config, attempt, nil)
+			if sleep < config.MinBackoff || sleep > config.MaxBackoff {
+				t.Fatalf("attempt %d: sleep = %s, want within [%s, %s]", attempt, sleep, config.MinBackoff, config.MaxBackoff)
+			}
+			if sleep > previous*3 {
+				t.Fatalf("attempt %d: sleep = %s, want at most 3x previous (%s)", attempt, sleep, previous)
+			}
+			if sleep == config.MaxBackoff {
+				capped++
+			}
+			seen[sleep] = struct{}{}
+			previous = sleep
+		}
+	}
+
+	// Sleeps should be spread out, rather than the same for every request.
+	if len(seen) < 1000 {
+		t.Errorf("got %d distinct sleeps, want at least 1000", len(seen))
+	}
+	if capped == 0 {
+		t.Error("expected some sleeps to reach MaxBackoff")
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3"}},
+	}
+	if sleep := DecorrelatedJitterBackoff()(config, 0, resp); sleep != 3*time.Second {
+		t.Errorf("sleep = %s, want Retry-After of %s", sleep, 3*time.Second)
+	}
+}
+
+func TestNewTransport_NewBackoff(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Int32
+	var slept []time.Duration
+
+	config := &Config{
+		MaxRetries: 3,
+		NewBackoff: func() BackoffFunc {
+			created.Add(1)
+			var calls time.Duration
+			return func(*Config, int, *http.Response) time.Duration {
+				calls++
+				return calls * time.Second
+			}
+		},
+		Sleep: func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		},
+	}
+	config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+	})
+
+	rt := NewTransport(config)
+	for range 2 {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if created.Load() != 2 {
+		t.Errorf("NewBackoff called %d times, want once per request (2)", created.Load())
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, time.Second, 2 * time.Second, 3 * time.Second}
+	if !slices.Equal(slept, want) {
+		t.Errorf("slept = %v, want %v", slept, want)
+	}
+}
+
 func TestNewTransport(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -303,3 +489,581 @@ func TestNewTransport(t *testing.T) {
 		})
 	}
 }
+
+func hbody(t *testing.T, code int, body string) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(code)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+func TestStatusCodePolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := StatusCodePolicy(http.StatusConflict, http.StatusTooEarly)
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context //nolint:containedctx
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{name: "network-error", ctx: t.Context(), err: errors.New("dial"), want: true},
+		{name: "canceled", ctx: canceled, err: context.Canceled, want: false},
+		{name: "allowlisted-409", ctx: t.Context(), resp: &http.Response{StatusCode: http.StatusConflict}, want: true},
+		{name: "allowlisted-425", ctx: t.Context(), resp: &http.Response{StatusCode: http.StatusTooEarly}, want: true},
+		{name: "not-allowlisted-500", ctx: t.Context(), resp: &http.Response{StatusCode: http.StatusInternalServerError}, want: false},
+		{name: "not-allowlisted-429", ctx: t.Context(), resp: &http.Response{StatusCode: http.StatusTooManyRequests}, want: false},
+	}
+	for _, tt := range tests {
+		if got := policy(tt.ctx, tt.resp, tt.err); got != tt.want {
+			t.Errorf("%s: StatusCodePolicy = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewTransport_RetryStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	srv := mockServer(t, []http.HandlerFunc{
+		hstatus(t, http.StatusConflict),
+		hstatus(t, http.StatusInternalServerError),
+	}, false)
+
+	config := fastTestConfig()
+	config.RetryStatusCodes = []int{http.StatusConflict}
+
+	client := &http.Client{Transport: NewTransport(config)}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// 409 is retried, 500 is not (as it isn't in the allowlist).
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestNewTransport_ResponsePolicy(t *testing.T) {
+	t.Parallel()
+
+	srv := mockServer(t, []http.HandlerFunc{
+		hbody(t, http.StatusOK, `{"status":"throttled"}`),
+		hbody(t, http.StatusOK, `{"status":"ok","data":"`+strings.Repeat("x", 100)+`"}`),
+	}, false)
+
+	config := fastTestConfig()
+	config.MaxResponsePolicyBodySize = 32
+	config.ResponsePolicy = func(resp *http.Response) (bool, error) {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return false, err
+		}
+		if len(b) > 32 {
+			t.Errorf("policy got %d bytes, want at most 32", len(b))
+		}
+		return strings.Contains(string(b), `"throttled"`), nil
+	}
+
+	client := &http.Client{Transport: NewTransport(config)}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if want := `{"status":"ok","data":"` + strings.Repeat("x", 100) + `"}`; string(b) != want {
+		t.Errorf("body = %q, want %q", b, want)
+	}
+}
+
+func TestNewTransport_ResponsePolicyError(t *testing.T) {
+	t.Parallel()
+
+	srv := mockServer(t, []http.HandlerFunc{hbody(t, http.StatusOK, `{"status":"fatal"}`)}, false)
+
+	want := errors.New("fatal response")
+	config := fastTestConfig()
+	config.ResponsePolicy = func(*http.Response) (bool, error) {
+		return false, want
+	}
+
+	client := &http.Client{Transport: NewTransport(config)}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("err = %v, want %v", err, want)
+	}
+}
+
+// funcRoundTripper adapts a function to [http.RoundTripper] for tests.
+type funcRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f funcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewTransport_RetryError(t *testing.T) {
+	t.Parallel()
+
+	errDial := errors.New("dial failed")
+	errReset := errors.New("connection reset")
+
+	var mu sync.Mutex
+	results := []struct {
+		status int
+		err    error
+	}{
+		{err: errDial},
+		{status: http.StatusServiceUnavailable},
+		{err: errReset},
+	}
+
+	config := fastTestConfig()
+	config.MaxRetries = len(results) - 1
+	config.BaseTransport = funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		r := results[0]
+		results = results[1:]
+		if r.err != nil {
+			return nil, r.err
+		}
+		return &http.Response{StatusCode: r.status, Body: http.NoBody, Request: req}, nil
+	})
+
+	client := &http.Client{Transport: NewTransport(config)}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if !errors.Is(err, errReset) {
+		t.Fatalf("err = %v, want %v", err, errReset)
+	}
+	if !errors.Is(err, errDial) {
+		t.Fatalf("err = %v, expected to also match earlier attempt error %v", err, errDial)
+	}
+
+	var rerr *RetryError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("err = %T, want *RetryError", err)
+	}
+	if !errors.Is(rerr.Last(), errReset) {
+		t.Fatalf("last = %v, want %v", rerr.Last(), errReset)
+	}
+
+	want := []AttemptResult{
+		{Attempt: 0, Err: errDial},
+		{Attempt: 1, StatusCode: http.StatusServiceUnavailable},
+		{Attempt: 2, Err: errReset},
+	}
+	got := rerr.Attempts()
+	if len(got) != len(want) {
+		t.Fatalf("attempts = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("attempt %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewTransport_noRetryErrorWithoutRetries(t *testing.T) {
+	t.Parallel()
+
+	want := errors.New("not retried")
+	config := fastTestConfig()
+	config.DefaultPolicy = func(context.Context, *http.Response, error) bool { return false }
+	config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+		return nil, want
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	_, err = NewTransport(config).RoundTrip(req)
+	if err != want { //nolint:errorlint
+		t.Fatalf("err = %v, want unwrapped %v", err, want)
+	}
+}
+
+func TestNewTransport_Sleep(t *testing.T) {
+	t.Parallel()
+
+	var slept []time.Duration
+	config := &Config{
+		MaxRetries: 3,
+		MinBackoff: time.Minute,
+		MaxBackoff: time.Hour,
+		Sleep: func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		},
+	}
+	config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := NewTransport(config).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(slept) != 3 {
+		t.Fatalf("slept %d times, want 3", len(slept))
+	}
+	for i, d := range slept {
+		if d < time.Minute {
+			t.Errorf("sleep %d = %s, want at least %s", i, d, time.Minute)
+		}
+	}
+}
+
+func TestNewTransport_SleepError(t *testing.T) {
+	t.Parallel()
+
+	want := errors.New("sleep failed")
+	var attempts int
+
+	config := &Config{
+		Sleep: func(context.Context, time.Duration) error { return want },
+	}
+	config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := NewTransport(config).RoundTrip(req)
+	if !errors.Is(err, want) {
+		t.Fatalf("err = %v, want %v", err, want)
+	}
+	if resp != nil {
+		t.Fatalf("resp = %v, want nil", resp)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestDefaultSleep_contextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if err := DefaultSleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestIsConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{
+			name: "econnreset",
+			err:  &net.OpError{Op: "read", Net: "tcp", Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}},
+			want: true,
+		},
+		{
+			name: "wrapped-econnreset",
+			err:  fmt.Errorf("roundtrip: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}),
+			want: true,
+		},
+		{
+			name: "http2-goaway",
+			err:  errors.New("http2: server sent GOAWAY and closed the connection; LastStreamID=1, ErrCode=NO_ERROR, debug=\"\""),
+			want: true,
+		},
+		{
+			name: "http2-goaway-graceful",
+			err:  errors.New("http2: Transport received Server's graceful shutdown GOAWAY"),
+			want: true,
+		},
+		{
+			name: "http2-goaway-error",
+			err:  errors.New("http2: server sent GOAWAY and closed the connection; LastStreamID=1, ErrCode=PROTOCOL_ERROR, debug=\"\""),
+			want: false,
+		},
+		{
+			name: "http2-goaway-typed",
+			err:  fmt.Errorf("roundtrip: %w", http2.GoAwayError{LastStreamID: 1, ErrCode: http2.ErrCodeNo}),
+			want: true,
+		},
+		{
+			name: "http2-goaway-typed-error",
+			err:  fmt.Errorf("roundtrip: %w", http2.GoAwayError{LastStreamID: 1, ErrCode: http2.ErrCodeEnhanceYourCalm}),
+			want: false,
+		},
+		{name: "timeout", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, want: false},
+		{name: "refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, want: false},
+		{name: "eof", err: io.EOF, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsConnectionReset(tt.err); got != tt.want {
+				t.Errorf("IsConnectionReset(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// resetServer returns a server which resets the connection (before sending any
+// response bytes) for the first resets requests, and responds with 200 OK
+// afterwards.
+func resetServer(t *testing.T, resets int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) > resets {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("failed to hijack connection: %v", err)
+			return
+		}
+
+		// A zero linger makes Close send a RST, rather than a FIN.
+		_ = conn.(*net.TCPConn).SetLinger(0)
+		_ = conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestNewTransport_ImmediateRetryOnReset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		method    string
+		header    http.Header
+		immediate bool
+		resets    int32
+		want      []time.Duration
+	}{
+		{name: "get", method: http.MethodGet, immediate: true, resets: 1, want: []time.Duration{0}},
+		{name: "put", method: http.MethodPut, immediate: true, resets: 1, want: []time.Duration{0}},
+		{name: "disabled", method: http.MethodGet, immediate: false, resets: 1, want: []time.Duration{time.Minute}},
+		{name: "post", method: http.MethodPost, immediate: true, resets: 1, want: []time.Duration{time.Minute}},
+		{
+			name:      "post-idempotency-key",
+			method:    http.MethodPost,
+			header:    http.Header{"Idempotency-Key": {"abc"}},
+			immediate: true,
+			resets:    1,
+			want:      []time.Duration{0},
+		},
+		{
+			name:      "consecutive",
+			method:    http.MethodGet,
+			immediate: true,
+			resets:    3,
+			want:      []time.Duration{0, 2 * time.Minute, 4 * time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, requests := resetServer(t, tt.resets)
+
+			var slept []time.Duration
+			var errs []error
+			config := &Config{
+				// Disable keep-alives, so net/http doesn't transparently retry
+				// requests on reused connections.
+				BaseTransport:         &http.Transport{DisableKeepAlives: true},
+				MinBackoff:            time.Minute,
+				MaxBackoff:            time.Hour,
+				ImmediateRetryOnReset: tt.immediate,
+				Sleep: func(_ context.Context, d time.Duration) error {
+					slept = append(slept, d)
+					return nil
+				},
+				RetryCallback: func(_ context.Context, _ int, _ time.Duration, _ *http.Request, _ *http.Response, err error) {
+					errs = append(errs, err)
+				},
+			}
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, srv.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			maps.Copy(req.Header, tt.header)
+
+			resp, err := NewTransport(config).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+			if got := requests.Load(); got != tt.resets+1 {
+				t.Fatalf("requests = %d, want %d", got, tt.resets+1)
+			}
+			for i, err := range errs {
+				if !IsConnectionReset(err) {
+					t.Errorf("retry %d error = %v, want connection reset", i, err)
+				}
+			}
+			if !slices.Equal(slept, tt.want) {
+				t.Fatalf("slept = %v, want %v", slept, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTransport_MaxElapsed(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var attempts int
+		config := &Config{
+			MaxRetries: 10,
+			MinBackoff: time.Minute,
+			MaxBackoff: time.Minute,
+			MaxElapsed: 150 * time.Second,
+		}
+		config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+		})
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+
+		start := time.Now()
+		resp, err := NewTransport(config).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+		}
+		if attempts != 3 {
+			t.Fatalf("attempts = %d, want 3", attempts)
+		}
+		if elapsed := time.Since(start); elapsed != 2*time.Minute {
+			t.Fatalf("elapsed = %s, want %s", elapsed, 2*time.Minute)
+		}
+	})
+}
+
+func TestNewTransport_MaxElapsedRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	config := &Config{
+		MaxRateLimitDuration: 2 * time.Hour,
+		MaxElapsed:           time.Minute,
+		Sleep: func(_ context.Context, d time.Duration) error {
+			t.Errorf("unexpected sleep of %s", d)
+			return nil
+		},
+	}
+	config.BaseTransport = funcRoundTripper(func(*http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"3600"}},
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := NewTransport(config).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestNewClient_MaxElapsedTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(&Config{MaxElapsed: 10 * time.Second})
+	if want := 15 * time.Second; client.Timeout != want {
+		t.Fatalf("timeout = %s, want %s", client.Timeout, want)
+	}
+
+	client = NewClient(&Config{MaxRetries: 1, MaxBackoff: time.Second, MaxElapsed: time.Hour})
+	if want := 6 * time.Second; client.Timeout != want {
+		t.Fatalf("timeout = %s, want %s", client.Timeout, want)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package httpctrace (http client trace) provides a [net/http.RoundTripper] that
+// propagates [W3C Trace Context] headers (traceparent/tracestate) on outbound
+// requests, using a trace context extracted from the request context.
+//
+// [W3C Trace Context]: https://www.w3.org/TR/trace-context/
+package httpctrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderTraceparent is the W3C Trace Context traceparent header.
+	HeaderTraceparent = "Traceparent"
+	// HeaderTracestate is the W3C Trace Context tracestate header.
+	HeaderTracestate = "Tracestate"
+
+	// FlagSampled is the traceparent "sampled" trace flag.
+	FlagSampled byte = 0x01
+
+	traceparentVersion = "00"
+)
+
+// ErrInvalidTraceparent is returned by [ParseTraceparent] when the header value
+// is malformed.
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// TraceContext is a W3C trace context, identifying the trace and the parent span
+// of an outbound request.
+type TraceContext struct {
+	// TraceID is the ID of the whole trace. Must not be all zeros.
+	TraceID [16]byte
+	// SpanID is the ID of the current (parent) span. Must not be all zeros.
+	SpanID [8]byte
+	// Flags are the trace flags, e.g. [FlagSampled].
+	Flags byte
+	// State is the optional, vendor-specific tracestate header value, propagated
+	// as-is.
+	State string
+}
+
+// IsValid reports whether both the trace ID and span ID are non-zero.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != [16]byte{} && tc.SpanID != [8]byte{}
+}
+
+// Sampled reports whether the [FlagSampled] flag is set.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&FlagSampled != 0
+}
+
+// Traceparent returns the traceparent header value for the trace context.
+func (tc TraceContext) Traceparent() string {
+	return fmt.Sprintf(
+		"%s-%s-%s-%02x",
+		traceparentVersion,
+		hex.EncodeToString(tc.TraceID[:]),
+		hex.EncodeToString(tc.SpanID[:]),
+		tc.Flags,
+	)
+}
+
+// Child returns a copy of the trace context with a new, random span ID, which
+// is what should be propagated to the next hop.
+func (tc TraceContext) Child() TraceContext {
+	child := tc
+	for child.SpanID == tc.SpanID || child.SpanID == [8]byte{} {
+		_, _ = rand.Read(child.SpanID[:])
+	}
+	return child
+}
+
+// ParseTraceparent parses a traceparent header value (and optionally, the
+// tracestate header value) into a [TraceContext]. This is useful for continuing
+// a trace from an inbound request.
+func ParseTraceparent(traceparent, tracestate string) (TraceContext, error) {
+	var tc TraceContext
+
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return tc, ErrInvalidTraceparent
+	}
+
+	// Version 00 must have exactly 4 parts, future versions may append more.
+	if parts[0] == traceparentVersion && len(parts) != 4 {
+		return tc, ErrInvalidTraceparent
+	}
+
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return tc, ErrInvalidTraceparent
+	}
+
+	if _, err := hex.Decode(tc.TraceID[:], []byte(parts[1])); err != nil {
+		return tc, ErrInvalidTraceparent
+	}
+	if _, err := hex.Decode(tc.SpanID[:], []byte(parts[2])); err != nil {
+		return tc, ErrInvalidTraceparent
+	}
+
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return tc, ErrInvalidTraceparent
+	}
+	tc.Flags = flags[0]
+
+	if !tc.IsValid() {
+		return tc, ErrInvalidTraceparent
+	}
+
+	tc.State = strings.TrimSpace(tracestate)
+	return tc, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx which carries tc. Use [FromContext] as the
+// [Config.Extractor] to propagate it.
+func NewContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, tc)
+}
+
+// FromContext returns the [TraceContext] stored in ctx by [NewContext], if any.
+// It can be used directly as a [Config.Extractor].
+func FromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(contextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// ExtractorFunc extracts the current trace context from a request context. It
+// should return false if there is no trace context available. This allows
+// plugging in any tracer (e.g. OpenTelemetry) without a direct dependency.
+type ExtractorFunc func(ctx context.Context) (TraceContext, bool)
+
+// NoopExtractor is an [ExtractorFunc] that never returns a trace context, which
+// makes the transport a pass-through.
+func NoopExtractor(_ context.Context) (TraceContext, bool) {
+	return TraceContext{}, false
+}
+
+// Config is the configuration for the trace transport.
+type Config struct {
+	// BaseTransport is the base transport to use (will be chained). Defaults to
+	// [net/http.DefaultTransport], which allows for connection reuse, HTTP proxy
+	// support, etc.
+	BaseTransport http.RoundTripper
+
+	// Extractor extracts the trace context from the request context. Defaults to
+	// [NoopExtractor], so the transport is safe to add to a chain even without a
+	// tracer configured. See also [FromContext].
+	Extractor ExtractorFunc
+
+	// Overwrite will replace any traceparent/tracestate headers already present
+	// on the request. By default, existing headers are left untouched.
+	Overwrite bool
+}
+
+// Validate validates the trace configuration, and sets defaults. Use this to
+// validate the configuration, before passing it to [NewTransport] or
+// [NewClient], as they will panic if the configuration is invalid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("config cannot be nil")
+	}
+
+	if c.BaseTransport == nil {
+		c.BaseTransport = http.DefaultTransport
+	}
+
+	if c.Extractor == nil {
+		c.Extractor = NoopExtractor
+	}
+
+	return nil
+}
+
+type transport struct {
+	config *Config
+}
+
+// NewTransport returns a [net/http.RoundTripper] that injects traceparent and
+// tracestate headers into outbound requests, using a new child span ID per
+// request. See also [NewClient]. This will panic if the configuration is invalid,
+// which can be avoided by using [Config.Validate] first.
+func NewTransport(config *Config) http.RoundTripper {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return &transport{config: config}
+}
+
+// NewClient returns an [http.Client] which propagates trace context headers. See
+// also [NewTransport]. The default timeout is 60 seconds. This will panic if the
+// configuration is invalid, which can be avoided by using [Config.Validate] first.
+func NewClient(config *Config) *http.Client {
+	if config == nil {
+		config = &Config{}
+	}
+	err := config.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return &http.Client{
+		Timeout:   60 * time.Second,
+		Transport: NewTransport(config),
+	}
+}
+
+// RoundTrip implements [net/http.RoundTripper]. The original request is never
+// modified; when headers are injected, a clone of the request is sent instead.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.config.Overwrite && req.Header.Get(HeaderTraceparent) != "" {
+		return t.config.BaseTransport.RoundTrip(req)
+	}
+
+	tc, ok := t.config.Extractor(req.Context())
+	if !ok || !tc.IsValid() {
+		return t.config.BaseTransport.RoundTrip(req)
+	}
+
+	child := tc.Child()
+
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderTraceparent, child.Traceparent())
+	if child.State != "" {
+		req.Header.Set(HeaderTracestate, child.State)
+	} else {
+		req.Header.Del(HeaderTracestate)
+	}
+
+	return t.config.BaseTransport.RoundTrip(req)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package httpctrace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// funcRoundTripper adapts a function to [http.RoundTripper] for tests.
+type funcRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f funcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func captureTransport(got *http.Header) http.RoundTripper {
+	return funcRoundTripper(func(req *http.Request) (*http.Response, error) {
+		*got = req.Header.Clone()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+}
+
+const (
+	testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTracestate  = "congo=t61rcWkgMzE"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		err   bool
+	}{
+		{name: "valid", input: testTraceparent},
+		{name: "valid-future-version", input: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"},
+		{name: "empty", input: "", err: true},
+		{name: "invalid-version", input: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", err: true},
+		{name: "extra-parts-v00", input: testTraceparent + "-00", err: true},
+		{name: "short-trace-id", input: "00-4bf92f3577b34da6-00f067aa0ba902b7-01", err: true},
+		{name: "non-hex", input: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", err: true},
+		{name: "zero-trace-id", input: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", err: true},
+		{name: "zero-span-id", input: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseTraceparent(tt.input, "")
+			if tt.err != (err != nil) {
+				t.Fatalf("ParseTraceparent(%q) error = %v, want error %v", tt.input, err, tt.err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTraceparent) {
+				t.Fatalf("error = %v, want ErrInvalidTraceparent", err)
+			}
+		})
+	}
+}
+
+func TestTraceContext_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	tc, err := ParseTraceparent(testTraceparent, " "+testTracestate+" ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tc.Traceparent(); got != testTraceparent {
+		t.Fatalf("Traceparent() = %q, want %q", got, testTraceparent)
+	}
+	if tc.State != testTracestate {
+		t.Fatalf("State = %q, want %q", tc.State, testTracestate)
+	}
+	if !tc.Sampled() {
+		t.Fatal("expected sampled flag")
+	}
+
+	child := tc.Child()
+	if child.TraceID != tc.TraceID || child.Flags != tc.Flags || child.State != tc.State {
+		t.Fatalf("child = %+v, want same trace as %+v", child, tc)
+	}
+	if child.SpanID == tc.SpanID {
+		t.Fatal("child span ID should differ from parent")
+	}
+}
+
+func TestTransport_defaultExtractorIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	tr := NewTransport(&Config{BaseTransport: captureTransport(&got)})
+
+	tc, _ := ParseTraceparent(testTraceparent, "")
+	req := httptest.NewRequestWithContext(NewContext(context.Background(), tc), http.MethodGet, "http://example.com/", nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if v := got.Get(HeaderTraceparent); v != "" {
+		t.Fatalf("traceparent = %q, want none", v)
+	}
+}
+
+func TestTransport_injectsChildSpan(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	tr := NewTransport(&Config{
+		BaseTransport: captureTransport(&got),
+		Extractor:     FromContext,
+	})
+
+	parent, err := ParseTraceparent(testTraceparent, testTracestate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequestWithContext(NewContext(context.Background(), parent), http.MethodGet, "http://example.com/", nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if req.Header.Get(HeaderTraceparent) != "" {
+		t.Fatal("original request should not be modified")
+	}
+
+	sent, err := ParseTraceparent(got.Get(HeaderTraceparent), got.Get(HeaderTracestate))
+	if err != nil {
+		t.Fatalf("sent traceparent %q: %v", got.Get(HeaderTraceparent), err)
+	}
+	if sent.TraceID != parent.TraceID {
+		t.Fatalf("trace ID = %x, want %x", sent.TraceID, parent.TraceID)
+	}
+	if sent.SpanID == parent.SpanID {
+		t.Fatal("expected a new child span ID")
+	}
+	if sent.State != testTracestate {
+		t.Fatalf("tracestate = %q, want %q", sent.State, testTracestate)
+	}
+}
+
+func TestTransport_overwrite(t *testing.T) {
+	t.Parallel()
+
+	parent, _ := ParseTraceparent(testTraceparent, "")
+	existing := "00-11111111111111111111111111111111-2222222222222222-00"
+
+	for _, overwrite := range []bool{false, true} {
+		var got http.Header
+		tr := NewTransport(&Config{
+			BaseTransport: captureTransport(&got),
+			Extractor:     FromContext,
+			Overwrite:     overwrite,
+		})
+
+		req := httptest.NewRequestWithContext(NewContext(context.Background(), parent), http.MethodGet, "http://example.com/", nil)
+		req.Header.Set(HeaderTraceparent, existing)
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+
+		if kept := got.Get(HeaderTraceparent) == existing; kept == overwrite {
+			t.Fatalf("overwrite=%v: traceparent = %q", overwrite, got.Get(HeaderTraceparent))
+		}
+	}
+}
+
+func TestConfigValidate_NilReceiver(t *testing.T) {
+	t.Parallel()
+	if err := (*Config)(nil).Validate(); err == nil {
+		t.Fatal("expected error for nil config")
+	}
+}
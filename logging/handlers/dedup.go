@@ -0,0 +1,191 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ slog.Handler = (*Dedup)(nil) // Ensure we implement the [log/slog.Handler] interface.
+
+// DedupRepeatedKey is the attribute key used for the repeat count, on records
+// emitted by [Dedup] when collapsing duplicates.
+const DedupRepeatedKey = "repeated"
+
+// dedupState is the state shared between a [Dedup] handler, and all handlers
+// derived from it with [Dedup.WithAttrs] and [Dedup.WithGroup].
+type dedupState struct {
+	mu       sync.Mutex
+	interval time.Duration
+	timer    *time.Timer
+
+	owner   *Dedup // Handler the last record was handled by.
+	key     string
+	last    slog.Record
+	repeats int
+}
+
+// Dedup collapses identical consecutive records (same level, message and
+// attributes) into a single "last message repeated N times" record, like syslog.
+// The first record is passed to the wrapped handler immediately, and duplicates
+// are counted, and emitted as a single record when a different record arrives,
+// the flush interval elapses, or [Dedup.Close] is called.
+type Dedup struct {
+	handler slog.Handler
+	state   *dedupState
+}
+
+// NewDedup creates a new [log/slog.Handler] which collapses identical consecutive
+// records, passing records to the wrapped handler. If flushInterval is greater
+// than 0, pending duplicates are also emitted after the interval elapses, so
+// they aren't held back indefinitely. [Dedup.Close] should be called on
+// shutdown, to emit any pending duplicates.
+func NewDedup(flushInterval time.Duration, handler slog.Handler) *Dedup {
+	return &Dedup{
+		handler: handler,
+		state:   &dedupState{interval: flushInterval},
+	}
+}
+
+// Enabled checks if the wrapped handler is enabled for the given level.
+func (h *Dedup) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.handler.Enabled(ctx, l)
+}
+
+// Handle passes the record to the wrapped handler, unless it is identical to the
+// previous record, in which case it is counted, and emitted later as a repeat
+// count.
+func (h *Dedup) Handle(ctx context.Context, r slog.Record) error {
+	key := dedupKey(r)
+
+	s := h.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.owner == h && s.key == key {
+		s.repeats++
+		if s.repeats == 1 && s.interval > 0 {
+			if s.timer == nil {
+				s.timer = time.AfterFunc(s.interval, s.flushTimer)
+			} else {
+				s.timer.Reset(s.interval)
+			}
+		}
+		return nil
+	}
+
+	err := s.flushLocked(ctx)
+	s.owner = h
+	s.key = key
+	s.last = r.Clone()
+
+	if herr := h.handler.Handle(ctx, r); herr != nil {
+		return herr
+	}
+	return err
+}
+
+// WithAttrs creates a new handler with additional attributes added to the
+// wrapped handler. Duplicates are tracked across all derived handlers.
+func (h *Dedup) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Dedup{handler: h.handler.WithAttrs(attrs), state: h.state}
+}
+
+// WithGroup creates a new handler with a group name applied to the wrapped
+// handler. Duplicates are tracked across all derived handlers.
+func (h *Dedup) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Dedup{handler: h.handler.WithGroup(name), state: h.state}
+}
+
+// Flush emits any pending duplicates to the wrapped handler.
+func (h *Dedup) Flush(ctx context.Context) error {
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+	return h.state.flushLocked(ctx)
+}
+
+// Close emits any pending duplicates to the wrapped handler, and stops the
+// flush timer. The handler can still be used after it is closed, though pending
+// duplicates will no longer be flushed on an interval.
+func (h *Dedup) Close() error {
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+	h.state.interval = 0
+	return h.state.flushLocked(context.Background())
+}
+
+// flushTimer is invoked by the flush timer.
+func (s *dedupState) flushTimer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.flushLocked(context.Background())
+}
+
+// flushLocked emits the repeat count of the last record, if it was repeated.
+// Caller must hold [dedupState.mu].
+func (s *dedupState) flushLocked(ctx context.Context) error {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	if s.repeats == 0 || s.owner == nil {
+		return nil
+	}
+
+	r := slog.NewRecord(
+		time.Now(),
+		s.last.Level,
+		fmt.Sprintf("last message repeated %d times", s.repeats),
+		s.last.PC,
+	)
+	r.AddAttrs(slog.Int(DedupRepeatedKey, s.repeats))
+	s.repeats = 0
+
+	if !s.owner.handler.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return s.owner.handler.Handle(ctx, r)
+}
+
+// dedupKey returns a key which is identical for records with the same level,
+// message and attributes.
+func dedupKey(r slog.Record) string {
+	var b strings.Builder
+	b.WriteString(r.Level.String())
+	b.WriteByte(0)
+	b.WriteString(r.Message)
+	r.Attrs(func(attr slog.Attr) bool {
+		b.WriteByte(0)
+		writeAttr(&b, attr)
+		return true
+	})
+	return b.String()
+}
+
+// writeAttr writes a resolved attribute (including nested groups) to b.
+func writeAttr(b *strings.Builder, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	b.WriteString(attr.Key)
+	b.WriteByte('=')
+	if attr.Value.Kind() != slog.KindGroup {
+		b.WriteString(attr.Value.String())
+		return
+	}
+	b.WriteByte('{')
+	for i, a := range attr.Value.Group() {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		writeAttr(b, a)
+	}
+	b.WriteByte('}')
+}
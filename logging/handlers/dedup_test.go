@@ -0,0 +1,134 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package handlers
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a concurrent-safe [bytes.Buffer].
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func newTestDedup(interval time.Duration) (*Dedup, *syncBuffer) {
+	buf := &syncBuffer{}
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return NewDedup(interval, handler), buf
+}
+
+func assertLines(t *testing.T, got, want []string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDedup(t *testing.T) {
+	h, buf := newTestDedup(0)
+	logger := slog.New(h)
+
+	logger.Info("connecting", "attempt", 1)
+	logger.Info("connecting", "attempt", 1)
+	logger.Info("connecting", "attempt", 1)
+	logger.Info("connecting", "attempt", 2)
+	logger.Warn("connecting", "attempt", 2)
+	logger.Warn("connecting", "attempt", 2)
+	logger.Info("connected")
+	logger.Info("connected")
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	assertLines(t, buf.lines(), []string{
+		`level=INFO msg=connecting attempt=1`,
+		`level=INFO msg="last message repeated 2 times" repeated=2`,
+		`level=INFO msg=connecting attempt=2`,
+		`level=WARN msg=connecting attempt=2`,
+		`level=WARN msg="last message repeated 1 times" repeated=1`,
+		`level=INFO msg=connected`,
+		`level=INFO msg="last message repeated 1 times" repeated=1`,
+	})
+}
+
+func TestDedup_derivedHandlers(t *testing.T) {
+	h, buf := newTestDedup(0)
+	logger := slog.New(h)
+	a := logger.With("component", "a")
+	b := logger.WithGroup("b")
+
+	a.Info("tick")
+	a.Info("tick")
+	b.Info("tick")
+	logger.Info("tick")
+	logger.Info("tick")
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	assertLines(t, buf.lines(), []string{
+		`level=INFO msg=tick component=a`,
+		`level=INFO msg="last message repeated 1 times" component=a repeated=1`,
+		`level=INFO msg=tick`,
+		`level=INFO msg=tick`,
+		`level=INFO msg="last message repeated 1 times" repeated=1`,
+	})
+}
+
+func TestDedup_flushInterval(t *testing.T) {
+	h, buf := newTestDedup(20 * time.Millisecond)
+	defer h.Close()
+	logger := slog.New(h)
+
+	logger.Info("tick")
+	logger.Info("tick")
+	logger.Info("tick")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(buf.lines()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for flush")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	logger.Info("tick")
+	if err := h.Flush(t.Context()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	assertLines(t, buf.lines(), []string{
+		`level=INFO msg=tick`,
+		`level=INFO msg="last message repeated 2 times" repeated=2`,
+		`level=INFO msg="last message repeated 1 times" repeated=1`,
+	})
+}
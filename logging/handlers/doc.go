@@ -3,6 +3,7 @@
 // the LICENSE file.
 
 // Package handlers provides supplemental [log/slog.Handler] implementations,
-// including fanout to multiple handlers, in-memory history, panic capture,
-// discard, and level overrides.
+// including fanout to multiple handlers, per-handler minimum levels, in-memory
+// history, panic capture, discard, level overrides, and deduplication of
+// repeated records.
 package handlers
@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = (*minLevel)(nil) // Ensure we implement the [log/slog.Handler] interface.
+
+// minLevel drops records below a minimum level, before they reach another handler.
+type minLevel struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+// NewMinLevel creates a new [log/slog.Handler] which only passes records at or
+// above level to the wrapped handler. The level is checked on every record, so a
+// [log/slog.LevelVar] can be used to change it at runtime.
+//
+// This is useful for giving each handler of a fanout (e.g. [log/slog.NewMultiHandler])
+// its own minimum level, for example, to send errors to stderr, while the
+// [Historical] handler keeps everything:
+//
+//	slog.New(slog.NewMultiHandler(
+//		handlers.NewMinLevel(slog.LevelError, slog.NewTextHandler(os.Stderr, nil)),
+//		handlers.NewHistorical(1000, slog.LevelDebug, handlers.NewMinLevel(slog.LevelError, fileHandler)),
+//	))
+//
+// The multi-handler is enabled for a level if any of its handlers are, and
+// attributes and groups are forwarded to all of them.
+func NewMinLevel(level slog.Leveler, handler slog.Handler) slog.Handler {
+	return &minLevel{level: level, handler: handler}
+}
+
+// Enabled checks if the level is at or above the minimum level, and the wrapped
+// handler is enabled for it.
+func (h *minLevel) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.level.Level() && h.handler.Enabled(ctx, l)
+}
+
+// Handle passes the record to the wrapped handler, if it is at or above the
+// minimum level.
+func (h *minLevel) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.level.Level() {
+		return nil
+	}
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs creates a new handler with additional attributes added to the
+// wrapped handler, with the same minimum level.
+func (h *minLevel) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &minLevel{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup creates a new handler with a group name applied to the wrapped
+// handler, with the same minimum level.
+func (h *minLevel) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &minLevel{level: h.level, handler: h.handler.WithGroup(name)}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package handlers
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestMinLevel(t *testing.T) {
+	t.Parallel()
+
+	level := &slog.LevelVar{}
+	level.Set(slog.LevelWarn)
+
+	errs := &syncBuffer{}
+	all := &syncBuffer{}
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}
+
+	logger := slog.New(NewFanout(
+		NewMinLevel(level, slog.NewTextHandler(errs, opts)),
+		NewMinLevel(slog.LevelDebug, slog.NewTextHandler(all, opts)),
+	)).With("a", 1).WithGroup("g")
+
+	if logger.Enabled(t.Context(), slog.LevelDebug-1) {
+		t.Fatal("expected logger to be disabled below the lowest minimum level")
+	}
+
+	logger.Debug("debug", "b", 2)
+	logger.Warn("warn", "b", 2)
+	level.Set(slog.LevelError)
+	logger.Warn("filtered")
+	logger.Error("error")
+
+	want := []string{
+		"level=WARN msg=warn a=1 g.b=2",
+		"level=ERROR msg=error a=1",
+	}
+	if got := errs.lines(); !slices.Equal(got, want) {
+		t.Fatalf("errors handler got:\n%q\nwant:\n%q", got, want)
+	}
+
+	want = []string{
+		"level=DEBUG msg=debug a=1 g.b=2",
+		"level=WARN msg=warn a=1 g.b=2",
+		"level=WARN msg=filtered a=1",
+		"level=ERROR msg=error a=1",
+	}
+	if got := all.lines(); !slices.Equal(got, want) {
+		t.Fatalf("all handler got:\n%q\nwant:\n%q", got, want)
+	}
+}
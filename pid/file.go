@@ -5,6 +5,8 @@
 package pid
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -20,18 +22,23 @@ import (
 const (
 	MaxSignalRetries = 10
 	SignalRetryDelay = 250 * time.Millisecond
+
+	// DefaultReleasePollInterval is the default interval at which
+	// [File.WaitForRelease] checks if the owner of the pidfile is still running.
+	DefaultReleasePollInterval = 500 * time.Millisecond
 )
 
 // File represents a pidfile for a given application.
 type File struct {
 	firstPID int
 
-	mu          sync.RWMutex
-	appID       string
-	logger      *slog.Logger
-	dir         string
-	signal      syscall.Signal
-	onSecondary func([]string)
+	mu           sync.RWMutex
+	appID        string
+	logger       *slog.Logger
+	dir          string
+	signal       syscall.Signal
+	onSecondary  func([]string)
+	pollInterval time.Duration
 }
 
 // New creates a pidfile instance based on the provided application ID.
@@ -45,11 +52,12 @@ func New(appID string) *File {
 		dir = cwd
 	}
 	return &File{
-		appID:       appID,
-		logger:      slog.New(slog.DiscardHandler),
-		dir:         dir,
-		signal:      syscall.SIGTERM,
-		onSecondary: nil,
+		appID:        appID,
+		logger:       slog.New(slog.DiscardHandler),
+		dir:          dir,
+		signal:       syscall.SIGTERM,
+		onSecondary:  nil,
+		pollInterval: DefaultReleasePollInterval,
 	}
 }
 
@@ -100,6 +108,18 @@ func (pf *File) WithSecondary(fn func(args []string)) *File {
 	return pf
 }
 
+// WithPollInterval sets the interval at which [File.WaitForRelease] checks if the
+// owner of the pidfile is still running. Defaults to [DefaultReleasePollInterval].
+func (pf *File) WithPollInterval(interval time.Duration) *File {
+	if interval <= 0 {
+		interval = DefaultReleasePollInterval
+	}
+	pf.mu.Lock()
+	pf.pollInterval = interval
+	pf.mu.Unlock()
+	return pf
+}
+
 // FirstPID returns the PID of the first process. Should not be called until after
 // [File.Create] has been called.
 func (pf *File) FirstPID() int {
@@ -256,3 +276,64 @@ func (pf *File) Remove() error {
 	}
 	return nil
 }
+
+// WaitForRelease blocks until the process which owns the pidfile (see
+// [File.FirstPID]) is no longer running, or the pidfile has been removed, polling
+// at the interval configured with [File.WithPollInterval]. This allows a secondary
+// process to promote itself to primary (e.g. by calling [File.Create] again) once
+// the primary exits. If [File.Create] hasn't been called, the PID is read from the
+// pidfile. Returns the context error if the context is cancelled first, and an
+// error if the current process is the owner.
+func (pf *File) WaitForRelease(ctx context.Context) error {
+	pid := pf.FirstPID()
+	if pid == os.Getpid() {
+		return errors.New("pidfile is owned by the current process")
+	}
+
+	pf.mu.RLock()
+	interval := pf.pollInterval
+	pf.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := pf.released(pid)
+		if err != nil || released {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// released reports whether the pidfile has been removed, or the process with the
+// provided PID is no longer running. If pid is 0, the PID is read from the pidfile.
+func (pf *File) released(pid int) (bool, error) {
+	if pid == 0 {
+		data, err := os.ReadFile(pf.path())
+		if err != nil {
+			if os.IsNotExist(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to read pidfile: %w", err)
+		}
+
+		pid, err = strconv.Atoi(string(data))
+		if err != nil {
+			return false, fmt.Errorf("failed to parse pidfile: %w", err)
+		}
+	} else if _, err := os.Stat(pf.path()); os.IsNotExist(err) {
+		return true, nil
+	}
+
+	if lookupProcess(pid) == nil {
+		pf.log().Debug("pidfile owner is no longer running", "path", pf.path(), "pid", pid)
+		return true, nil
+	}
+	return false, nil
+}
@@ -13,6 +13,6 @@
 //   - [ResultContextGroup] extends [ContextGroup] for tasks that return both a result
 //     and an error, preserving submission order.
 //   - [Map] is a type-safe generic wrapper around [sync.Map].
-//   - [Semaphore] limits concurrent work to a fixed number of logical slots.
+//   - [Semaphore] limits concurrent work to a resizable number of logical slots.
 //   - [WeightedSemaphore] limits concurrent work by tracking weighted resource usage.
 package conc
@@ -4,16 +4,21 @@
 
 package conc
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
-// Semaphore limits concurrent work to a fixed number of logical slots using a
-// counting semaphore. See [NewSemaphore] for more details.
+// Semaphore limits concurrent work to a number of logical slots using a counting
+// semaphore. The number of slots can be changed at runtime with
+// [Semaphore.Resize]. See [NewSemaphore] for more details.
 type Semaphore struct {
 	mu sync.Mutex
 
-	tokens chan struct{}
-	inUse  int
-	idle   *sync.Cond
+	size    int
+	inUse   int
+	changed chan struct{} // Closed (and replaced) when a slot may have become free.
+	idle    *sync.Cond
 }
 
 // NewSemaphore returns a semaphore that allows at most n concurrent holders.
@@ -23,33 +28,95 @@ func NewSemaphore(n int) *Semaphore {
 		panic("conc: NewSemaphore: n must be at least 1")
 	}
 	s := &Semaphore{
-		tokens: make(chan struct{}, n),
-	}
-	for range n {
-		s.tokens <- struct{}{}
+		size:    n,
+		changed: make(chan struct{}),
 	}
 	s.idle = sync.NewCond(&s.mu)
 	return s
 }
 
-// Alloc blocks until it can take one slot from the pool.
+// Alloc blocks until it can take one slot from the pool. See also
+// [Semaphore.AllocContext].
 func (s *Semaphore) Alloc() {
-	<-s.tokens
-	s.mu.Lock()
-	s.inUse++
-	s.mu.Unlock()
+	_ = s.AllocContext(context.Background())
+}
+
+// AllocContext blocks until it can take one slot from the pool or ctx is done.
+// On success, returns nil. On failure, returns ctx.Err() and leaves the semaphore
+// unchanged.
+func (s *Semaphore) AllocContext(ctx context.Context) error {
+	done := ctx.Done()
+	for {
+		s.mu.Lock()
+		select {
+		case <-done:
+			s.mu.Unlock()
+			return ctx.Err()
+		default:
+		}
+
+		if s.inUse < s.size {
+			s.inUse++
+			s.mu.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-done:
+			return ctx.Err()
+		case <-changed:
+		}
+	}
 }
 
 // Free returns one slot to the pool. A caller must not invoke Free more times than
 // they have successfully taken slots via [Semaphore.Alloc].
 func (s *Semaphore) Free() {
 	s.mu.Lock()
+	if s.inUse == 0 {
+		s.mu.Unlock()
+		panic("conc: Semaphore: freed more than held")
+	}
 	s.inUse--
 	if s.inUse == 0 {
 		s.idle.Broadcast()
 	}
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+// Resize changes the number of slots to n. It panics if n is less than 1.
+//
+// Growing the semaphore immediately unblocks waiters for the additional slots.
+// Shrinking the semaphore below the number of slots currently in use does not
+// affect existing holders; instead, new callers block until enough slots have
+// been freed to bring usage below the new size.
+func (s *Semaphore) Resize(n int) {
+	if n < 1 {
+		panic("conc: Semaphore.Resize: n must be at least 1")
+	}
+	s.mu.Lock()
+	grow := n > s.size
+	s.size = n
+	if grow {
+		s.notifyLocked()
+	}
 	s.mu.Unlock()
-	s.tokens <- struct{}{}
+}
+
+// Size returns the current number of slots.
+func (s *Semaphore) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// notifyLocked wakes all callers waiting for a slot. Caller must hold [Semaphore.mu].
+func (s *Semaphore) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
 }
 
 // Wait blocks until every slot has been returned (inUse is zero).
@@ -64,6 +131,7 @@ func (s *Semaphore) Wait() {
 // Go runs f in a new goroutine after acquiring a slot from the semaphore. When f
 // returns or terminates with [runtime.Goexit], the slot is released. If f panics,
 // the panic value is re-raised and Free is not called, matching [sync.WaitGroup.Go].
+// See also [Semaphore.GoContext].
 //
 // In the terminology of [the Go memory model], the return from f
 // "synchronizes before" the return of any Wait call that it unblocks.
@@ -71,6 +139,22 @@ func (s *Semaphore) Wait() {
 // [the Go memory model]: https://go.dev/ref/mem
 func (s *Semaphore) Go(f func()) {
 	s.Alloc()
+	s.run(f)
+}
+
+// GoContext is the same as [Semaphore.Go], however it returns ctx.Err() without
+// running f if ctx is done before a slot could be acquired.
+func (s *Semaphore) GoContext(ctx context.Context, f func()) error {
+	if err := s.AllocContext(ctx); err != nil {
+		return err
+	}
+	s.run(f)
+	return nil
+}
+
+// run runs f in a new goroutine, releasing the already-acquired slot when f
+// returns.
+func (s *Semaphore) run(f func()) {
 	go func() {
 		defer func() {
 			if x := recover(); x != nil {
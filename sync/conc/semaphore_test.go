@@ -5,6 +5,8 @@
 package conc
 
 import (
+	"context"
+	"errors"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -152,3 +154,169 @@ func TestSemaphore_limitsConcurrentHolders(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestSemaphore_Free_panicsOnOverrelease(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic when freeing more than held")
+		}
+	}()
+	NewSemaphore(1).Free()
+}
+
+func TestSemaphore_Resize_grow(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphore(1)
+	s.Alloc()
+
+	acquired := make(chan struct{})
+	go func() {
+		s.Alloc()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Alloc should block while the semaphore is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	s.Resize(2)
+	if s.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", s.Size())
+	}
+
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Alloc should unblock after growing")
+	}
+	s.Free()
+	s.Free()
+	s.Wait()
+}
+
+func TestSemaphore_Resize_shrinkBelowInUse(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphore(3)
+	for range 3 {
+		s.Alloc()
+	}
+	s.Resize(1)
+
+	acquired := make(chan struct{})
+	go func() {
+		s.Alloc()
+		close(acquired)
+	}()
+
+	// 3 in use, size 1: the waiter needs usage to drop to 0 first.
+	for range 2 {
+		s.Free()
+		select {
+		case <-acquired:
+			t.Fatal("Alloc should block until usage drops below the new size")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+
+	s.Free()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Alloc should unblock once usage drops below the new size")
+	}
+	s.Free()
+	s.Wait()
+}
+
+func TestSemaphore_Resize_concurrent(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphore(4)
+
+	var limit atomic.Int32
+	limit.Store(8) // Upper bound of any size used below.
+
+	var cur atomic.Int32
+	var wg sync.WaitGroup
+	for range 64 {
+		wg.Go(func() {
+			s.Go(func() {
+				if n := cur.Add(1); n > limit.Load() {
+					t.Errorf("holders %d exceed max %d", n, limit.Load())
+				}
+				time.Sleep(time.Millisecond)
+				cur.Add(-1)
+			})
+		})
+	}
+
+	for i := range 32 {
+		s.Resize(i%8 + 1)
+		runtime.Gosched()
+	}
+	s.Resize(8)
+
+	wg.Wait()
+	s.Wait()
+	if cur.Load() != 0 {
+		t.Fatalf("holders = %d after Wait, want 0", cur.Load())
+	}
+}
+
+func TestSemaphore_AllocContext_canceled(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphore(1)
+	s.Alloc()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- s.AllocContext(ctx) }()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AllocContext did not return after cancellation")
+	}
+
+	// The canceled waiter must not have taken a slot.
+	s.Free()
+	s.Wait()
+	if err := s.AllocContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	s.Free()
+}
+
+func TestSemaphore_GoContext(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphore(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.GoContext(ctx, func() { t.Error("f should not run") }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	var ran atomic.Bool
+	if err := s.GoContext(context.Background(), func() { ran.Store(true) }); err != nil {
+		t.Fatal(err)
+	}
+	s.Wait()
+	if !ran.Load() {
+		t.Fatal("f did not run")
+	}
+}
@@ -29,3 +29,21 @@ func LoggerFromContext(ctx context.Context) *slog.Logger {
 func withLogger(ctx context.Context, l *slog.Logger) context.Context {
 	return context.WithValue(ctx, contextKeyLogger, l)
 }
+
+// CancelCause returns why the context of a job was canceled, or nil if it hasn't
+// been canceled (see [context.Cause]). For jobs invoked through [Run] (and
+// variants), the cause wraps one of the following, which can be checked with
+// [errors.Is]:
+//   - [ErrShutdownSignal]: a termination signal was received.
+//   - [ErrJobFailed]: another job returned an error.
+//   - [ErrJobTimeout]: the run exceeded the timeout of the [Cron] (see
+//     [Cron.WithTimeout]).
+//
+// Otherwise, the cause of the parent context is returned (e.g.
+// [context.Canceled], or [context.DeadlineExceeded]).
+func CancelCause(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
@@ -0,0 +1,396 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Describe parses the provided spec (see [Parse]) and returns a human-readable
+// description of it, e.g. "0 5 * * 1" is described as "At 5:00 AM, only on
+// Monday". See [SpecSchedule.Describe] for more details.
+func Describe(spec string) (string, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return "", err
+	}
+
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		return s.Describe(), nil
+	case FrequencySchedule:
+		return s.Describe(), nil
+	default:
+		return s.String(), nil
+	}
+}
+
+// Describe returns a human-readable description of the schedule, e.g. "Every 5
+// minutes".
+func (s FrequencySchedule) Describe() string {
+	return "Every " + describeDuration(s.Delay)
+}
+
+// Describe returns a human-readable description of the schedule, e.g. "Every
+// hour, aligned to 2024-01-01T00:00:00Z".
+func (s AnchoredSchedule) Describe() string {
+	return "Every " + describeDuration(s.Interval) + ", aligned to " + s.Anchor.Format(time.RFC3339)
+}
+
+// Describe returns a human-readable description of the schedule, e.g. "0 5 * * 1"
+// is described as "At 5:00 AM, only on Monday", and "*/15 9-17 * * mon-fri" as
+// "Every 15 minutes, between 9:00 AM and 5:59 PM, Monday through Friday".
+//
+// The description is built from the parsed fields, not the source spec, so
+// equivalent specs (e.g. "0-59" and "*", or "*/20" and "0,20,40") are described
+// the same way. Ranges and steps are recognized from the values they expand to.
+// If the schedule uses a location other than [time.Local], the name of the
+// location is appended.
+func (s *SpecSchedule) Describe() string {
+	var b strings.Builder
+	b.WriteString(describeTime(decodeField(s.Minute, minutes), decodeField(s.Hour, hours)))
+
+	dayOfMonth := decodeField(s.DayOfMonth, dom)
+	dayOfWeek := decodeField(s.DayOfWeek, dow)
+
+	switch {
+	case !dayOfMonth.all && !dayOfWeek.all:
+		// When both are restricted, either matching is enough (see dayMatches).
+		b.WriteString(", " + describeDayOfMonth(dayOfMonth) + " or " + describeDayOfWeek(dayOfWeek))
+	case !dayOfMonth.all:
+		b.WriteString(", " + describeDayOfMonth(dayOfMonth))
+	case !dayOfWeek.all:
+		desc := describeDayOfWeek(dayOfWeek)
+		if strings.HasPrefix(desc, "on ") {
+			desc = "only " + desc
+		}
+		b.WriteString(", " + desc)
+	}
+
+	if month := decodeField(s.Month, months); !month.all {
+		b.WriteString(", " + describeMonth(month))
+	}
+
+	if s.Location != nil && s.Location != time.Local {
+		b.WriteString(" (" + s.Location.String() + ")")
+	}
+
+	return b.String()
+}
+
+// segment is a single value (start == end), a range (step == 1), or a stepped
+// range of values within a field.
+type segment struct {
+	start, end, step uint
+}
+
+func (s segment) single() bool { return s.start == s.end }
+
+// field is a decoded field of a [SpecSchedule].
+type field struct {
+	bounds   bounds
+	all      bool // Matches every value within the bounds.
+	segments []segment
+}
+
+// single returns true if the field is a single value.
+func (f field) single() bool {
+	return !f.all && len(f.segments) == 1 && f.segments[0].single()
+}
+
+// list returns true if the field is a list of one or more single values.
+func (f field) list() bool {
+	if f.all {
+		return false
+	}
+	for _, s := range f.segments {
+		if !s.single() {
+			return false
+		}
+	}
+	return true
+}
+
+// stepped returns the stepped segment, if the field is a single stepped range.
+func (f field) stepped() (segment, bool) {
+	if f.all || len(f.segments) != 1 || f.segments[0].step < 2 {
+		return segment{}, false
+	}
+	return f.segments[0], true
+}
+
+// open returns true if the stepped segment continues until the end of the bounds,
+// i.e. it was likely specified as "start/step".
+func (f field) open(s segment) bool {
+	return s.end+s.step > f.bounds.max
+}
+
+// decodeField decodes the bits of a field into segments. Values that are evenly
+// spaced (3 or more) are decoded as a stepped range, and runs of 3 or more
+// consecutive values as a range.
+func decodeField(bits uint64, r bounds) field {
+	f := field{bounds: r}
+
+	var values []uint
+	for i := r.min; i <= r.max; i++ {
+		if bits&(1<<i) > 0 {
+			values = append(values, i)
+		}
+	}
+
+	if bits&starBit > 0 || len(values) == int(r.max-r.min+1) { //nolint:gosec
+		f.all = true
+		return f
+	}
+
+	if len(values) >= 3 {
+		step := values[1] - values[0]
+		even := step > 1
+		for i := 2; even && i < len(values); i++ {
+			even = values[i]-values[i-1] == step
+		}
+		if even {
+			f.segments = []segment{{start: values[0], end: values[len(values)-1], step: step}}
+			return f
+		}
+	}
+
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			f.segments = append(f.segments, segment{start: values[i], end: values[j], step: 1})
+		} else {
+			for k := i; k <= j; k++ {
+				f.segments = append(f.segments, segment{start: values[k], end: values[k], step: 1})
+			}
+		}
+		i = j + 1
+	}
+
+	return f
+}
+
+// describeItems describes each segment of a (non-stepped) field with the provided
+// label function, joined as a list, e.g. "1, 3 through 5 and 7".
+func describeItems(f field, label func(uint) string) string {
+	items := make([]string, 0, len(f.segments))
+	for _, s := range f.segments {
+		if s.single() {
+			items = append(items, label(s.start))
+			continue
+		}
+		items = append(items, label(s.start)+" through "+label(s.end))
+	}
+	return joinList(items)
+}
+
+// describeTime describes the minute and hour fields.
+func describeTime(minute, hour field) string {
+	// Specific times of the day, e.g. "At 5:00 AM and 5:30 PM".
+	if minute.list() && hour.list() {
+		var times []string
+		for _, h := range hour.segments {
+			for _, m := range minute.segments {
+				times = append(times, clock(h.start, m.start))
+			}
+		}
+		return "At " + joinList(times)
+	}
+
+	var desc string
+	step, stepped := minute.stepped()
+	hourStep, hourStepped := hour.stepped()
+
+	switch {
+	case minute.all:
+		desc = "Every minute"
+	case stepped:
+		desc = "Every " + strconv.FormatUint(uint64(step.step), 10) + " minutes"
+		switch {
+		case !minute.open(step):
+			desc += ", minutes " + strconv.FormatUint(uint64(step.start), 10) + " through " +
+				strconv.FormatUint(uint64(step.end), 10) + " past the hour"
+		case step.start != minute.bounds.min:
+			desc += ", starting at " + plural(step.start, "minute") + " past the hour"
+		}
+	case minute.single() && minute.segments[0].start == 0 && (hour.all || (hourStepped && hour.open(hourStep))):
+		// "Every hour" or "Every 2 hours" (described below).
+		if hour.all {
+			return "Every hour"
+		}
+	case len(minute.segments) == 1:
+		seg := minute.segments[0]
+		if seg.single() {
+			desc = "At " + plural(seg.start, "minute") + " past the hour"
+		} else {
+			desc = "Every minute between " + strconv.FormatUint(uint64(seg.start), 10) + " and " +
+				strconv.FormatUint(uint64(seg.end), 10) + " minutes past the hour"
+		}
+	default:
+		desc = "At " + describeItems(minute, func(v uint) string {
+			return strconv.FormatUint(uint64(v), 10)
+		}) + " minutes past the hour"
+	}
+
+	if hour.all {
+		return desc
+	}
+
+	var hdesc string
+	switch {
+	case hourStepped:
+		hdesc = "every " + strconv.FormatUint(uint64(hourStep.step), 10) + " hours"
+		switch {
+		case !hour.open(hourStep):
+			hdesc += " from " + clock(hourStep.start, 0) + " through " + clock(hourStep.end, 0)
+		case hourStep.start != hour.bounds.min:
+			hdesc += ", starting at " + clock(hourStep.start, 0)
+		}
+	case len(hour.segments) == 1:
+		hdesc = "between " + clock(hour.segments[0].start, 0) + " and " + clock(hour.segments[0].end, 59)
+	default:
+		hdesc = "during the hours of " + describeItems(hour, func(v uint) string {
+			return strings.Replace(clock(v, 0), ":00", "", 1)
+		})
+	}
+
+	if desc == "" {
+		return strings.ToUpper(hdesc[:1]) + hdesc[1:]
+	}
+	return desc + ", " + hdesc
+}
+
+// describeDayOfMonth describes the day of month field, when it is restricted.
+func describeDayOfMonth(f field) string {
+	if s, ok := f.stepped(); ok {
+		desc := "every " + strconv.FormatUint(uint64(s.step), 10) + " days"
+		switch {
+		case !f.open(s):
+			desc += " from day " + strconv.FormatUint(uint64(s.start), 10) + " through day " +
+				strconv.FormatUint(uint64(s.end), 10) + " of the month"
+		case s.start != f.bounds.min:
+			desc += ", starting on day " + strconv.FormatUint(uint64(s.start), 10) + " of the month"
+		}
+		return desc
+	}
+
+	if len(f.segments) == 1 && !f.segments[0].single() {
+		return "between day " + strconv.FormatUint(uint64(f.segments[0].start), 10) + " and " +
+			strconv.FormatUint(uint64(f.segments[0].end), 10) + " of the month"
+	}
+
+	return "on day " + describeItems(f, func(v uint) string {
+		return strconv.FormatUint(uint64(v), 10)
+	}) + " of the month"
+}
+
+// describeDayOfWeek describes the day of week field, when it is restricted.
+func describeDayOfWeek(f field) string {
+	if s, ok := f.stepped(); ok {
+		desc := "every " + strconv.FormatUint(uint64(s.step), 10) + " days of the week"
+		switch {
+		case !f.open(s):
+			desc += " from " + time.Weekday(s.start).String() + " through " + time.Weekday(s.end).String() //nolint:gosec
+		case s.start != f.bounds.min:
+			desc += ", starting on " + time.Weekday(s.start).String() //nolint:gosec
+		}
+		return desc
+	}
+
+	if len(f.segments) == 1 && !f.segments[0].single() {
+		return time.Weekday(f.segments[0].start).String() + " through " + time.Weekday(f.segments[0].end).String() //nolint:gosec
+	}
+
+	return "on " + describeItems(f, func(v uint) string {
+		return time.Weekday(v).String() //nolint:gosec
+	})
+}
+
+// describeMonth describes the month field, when it is restricted.
+func describeMonth(f field) string {
+	if s, ok := f.stepped(); ok {
+		desc := "every " + strconv.FormatUint(uint64(s.step), 10) + " months"
+		switch {
+		case !f.open(s):
+			desc += " from " + time.Month(s.start).String() + " through " + time.Month(s.end).String() //nolint:gosec
+		case s.start != f.bounds.min:
+			desc += ", starting in " + time.Month(s.start).String() //nolint:gosec
+		}
+		return desc
+	}
+
+	if len(f.segments) == 1 && !f.segments[0].single() {
+		return time.Month(f.segments[0].start).String() + " through " + time.Month(f.segments[0].end).String() //nolint:gosec
+	}
+
+	return "only in " + describeItems(f, func(v uint) string {
+		return time.Month(v).String() //nolint:gosec
+	})
+}
+
+// clock formats the hour and minute as a 12-hour clock time, e.g. "5:00 PM".
+func clock(hour, minute uint) string {
+	period := "AM"
+	if hour >= 12 {
+		period = "PM"
+	}
+	hour %= 12
+	if hour == 0 {
+		hour = 12
+	}
+	return fmt.Sprintf("%d:%02d %s", hour, minute, period)
+}
+
+// plural formats n with the provided unit, pluralized if needed, e.g. "1 minute"
+// or "5 minutes".
+func plural(n uint, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.FormatUint(uint64(n), 10) + " " + unit + "s"
+}
+
+// joinList joins the items as an English list, e.g. "a, b and c".
+func joinList(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// describeDuration describes a duration in hours, minutes and seconds, e.g.
+// "hour", "5 minutes" or "1 hour and 30 minutes".
+func describeDuration(d time.Duration) string {
+	var parts []string
+	for _, unit := range []struct {
+		size time.Duration
+		name string
+	}{
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	} {
+		if n := d / unit.size; n > 0 {
+			parts = append(parts, plural(uint(n), unit.name)) //nolint:gosec
+			d -= n * unit.size
+		}
+	}
+
+	switch {
+	case len(parts) == 0:
+		return "second"
+	case len(parts) == 1 && strings.HasPrefix(parts[0], "1 "):
+		return parts[0][2:]
+	default:
+		return joinList(parts)
+	}
+}
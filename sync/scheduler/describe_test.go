@@ -0,0 +1,85 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{spec: "* * * * *", want: "Every minute"},
+		{spec: "0-59 * * * *", want: "Every minute"},
+		{spec: "*/15 * * * *", want: "Every 15 minutes"},
+		{spec: "0,20,40 * * * *", want: "Every 20 minutes"},
+		{spec: "5/15 * * * *", want: "Every 15 minutes, starting at 5 minutes past the hour"},
+		{spec: "0-30/10 * * * *", want: "Every 10 minutes, minutes 0 through 30 past the hour"},
+		{spec: "10-20 * * * *", want: "Every minute between 10 and 20 minutes past the hour"},
+		{spec: "1,5-9 * * * *", want: "At 1 and 5 through 9 minutes past the hour"},
+		{spec: "30 * * * *", want: "At 30 minutes past the hour"},
+		{spec: "0 * * * *", want: "Every hour"},
+		{spec: "@hourly", want: "Every hour"},
+		{spec: "0 */2 * * *", want: "Every 2 hours"},
+		{spec: "0 1/6 * * *", want: "Every 6 hours, starting at 1:00 AM"},
+		{spec: "0 5 * * 1", want: "At 5:00 AM, only on Monday"},
+		{spec: "@daily", want: "At 12:00 AM"},
+		{spec: "30 0,12 * * *", want: "At 12:30 AM and 12:30 PM"},
+		{spec: "0,30 9 * * *", want: "At 9:00 AM and 9:30 AM"},
+		{spec: "*/15 9-17 * * mon-fri", want: "Every 15 minutes, between 9:00 AM and 5:59 PM, Monday through Friday"},
+		{spec: "* 9 * * *", want: "Every minute, between 9:00 AM and 9:59 AM"},
+		{spec: "0 9-11,14 * * *", want: "At 0 minutes past the hour, during the hours of 9 AM through 11 AM and 2 PM"},
+		{spec: "0 8 1 * *", want: "At 8:00 AM, on day 1 of the month"},
+		{spec: "0 8 1,15 * *", want: "At 8:00 AM, on day 1 and 15 of the month"},
+		{spec: "0 8 1-7 * *", want: "At 8:00 AM, between day 1 and 7 of the month"},
+		{spec: "0 8 */2 * *", want: "At 8:00 AM, every 2 days"},
+		{spec: "0 8 1 * 1", want: "At 8:00 AM, on day 1 of the month or on Monday"},
+		{spec: "0 8 * * sat,sun", want: "At 8:00 AM, only on Sunday and Saturday"},
+		{spec: "0 0 1 1 *", want: "At 12:00 AM, on day 1 of the month, only in January"},
+		{spec: "@yearly", want: "At 12:00 AM, on day 1 of the month, only in January"},
+		{spec: "0 0 * jan-mar *", want: "At 12:00 AM, January through March"},
+		{spec: "0 0 1 */3 *", want: "At 12:00 AM, on day 1 of the month, every 3 months"},
+		{spec: "CRON_TZ=UTC 0 5 * * *", want: "At 5:00 AM (UTC)"},
+		{spec: "@every 5m", want: "Every 5 minutes"},
+		{spec: "@every 1h30m", want: "Every 1 hour and 30 minutes"},
+		{spec: "@every 1h", want: "Every hour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Describe(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Describe(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribe_invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Describe("61 * * * *"); err == nil {
+		t.Fatal("expected error for invalid spec")
+	}
+}
+
+func TestAnchoredSchedule_Describe(t *testing.T) {
+	t.Parallel()
+
+	s := EveryAnchored(time.Hour, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got, want := s.Describe(), "Every hour, aligned to 2024-01-01T00:00:00Z"; got != want {
+		t.Fatalf("Describe() = %q, want %q", got, want)
+	}
+}
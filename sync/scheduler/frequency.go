@@ -40,3 +40,40 @@ func Every(dur time.Duration) FrequencySchedule {
 func (s FrequencySchedule) Next(t time.Time) time.Time {
 	return t.Add(s.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 }
+
+// AnchoredSchedule represents a recurring duty cycle which is aligned to a fixed
+// anchor time, e.g. "Every hour, on the hour". Unlike [FrequencySchedule], the
+// activation times don't depend on when the schedule was started, so they are
+// stable across restarts.
+type AnchoredSchedule struct {
+	Interval time.Duration
+	Anchor   time.Time
+}
+
+func (s AnchoredSchedule) String() string {
+	return fmt.Sprintf("@every %s from %s", s.Interval.Round(time.Second), s.Anchor.Format(time.RFC3339))
+}
+
+// EveryAnchored returns a Schedule that activates once every interval, at
+// multiples of the interval relative to the anchor (the anchor can be in the
+// past or future). For example, an interval of 1 hour with an anchor of midnight
+// UTC activates on the hour, regardless of when the job was started. Intervals
+// of less than a second are not supported (will round up to 1 second). Any
+// fields less than a Second are truncated.
+func EveryAnchored(interval time.Duration, anchor time.Time) AnchoredSchedule {
+	return AnchoredSchedule{
+		Interval: Every(interval).Delay,
+		Anchor:   anchor.Truncate(time.Second),
+	}
+}
+
+// Next returns the next time this should be run, which is the first multiple of
+// the interval from the anchor, later than the given time.
+func (s AnchoredSchedule) Next(t time.Time) time.Time {
+	diff := t.Sub(s.Anchor)
+	n := diff / s.Interval
+	if diff < 0 && diff%s.Interval != 0 {
+		n-- // Round towards negative infinity, not zero.
+	}
+	return s.Anchor.Add((n + 1) * s.Interval).In(t.Location())
+}
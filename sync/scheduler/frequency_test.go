@@ -57,3 +57,86 @@ func TestFrequencySchedule_Next(t *testing.T) {
 		t.Fatalf("Next = %v, want %v", next, want)
 	}
 }
+
+func TestAnchoredSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	anchor := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		in       time.Time
+		want     time.Time
+	}{
+		{
+			name:     "on-the-hour",
+			interval: time.Hour,
+			in:       time.Date(2024, 3, 15, 12, 34, 56, 789, time.UTC),
+			want:     time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exactly-on-multiple",
+			interval: time.Hour,
+			in:       time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before-anchor",
+			interval: 90 * time.Minute,
+			in:       time.Date(2024, 3, 14, 23, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before-anchor-multiple-intervals",
+			interval: 90 * time.Minute,
+			in:       time.Date(2024, 3, 14, 20, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 3, 14, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "long-after-anchor",
+			interval: 15 * time.Minute,
+			in:       time.Date(2025, 1, 1, 10, 7, 0, 0, time.UTC),
+			want:     time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := EveryAnchored(tt.interval, anchor).Next(tt.in); !got.Equal(tt.want) {
+				t.Fatalf("Next(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnchoredSchedule_restartIndependent(t *testing.T) {
+	t.Parallel()
+
+	s := EveryAnchored(time.Hour, time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC))
+	a := s.Next(time.Date(2024, 3, 15, 12, 1, 0, 0, time.UTC))
+	b := s.Next(time.Date(2024, 3, 15, 12, 29, 59, 0, time.UTC))
+	if !a.Equal(b) || a.Minute() != 30 {
+		t.Fatalf("Next = %v and %v, want both at 12:30", a, b)
+	}
+}
+
+func TestAnchoredSchedule_truncatesAnchor(t *testing.T) {
+	t.Parallel()
+
+	s := EveryAnchored(time.Hour, time.Date(2024, 1, 1, 0, 30, 0, 999_999_999, time.UTC))
+	want := time.Date(2024, 3, 15, 12, 30, 0, 0, time.UTC)
+	if got := s.Next(time.Date(2024, 3, 15, 12, 1, 0, 0, time.UTC)); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestAnchoredSchedule_String(t *testing.T) {
+	t.Parallel()
+
+	s := EveryAnchored(1500*time.Millisecond, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	if got := s.String(); got != "@every 1s from 2024-03-15T00:00:00Z" {
+		t.Fatalf("String() = %q", got)
+	}
+}
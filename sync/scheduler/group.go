@@ -0,0 +1,97 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lrstanley/x/sync/conc"
+)
+
+var _ Job = (*JobGroup)(nil)
+
+// JobGroup is a composite [Job], which invokes a set of jobs concurrently. See
+// [Group] for more details.
+type JobGroup struct {
+	jobs          []Job
+	sem           *conc.Semaphore
+	cancelOnError bool
+}
+
+// Group returns a [JobGroup], which invokes all of the provided jobs concurrently
+// each time it is invoked, and waits for them to return. This allows scheduling
+// related jobs under a single [Cron], e.g:
+//
+//	NewCron("nightly", Group(cleanup, report, backup)).WithSchedule("@daily")
+//
+// All errors returned by the jobs are joined (see [errors.Join]). By default, a
+// failing job does not affect the others, see [JobGroup.WithCancelOnError] to
+// change this.
+func Group(jobs ...Job) *JobGroup {
+	return &JobGroup{jobs: jobs}
+}
+
+// WithSemaphore bounds the number of jobs in the group which can run at once,
+// using the provided semaphore. The semaphore can be shared between groups (or
+// anything else), to bound concurrency across all of them. Jobs which are still
+// waiting for a slot when the context is canceled are never invoked, and are
+// reported as a single error (included in the error returned by
+// [JobGroup.Invoke]) wrapping the context error.
+func (g *JobGroup) WithSemaphore(sem *conc.Semaphore) *JobGroup {
+	g.sem = sem
+	return g
+}
+
+// WithCancelOnError sets whether the context passed to the jobs is canceled when
+// any job in the group returns an error, so the remaining jobs can stop early
+// (assuming they listen to the provided context). Jobs which haven't started
+// yet (e.g. waiting on the semaphore, see [JobGroup.WithSemaphore]) are never
+// invoked. Defaults to false, where all jobs run to completion, regardless of
+// failures.
+func (g *JobGroup) WithCancelOnError(enabled bool) *JobGroup {
+	g.cancelOnError = enabled
+	return g
+}
+
+// Invoke invokes all jobs in the group concurrently, and waits for them to
+// return. Returns all job errors, joined.
+func (g *JobGroup) Invoke(ctx context.Context) error {
+	if len(g.jobs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(g.jobs))
+
+	for i, job := range g.jobs {
+		if g.sem != nil {
+			if err := g.sem.AllocContext(ctx); err != nil {
+				// Job i and later never started, so nothing else writes to errs[i].
+				errs[i] = fmt.Errorf("%d of %d jobs not started: %w", len(g.jobs)-i, len(g.jobs), err)
+				break
+			}
+		}
+
+		wg.Go(func() {
+			if g.sem != nil {
+				defer g.sem.Free()
+			}
+
+			errs[i] = job.Invoke(ctx)
+			if errs[i] != nil && g.cancelOnError {
+				cancel()
+			}
+		})
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
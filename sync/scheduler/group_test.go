@@ -0,0 +1,136 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/lrstanley/x/sync/conc"
+)
+
+func TestGroup_joinedErrors(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		errA := errors.New("a")
+		errB := errors.New("b")
+
+		var runs atomic.Int32
+		err := Group(
+			JobFunc(func(context.Context) error { runs.Add(1); return errA }),
+			JobFunc(func(context.Context) error { runs.Add(1); return nil }),
+			JobFunc(func(ctx context.Context) error {
+				runs.Add(1)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(time.Second):
+					return errB
+				}
+			}),
+		).Invoke(t.Context())
+
+		if !errors.Is(err, errA) || !errors.Is(err, errB) {
+			t.Fatalf("err = %v, want joined %v and %v", err, errA, errB)
+		}
+		if runs.Load() != 3 {
+			t.Fatalf("runs = %d, want 3", runs.Load())
+		}
+	})
+}
+
+func TestGroup_cancelOnError(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		want := errors.New("failed")
+
+		err := Group(
+			JobFunc(func(context.Context) error { return want }),
+			JobFunc(func(ctx context.Context) error {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(time.Hour):
+					return errors.New("not canceled")
+				}
+			}),
+		).WithCancelOnError(true).Invoke(t.Context())
+
+		if !errors.Is(err, want) {
+			t.Fatalf("err = %v, want %v", err, want)
+		}
+		if err.Error() != want.Error() {
+			t.Fatalf("err = %v, want only %v", err, want)
+		}
+	})
+}
+
+func TestGroup_semaphore(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var running, peak atomic.Int32
+
+		job := JobFunc(func(context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Second)
+			return nil
+		})
+
+		start := time.Now()
+		err := Group(job, job, job, job).
+			WithSemaphore(conc.NewSemaphore(2)).
+			Invoke(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if peak.Load() != 2 {
+			t.Fatalf("peak = %d, want 2", peak.Load())
+		}
+		if elapsed := time.Since(start); elapsed != 2*time.Second {
+			t.Fatalf("elapsed = %v, want 2s", elapsed)
+		}
+	})
+}
+
+func TestGroup_semaphoreCanceled(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		var runs atomic.Int32
+		job := JobFunc(func(context.Context) error {
+			runs.Add(1)
+			cancel()
+			return nil
+		})
+
+		err := Group(job, job, job).
+			WithSemaphore(conc.NewSemaphore(1)).
+			Invoke(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want %v", err, context.Canceled)
+		}
+		if want := "2 of 3 jobs not started: context canceled"; err.Error() != want {
+			t.Fatalf("err = %q, want %q", err.Error(), want)
+		}
+		if runs.Load() != 1 {
+			t.Fatalf("runs = %d, want 1", runs.Load())
+		}
+	})
+}
+
+func TestGroup_empty(t *testing.T) {
+	if err := Group().Invoke(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"math/rand/v2"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -40,29 +42,81 @@ func (f JobLoggerFunc) Invoke(ctx context.Context) error {
 	return f(ctx, LoggerFromContext(ctx))
 }
 
+// ErrShutdownTimeout is returned by [RunWithShutdown] when jobs fail to return
+// within the drain timeout after cancellation.
+var ErrShutdownTimeout = errors.New("timed out waiting for jobs to finish during shutdown")
+
+// ErrJobTimeout is returned by [Cron] when a run of the underlying job exceeds
+// the timeout set via [Cron.WithTimeout].
+var ErrJobTimeout = errors.New("cron job timed out")
+
+// ErrShutdownSignal is the cancellation cause (see [CancelCause]) of jobs invoked
+// through [Run] (and variants), when a termination signal was received. The
+// cause includes the signal.
+var ErrShutdownSignal = errors.New("shutdown signal received")
+
+// ErrJobFailed is the cancellation cause (see [CancelCause]) of jobs invoked
+// through [Run] (and variants), when another job returned an error. The cause
+// includes the name of the failed job, and wraps its error.
+var ErrJobFailed = errors.New("job failed")
+
+// DefaultSignals are the termination signals handled by [Run] and
+// [RunWithShutdown]. See [RunWithSignals] to handle a different set of signals.
+var DefaultSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT}
+
 // Run invokes all jobs concurrently, and listens for any termination signals
-// (SIGINT, SIGTERM, SIGQUIT, etc).
+// (see [DefaultSignals]).
 //
 // If any jobs return an error, all jobs will terminate (assuming they listen to
 // the provided context), and the first known error will be returned. We will wait
-// for all jobs to finish before returning.
+// for all jobs to finish before returning. See [RunWithShutdown] if you want to
+// bound how long to wait for jobs after cancellation.
 func Run(ctx context.Context, jobs ...Job) error {
+	return run(ctx, DefaultSignals, 0, jobs)
+}
+
+// RunWithSignals is the same as [Run], however the provided signals are used to
+// trigger shutdown, rather than [DefaultSignals], e.g. to also handle SIGHUP, or
+// to exclude SIGQUIT (which is otherwise used for goroutine dumps). If signals
+// is empty, no signals are handled, and jobs are only stopped through the
+// context (or a job returning an error).
+func RunWithSignals(ctx context.Context, signals []os.Signal, jobs ...Job) error {
+	return run(ctx, signals, 0, jobs)
+}
+
+// RunWithShutdown is the same as [Run], however once the context is canceled
+// (parent cancellation, a termination signal, or a job returning an error), it
+// will only wait up to drainTimeout for all jobs to return. If jobs are still
+// running after drainTimeout, the jobs which are still running are logged, and
+// [ErrShutdownTimeout] is returned, leaving those jobs running in the background
+// so the process can exit. A drainTimeout of 0 or less waits indefinitely.
+func RunWithShutdown(ctx context.Context, drainTimeout time.Duration, jobs ...Job) error {
+	return run(ctx, DefaultSignals, drainTimeout, jobs)
+}
+
+// run implements [Run], [RunWithSignals] and [RunWithShutdown].
+func run(ctx context.Context, signals []os.Signal, drainTimeout time.Duration, jobs []Job) error {
 	if len(jobs) == 0 {
 		return errors.New("no jobs provided")
 	}
 
-	ctx, cancel := signal.NotifyContext(
-		ctx,
-		os.Interrupt,
-		syscall.SIGTERM,
-		syscall.SIGQUIT,
-	)
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
 
-	eg := conc.NewGroup().
-		WithContext(ctx).
-		WithCancelOnError().
-		WithFirstError()
+	// Note that Notify relays all signals if none are provided.
+	if len(signals) > 0 {
+		received := make(chan os.Signal, 1)
+		signal.Notify(received, signals...)
+		defer signal.Stop(received)
+
+		go func() {
+			select {
+			case sig := <-received:
+				cancel(fmt.Errorf("%w: %s", ErrShutdownSignal, sig))
+			case <-ctx.Done():
+			}
+		}()
+	}
 
 	for _, runner := range jobs {
 		if c, ok := runner.(*Cron); ok {
@@ -70,24 +124,123 @@ func Run(ctx context.Context, jobs ...Job) error {
 				return fmt.Errorf("cron job has invalid spec %qs: %w", c.name, err)
 			}
 		}
+	}
+
+	// Cancel on error through our own context as well, so we know when to start
+	// the drain timeout, and so jobs know why they were canceled.
+	jobsCtx, cancelJobs := context.WithCancelCause(ctx)
+	defer cancelJobs(nil)
+
+	eg := conc.NewGroup().
+		WithContext(jobsCtx).
+		WithCancelOnError().
+		WithFirstError()
+
+	running := make([]atomic.Bool, len(jobs))
+	for i, runner := range jobs {
+		running[i].Store(true)
 		eg.Go(func(gctx context.Context) error {
-			return runner.Invoke(gctx)
+			defer running[i].Store(false)
+			err := runner.Invoke(gctx)
+			if err != nil {
+				cancelJobs(fmt.Errorf("%w: %s: %w", ErrJobFailed, jobName(i, runner), err))
+			}
+			return err
 		})
 	}
 
-	return eg.Wait()
+	done := make(chan error, 1)
+	go func() {
+		done <- eg.Wait()
+	}()
+
+	if drainTimeout <= 0 {
+		return <-done
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-jobsCtx.Done():
+	}
+
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		var names []string
+		for i, runner := range jobs {
+			if running[i].Load() {
+				names = append(names, jobName(i, runner))
+			}
+		}
+
+		LoggerFromContext(ctx).WarnContext(
+			ctx,
+			"jobs did not finish within drain timeout",
+			"drain_timeout", drainTimeout,
+			"jobs", names,
+		)
+		return ErrShutdownTimeout
+	}
+}
+
+// jobName returns a human-readable name for the job at index i, used for logging.
+func jobName(i int, job Job) string {
+	if c, ok := job.(*Cron); ok {
+		return fmt.Sprintf("cron:%s", c.name)
+	}
+	return fmt.Sprintf("job:%d", i)
 }
 
 var _ Job = (*Cron)(nil)
 
+// LockFunc attempts to acquire a named lock (e.g. backed by Redis, Postgres or
+// etcd), shared by all instances of a [Cron]. If the lock was acquired, ok must
+// be true, and release is called once the run completes. If the lock is held
+// elsewhere, ok must be false (with a nil error). See [Cron.WithLock].
+type LockFunc func(ctx context.Context, name string) (release func(), ok bool, err error)
+
+// CronStats is a snapshot of the run statistics of a [Cron]. See [Cron.Stats].
+type CronStats struct {
+	// Runs is the total number of runs of the underlying job (including failures).
+	Runs int
+	// Failures is the number of runs which returned an error (including timeouts).
+	Failures int
+	// LastRun is when the last run started. Zero if the job hasn't run yet.
+	LastRun time.Time
+	// LastDuration is the duration of the last completed run.
+	LastDuration time.Duration
+	// LastError is the error of the last completed run, or nil if it succeeded.
+	LastError error
+}
+
+// SuccessRate returns the fraction (0-1) of runs which succeeded. Returns 0 if
+// the job hasn't run yet.
+func (s CronStats) SuccessRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Runs-s.Failures) / float64(s.Runs)
+}
+
 type Cron struct {
 	name            string
 	schedule        Schedule
 	immediate       bool
 	exitOnError     bool
+	timeout         time.Duration
 	job             Job
 	logger          *slog.Logger
+	lock            LockFunc
 	validationError error
+	paused          atomic.Bool
+
+	statsMu sync.RWMutex
+	stats   CronStats
 }
 
 // NewCron creates a new cron job with the provided name and underlying job. The
@@ -115,6 +268,15 @@ func (c *Cron) WithInterval(interval time.Duration) *Cron {
 	return c
 }
 
+// WithAnchoredInterval sets the interval at which the cron job will run the
+// underlying job, aligned to the provided anchor time (see [EveryAnchored]), so
+// runs happen at the same times regardless of when the job was started. Cannot
+// be less than 1 second.
+func (c *Cron) WithAnchoredInterval(interval time.Duration, anchor time.Time) *Cron {
+	c.schedule = EveryAnchored(interval, anchor)
+	return c
+}
+
 // WithSchedule sets the schedule at which the cron job will run the underlying
 // job. It supports standard crontab-style schedules (e.g. "0 5 * * *") as well
 // as "@every 1h30m", "@hourly", "@daily", "@midnight", "@weekly", "@monthly",
@@ -129,6 +291,24 @@ func (c *Cron) WithSchedule(schedule string) *Cron {
 	return c
 }
 
+// WithRandomWindow sets the cron job to run once a day, at a random time between
+// the start and end clock times (in "15:04" or "15:04:05" format, local time),
+// e.g. "02:00" and "04:00", to spread load across instances. The time within the
+// window is derived from the hostname, the name of the cron job, and the date
+// (see [RandomWindow]), so it differs between instances and days, but is stable
+// for each day, so restarts don't cause a second run on the same day.
+func (c *Cron) WithRandomWindow(start, end string) *Cron {
+	hostname, _ := os.Hostname()
+
+	schedule, err := RandomWindow(start, end, hostname+"/"+c.name)
+	if err != nil {
+		c.validationError = fmt.Errorf("failed to parse window %s-%s: %w", start, end, err)
+		return c
+	}
+	c.schedule = schedule
+	return c
+}
+
 // WithImmediate sets whether the cron job should run the underlying job
 // immediately upon creation. This defaults to false. If true, the job will also
 // exit on error if the initial immediate run fails.
@@ -144,6 +324,27 @@ func (c *Cron) WithExitOnError(enabled bool) *Cron {
 	return c
 }
 
+// WithTimeout sets the maximum duration of each run of the underlying job. The
+// context passed to the job is cancelled once the timeout is reached, so a hung
+// run (which respects the context) doesn't block the schedule. A run which times
+// out is considered failed (returning an error wrapping [ErrJobTimeout]), which
+// is respected by [Cron.WithExitOnError]. Defaults to no timeout.
+func (c *Cron) WithTimeout(d time.Duration) *Cron {
+	c.timeout = max(0, d)
+	return c
+}
+
+// WithLock sets a function which is used to acquire a lock (with the name of the
+// cron job) before each run, so only a single instance runs the job when the same
+// cron job is scheduled on multiple replicas. If the lock is held elsewhere, the
+// run is skipped. The lock is released once the run completes. If acquiring the
+// lock fails, the run is skipped, and the error is handled like a failed run
+// (see [Cron.WithExitOnError]). See [LockFunc].
+func (c *Cron) WithLock(acquire LockFunc) *Cron {
+	c.lock = acquire
+	return c
+}
+
 // WithLogger sets the logger for the cron job. This defaults to the default
 // logger. You can obtain the logger from the context via [LoggerFromContext].
 func (c *Cron) WithLogger(logger *slog.Logger) *Cron {
@@ -153,6 +354,34 @@ func (c *Cron) WithLogger(logger *slog.Logger) *Cron {
 	return c
 }
 
+// Stats returns a snapshot of the run statistics of the cron job. This is safe to
+// call concurrently with [Cron.Invoke], e.g. for metrics or a dashboard. Note that
+// a run in progress is only reflected in [CronStats.LastRun] until it completes.
+func (c *Cron) Stats() CronStats {
+	c.statsMu.RLock()
+	defer c.statsMu.RUnlock()
+	return c.stats
+}
+
+// Pause pauses the cron job, e.g. for a maintenance window, without stopping
+// [Cron.Invoke]. While paused, scheduled runs are skipped (a run already in
+// progress is not affected). The schedule continues to advance, so skipped runs
+// are not replayed once resumed. Safe to call concurrently with [Cron.Invoke].
+func (c *Cron) Pause() {
+	c.paused.Store(true)
+}
+
+// Resume resumes a cron job paused with [Cron.Pause]. The next run happens at the
+// next scheduled time.
+func (c *Cron) Resume() {
+	c.paused.Store(false)
+}
+
+// IsPaused returns true if the cron job is paused. See [Cron.Pause].
+func (c *Cron) IsPaused() bool {
+	return c.paused.Load()
+}
+
 // Invoke runs the cron job. This is typically not called directly, but rather
 // via [Run].
 func (c *Cron) Invoke(ctx context.Context) error {
@@ -162,28 +391,13 @@ func (c *Cron) Invoke(ctx context.Context) error {
 		"exit_on_error", c.exitOnError,
 	)
 
-	var lastRun time.Time
-
 	if c.immediate {
 		// Jitter the first run by 0-2 seconds.
 		time.Sleep(time.Duration(rand.IntN(2)) * time.Second) //nolint:gosec
 
-		lastRun = time.Now()
-		l.InfoContext(ctx, "invoking cron")
-		if err := c.job.Invoke(withLogger(ctx, l)); err != nil {
-			l.ErrorContext(
-				ctx,
-				"cron failed",
-				"error", err,
-				"duration", time.Since(lastRun),
-			)
+		if err := c.runLocked(ctx, l); err != nil {
 			return err
 		}
-		l.InfoContext(
-			ctx,
-			"cron complete",
-			"duration", time.Since(lastRun),
-		)
 	}
 
 	var next time.Time
@@ -197,25 +411,89 @@ func (c *Cron) Invoke(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case <-time.After(time.Until(next)):
+			if c.paused.Load() {
+				l.InfoContext(ctx, "cron paused, skipping run")
+				continue
+			}
 
-			lastRun = time.Now()
-			l.InfoContext(ctx, "invoking cron")
-			if err := c.job.Invoke(withLogger(ctx, l)); err != nil {
-				l.ErrorContext(
-					ctx,
-					"cron failed",
-					"error", err,
-					"duration", time.Since(lastRun),
-				)
-				if c.exitOnError {
-					return err
-				}
+			if err := c.runLocked(ctx, l); err != nil && c.exitOnError {
+				return err
 			}
-			l.InfoContext(
-				ctx,
-				"cron complete",
-				"duration", time.Since(lastRun),
-			)
 		}
 	}
 }
+
+// runLocked invokes the underlying job once (see [Cron.run]), if the lock can be
+// acquired (see [Cron.WithLock]).
+func (c *Cron) runLocked(ctx context.Context, l *slog.Logger) error {
+	if c.lock == nil {
+		return c.run(ctx, l)
+	}
+
+	release, ok, err := c.lock(ctx, c.name)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to acquire cron lock", "error", err)
+		return fmt.Errorf("failed to acquire lock for cron %q: %w", c.name, err)
+	}
+	if !ok {
+		l.InfoContext(ctx, "cron lock held elsewhere, skipping run")
+		return nil
+	}
+	if release != nil {
+		defer release()
+	}
+
+	return c.run(ctx, l)
+}
+
+// run invokes the underlying job once, applying the configured timeout (if any),
+// and logging the result.
+func (c *Cron) run(ctx context.Context, l *slog.Logger) error {
+	runCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeoutCause(ctx, c.timeout, fmt.Errorf("%w after %s", ErrJobTimeout, c.timeout))
+		defer cancel()
+	}
+
+	started := time.Now()
+	l.InfoContext(ctx, "invoking cron")
+
+	c.statsMu.Lock()
+	c.stats.LastRun = started
+	c.statsMu.Unlock()
+
+	err := c.job.Invoke(withLogger(runCtx, l))
+	if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		if err == nil {
+			err = runCtx.Err()
+		}
+		err = fmt.Errorf("%w after %s: %w", ErrJobTimeout, c.timeout, err)
+	}
+
+	c.statsMu.Lock()
+	c.stats.Runs++
+	c.stats.LastDuration = time.Since(started)
+	c.stats.LastError = err
+	if err != nil {
+		c.stats.Failures++
+	}
+	c.statsMu.Unlock()
+
+	if err != nil {
+		l.ErrorContext(
+			ctx,
+			"cron failed",
+			"error", err,
+			"duration", time.Since(started),
+		)
+		return err
+	}
+
+	l.InfoContext(
+		ctx,
+		"cron complete",
+		"duration", time.Since(started),
+	)
+	return nil
+}
@@ -8,7 +8,11 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -101,6 +105,48 @@ func TestRun_contextCancel(t *testing.T) {
 	}
 }
 
+func TestRunWithSignals_noSignals(t *testing.T) {
+	// Without any signals, no signal handling is registered, so this can run
+	// within a synctest bubble.
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		defer cancel()
+
+		err := RunWithSignals(ctx, nil, JobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("RunWithSignals: %v", err)
+		}
+	})
+}
+
+func TestRunWithSignals_customSignal(t *testing.T) {
+	started := make(chan struct{})
+	go func() {
+		<-started
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			_ = p.Signal(syscall.SIGHUP)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := RunWithSignals(ctx, []os.Signal{syscall.SIGHUP}, JobFunc(func(jctx context.Context) error {
+		close(started)
+		<-jctx.Done()
+		if ctx.Err() != nil {
+			return errors.New("expected job to be stopped by SIGHUP, not the parent context")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("RunWithSignals: %v", err)
+	}
+}
+
 func TestRun_firstJobError(t *testing.T) {
 	t.Parallel()
 
@@ -135,6 +181,51 @@ func TestRun_invalidCronSchedule(t *testing.T) {
 	}
 }
 
+func TestRunWithShutdown_drainTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	err := RunWithShutdown(ctx, 50*time.Millisecond,
+		JobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}),
+		JobFunc(func(context.Context) error {
+			<-release // Ignores context cancellation.
+			return nil
+		}),
+	)
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("err = %v, want ErrShutdownTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("took %v, expected to return shortly after drain timeout", elapsed)
+	}
+}
+
+func TestRunWithShutdown_drainsInTime(t *testing.T) {
+	t.Parallel()
+
+	want := errors.New("fail")
+	err := RunWithShutdown(context.Background(), 5*time.Second,
+		JobFunc(func(context.Context) error { return want }),
+		JobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}),
+	)
+	if !errors.Is(err, want) {
+		t.Fatalf("err = %v, want %v", err, want)
+	}
+}
+
 func TestCron_builder(t *testing.T) {
 	t.Parallel()
 
@@ -237,3 +328,348 @@ func TestCron_Invoke_exitOnError(t *testing.T) {
 		}
 	})
 }
+
+func TestCron_Invoke_timeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+
+		runs := atomic.Int32{}
+		job := JobFunc(func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				<-ctx.Done() // Hangs until the timeout.
+			}
+			return nil
+		})
+		c := NewCron("t", job).
+			WithInterval(time.Second).
+			WithTimeout(500 * time.Millisecond).
+			WithLogger(slog.New(slog.DiscardHandler))
+
+		// Timed-out run doesn't block the schedule.
+		err := c.Invoke(ctx)
+		if err != nil {
+			t.Fatalf("Invoke: %v", err)
+		}
+		if n := runs.Load(); n < 2 {
+			t.Fatalf("runs = %d, want at least 2", n)
+		}
+	})
+}
+
+func TestCron_Invoke_timeoutExitOnError(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+
+		job := JobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		c := NewCron("t", job).
+			WithInterval(time.Second).
+			WithExitOnError(true).
+			WithTimeout(time.Second).
+			WithLogger(slog.New(slog.DiscardHandler))
+
+		err := c.Invoke(ctx)
+		if !errors.Is(err, ErrJobTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v, want ErrJobTimeout wrapping context.DeadlineExceeded", err)
+		}
+		if ctx.Err() != nil {
+			t.Fatal("expected Invoke to return before the parent context was done")
+		}
+	})
+}
+
+func TestCron_Stats(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		want := errors.New("boom")
+		runs := atomic.Int32{}
+		job := JobFunc(func(context.Context) error {
+			time.Sleep(100 * time.Millisecond)
+			if runs.Add(1) == 2 {
+				return want
+			}
+			return nil
+		})
+		c := NewCron("t", job).
+			WithInterval(time.Second).
+			WithLogger(slog.New(slog.DiscardHandler))
+
+		if stats := c.Stats(); stats.Runs != 0 || !stats.LastRun.IsZero() || stats.SuccessRate() != 0 {
+			t.Fatalf("stats = %+v, want zero value", stats)
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- c.Invoke(ctx) }()
+
+		for runs.Load() < 3 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		synctest.Wait()
+
+		stats := c.Stats()
+		if stats.Runs != 3 || stats.Failures != 1 {
+			t.Fatalf("runs = %d, failures = %d, want 3 and 1", stats.Runs, stats.Failures)
+		}
+		if stats.LastError != nil {
+			t.Fatalf("last error = %v, want nil", stats.LastError)
+		}
+		if stats.LastDuration != 100*time.Millisecond {
+			t.Fatalf("last duration = %v, want 100ms", stats.LastDuration)
+		}
+		if rate := stats.SuccessRate(); rate < 0.66 || rate > 0.67 {
+			t.Fatalf("success rate = %v, want 2/3", rate)
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Invoke: %v", err)
+		}
+	})
+}
+
+func TestCron_Pause(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		runs := atomic.Int32{}
+		job := JobFunc(func(context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+		c := NewCron("t", job).
+			WithInterval(time.Minute).
+			WithLogger(slog.New(slog.DiscardHandler))
+
+		c.Pause()
+		if !c.IsPaused() {
+			t.Fatal("expected cron to be paused")
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- c.Invoke(ctx) }()
+
+		time.Sleep(5 * time.Minute)
+		synctest.Wait()
+		if n := runs.Load(); n != 0 {
+			t.Fatalf("runs while paused = %d, want 0", n)
+		}
+
+		c.Resume()
+		if c.IsPaused() {
+			t.Fatal("expected cron to be resumed")
+		}
+
+		time.Sleep(3 * time.Minute)
+		synctest.Wait()
+		if n := runs.Load(); n < 2 {
+			t.Fatalf("runs after resume = %d, want at least 2", n)
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Invoke: %v", err)
+		}
+	})
+}
+
+// memoryLock is an in-memory [LockFunc], shared by multiple crons.
+type memoryLock struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (m *memoryLock) acquire(_ context.Context, name string) (func(), bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.held[name] {
+		return nil, false, nil
+	}
+	m.held[name] = true
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.held, name)
+	}, true, nil
+}
+
+func TestCron_WithLock(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		lock := &memoryLock{held: make(map[string]bool)}
+		anchor := time.Now()
+
+		var runsA, runsB atomic.Int32
+		newCron := func(runs *atomic.Int32) *Cron {
+			return NewCron("shared", JobFunc(func(context.Context) error {
+				runs.Add(1)
+				time.Sleep(100 * time.Millisecond)
+				return nil
+			})).
+				WithAnchoredInterval(time.Minute, anchor).
+				WithLock(lock.acquire).
+				WithLogger(slog.New(slog.DiscardHandler))
+		}
+
+		done := make(chan error, 2)
+		go func() { done <- newCron(&runsA).Invoke(ctx) }()
+		go func() { done <- newCron(&runsB).Invoke(ctx) }()
+
+		for tick := int32(1); tick <= 3; tick++ {
+			time.Sleep(time.Minute)
+			synctest.Wait()
+
+			if total := runsA.Load() + runsB.Load(); total != tick {
+				t.Fatalf("tick %d: total runs = %d (a=%d, b=%d), want %d", tick, total, runsA.Load(), runsB.Load(), tick)
+			}
+		}
+
+		cancel()
+		for range 2 {
+			if err := <-done; err != nil {
+				t.Fatalf("Invoke: %v", err)
+			}
+		}
+	})
+}
+
+func TestCron_WithLock_error(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		want := errors.New("lock unavailable")
+
+		var runs atomic.Int32
+		err := NewCron("t", JobFunc(func(context.Context) error {
+			runs.Add(1)
+			return nil
+		})).
+			WithInterval(time.Second).
+			WithExitOnError(true).
+			WithLock(func(context.Context, string) (func(), bool, error) {
+				return nil, false, want
+			}).
+			WithLogger(slog.New(slog.DiscardHandler)).
+			Invoke(t.Context())
+
+		if !errors.Is(err, want) {
+			t.Fatalf("err = %v, want %v", err, want)
+		}
+		if runs.Load() != 0 {
+			t.Fatalf("runs = %d, want 0", runs.Load())
+		}
+	})
+}
+
+func TestCancelCause_jobFailed(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		want := errors.New("boom")
+		var cause error
+
+		err := Run(t.Context(),
+			NewCron("failing", JobFunc(func(context.Context) error { return want })).
+				WithImmediate(true).
+				WithExitOnError(true).
+				WithLogger(slog.New(slog.DiscardHandler)),
+			JobFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				cause = CancelCause(ctx)
+				return nil
+			}),
+		)
+		if !errors.Is(err, want) {
+			t.Fatalf("err = %v, want %v", err, want)
+		}
+		if !errors.Is(cause, ErrJobFailed) || !errors.Is(cause, want) {
+			t.Fatalf("cause = %v, want %v wrapping %v", cause, ErrJobFailed, want)
+		}
+		if !strings.Contains(cause.Error(), "cron:failing") {
+			t.Fatalf("cause = %v, want it to include the job name", cause)
+		}
+	})
+}
+
+func TestCancelCause_parent(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		var cause error
+
+		go func() {
+			time.Sleep(time.Second)
+			cancel()
+		}()
+
+		err := RunWithSignals(ctx, nil, JobFunc(func(ctx context.Context) error {
+			if CancelCause(ctx) != nil {
+				return errors.New("expected no cause before cancellation")
+			}
+			<-ctx.Done()
+			cause = CancelCause(ctx)
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("RunWithSignals: %v", err)
+		}
+		if cause != context.Canceled { //nolint:errorlint
+			t.Fatalf("cause = %v, want %v", cause, context.Canceled)
+		}
+	})
+}
+
+func TestCancelCause_timeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var cause error
+
+		err := NewCron("slow", JobFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			cause = CancelCause(ctx)
+			return nil
+		})).
+			WithImmediate(true).
+			WithExitOnError(true).
+			WithTimeout(time.Second).
+			WithLogger(slog.New(slog.DiscardHandler)).
+			Invoke(t.Context())
+		if !errors.Is(err, ErrJobTimeout) {
+			t.Fatalf("err = %v, want %v", err, ErrJobTimeout)
+		}
+		if !errors.Is(cause, ErrJobTimeout) {
+			t.Fatalf("cause = %v, want %v", cause, ErrJobTimeout)
+		}
+	})
+}
+
+func TestCancelCause_signal(t *testing.T) {
+	started := make(chan struct{})
+	go func() {
+		<-started
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			_ = p.Signal(syscall.SIGHUP)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var cause error
+	err := RunWithSignals(ctx, []os.Signal{syscall.SIGHUP}, JobFunc(func(jctx context.Context) error {
+		close(started)
+		<-jctx.Done()
+		cause = CancelCause(jctx)
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("RunWithSignals: %v", err)
+	}
+	if !errors.Is(cause, ErrShutdownSignal) || !strings.Contains(cause.Error(), syscall.SIGHUP.String()) {
+		t.Fatalf("cause = %v, want %v including the signal", cause, ErrShutdownSignal)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+var _ Job = (*onceJob)(nil)
+
+// onceJob invokes the underlying job a single time, once a target time has been
+// reached.
+type onceJob struct {
+	at    time.Time
+	delay time.Duration
+	job   Job
+}
+
+// OnceAt returns a [Job] that waits until t, invokes job a single time, and then
+// returns nil. If job returns an error, it is logged (see [LoggerFromContext])
+// rather than returned, so it doesn't stop other jobs invoked through [Run]. If
+// t is in the past, job is invoked immediately. If the context is canceled
+// before t, job is never invoked. See also [After].
+func OnceAt(t time.Time, job Job) Job {
+	return &onceJob{at: t, job: job}
+}
+
+// After returns a [Job] that waits for d (starting from when the returned job is
+// invoked, e.g. via [Run]), invokes job a single time, and then returns nil. Job
+// errors are handled the same as [OnceAt]. If the context is canceled before d
+// elapses, job is never invoked. See also [OnceAt].
+func After(d time.Duration, job Job) Job {
+	return &onceJob{delay: d, job: job}
+}
+
+func (o *onceJob) Invoke(ctx context.Context) error {
+	at := o.at
+	if at.IsZero() {
+		at = time.Now().Add(o.delay)
+	}
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-timer.C:
+		if err := o.job.Invoke(ctx); err != nil {
+			LoggerFromContext(ctx).ErrorContext(ctx, "one-off job failed", "error", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestOnceAt(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var logs bytes.Buffer
+		ctx := withLogger(t.Context(), slog.New(slog.NewTextHandler(&logs, nil)))
+		target := time.Now().Add(time.Hour)
+
+		var ranAt time.Time
+		err := OnceAt(target, JobFunc(func(context.Context) error {
+			ranAt = time.Now()
+			return errors.New("done")
+		})).Invoke(ctx)
+		if err != nil {
+			t.Fatalf("err = %v, want nil", err)
+		}
+		if !strings.Contains(logs.String(), "error=done") {
+			t.Fatalf("job error not logged: %q", logs.String())
+		}
+		if !ranAt.Equal(target) {
+			t.Fatalf("ran at %v, want %v", ranAt, target)
+		}
+	})
+}
+
+func TestOnceAt_past(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var runs int
+		err := OnceAt(time.Now().Add(-time.Hour), JobFunc(func(context.Context) error {
+			runs++
+			return nil
+		})).Invoke(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if runs != 1 {
+			t.Fatalf("runs = %d, want 1", runs)
+		}
+	})
+}
+
+func TestAfter_contextCancel(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		defer cancel()
+
+		var ran bool
+		err := After(time.Hour, JobFunc(func(context.Context) error {
+			ran = true
+			return nil
+		})).Invoke(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ran {
+			t.Fatal("job should not run when context is canceled first")
+		}
+	})
+}
+
+func TestAfter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		start := time.Now()
+		var ranAt time.Time
+		job := After(30*time.Minute, JobFunc(func(context.Context) error {
+			ranAt = time.Now()
+			return nil
+		}))
+		if err := job.Invoke(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		if got := ranAt.Sub(start); got != 30*time.Minute {
+			t.Fatalf("ran after %v, want 30m", got)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// WindowSchedule represents a schedule which activates once a day, at a random
+// time within a daily window, e.g. "sometime between 2am and 4am". The random
+// time is derived from the seed and the date, so it is stable for the day
+// (restarts don't cause a second run on the same day), while differing between
+// days, and between seeds (e.g. instances).
+type WindowSchedule struct {
+	// Start is the start of the window, as an offset from midnight.
+	Start time.Duration
+	// End is the end of the window (exclusive), as an offset from midnight. If
+	// End is before (or equal to) Start, the window spans midnight.
+	End time.Duration
+	// Seed is used (along with the date) to pick the time within the window.
+	Seed string
+}
+
+func (s WindowSchedule) String() string {
+	return fmt.Sprintf("@window %s-%s", formatClock(s.Start), formatClock(s.End))
+}
+
+// Describe returns a human-readable description of the schedule, e.g. "Once a
+// day, at a random time between 02:00 and 04:00".
+func (s WindowSchedule) Describe() string {
+	return fmt.Sprintf("Once a day, at a random time between %s and %s", formatClock(s.Start), formatClock(s.End))
+}
+
+// RandomWindow returns a [WindowSchedule], which activates once a day, at a random
+// time between the start and end clock times (in "15:04" or "15:04:05" format,
+// in the location of the time passed to Next). The time within the window is
+// derived from the seed and the date, so it is stable for each day. If end is
+// before start, the window spans midnight (e.g. "23:00" to "01:00").
+func RandomWindow(start, end, seed string) (WindowSchedule, error) {
+	startOffset, err := parseClock(start)
+	if err != nil {
+		return WindowSchedule{}, fmt.Errorf("invalid window start %q: %w", start, err)
+	}
+
+	endOffset, err := parseClock(end)
+	if err != nil {
+		return WindowSchedule{}, fmt.Errorf("invalid window end %q: %w", end, err)
+	}
+
+	return WindowSchedule{Start: startOffset, End: endOffset, Seed: seed}, nil
+}
+
+// Next returns the next time this should be run, which is the random time within
+// the first window (of the current, or following days) later than the given
+// time.
+func (s WindowSchedule) Next(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	// Windows which span midnight may still be in progress from the previous day.
+	for i := -1; ; i++ {
+		midnight := time.Date(year, month, day+i, 0, 0, 0, 0, t.Location())
+		if next := midnight.Add(s.Start + s.offset(midnight)); next.After(t) {
+			return next
+		}
+	}
+}
+
+// length returns the length of the window.
+func (s WindowSchedule) length() time.Duration {
+	length := s.End - s.Start
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+	return length
+}
+
+// offset returns the offset from the start of the window, of the run for the
+// window starting on the provided day, truncated to the second.
+func (s WindowSchedule) offset(day time.Time) time.Duration {
+	seconds := uint64(s.length() / time.Second)
+	if seconds == 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s.Seed))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(day.Format(time.DateOnly)))
+
+	return time.Duration(h.Sum64()%seconds) * time.Second
+}
+
+// parseClock parses a clock time ("15:04" or "15:04:05") into an offset from
+// midnight.
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		t, err = time.Parse("15:04", clock)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second, nil
+}
+
+// formatClock formats an offset from midnight as a clock time.
+func formatClock(offset time.Duration) string {
+	offset %= 24 * time.Hour
+	if s := offset % time.Minute; s != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(s/time.Second))
+	}
+	return fmt.Sprintf("%02d:%02d", int(offset/time.Hour), int(offset%time.Hour/time.Minute))
+}
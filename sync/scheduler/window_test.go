@@ -0,0 +1,166 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestRandomWindow_Next(t *testing.T) {
+	t.Parallel()
+
+	s, err := RandomWindow("02:00", "04:00", "instance-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 90 {
+		day := start.AddDate(0, 0, i)
+		next := s.Next(day)
+
+		windowStart := day.Add(2 * time.Hour)
+		windowEnd := day.Add(4 * time.Hour)
+		if next.Before(windowStart) || !next.Before(windowEnd) {
+			t.Fatalf("Next(%v) = %v, want within [%v, %v)", day, next, windowStart, windowEnd)
+		}
+		if next.Nanosecond() != 0 {
+			t.Fatalf("Next(%v) = %v, want truncated to the second", day, next)
+		}
+
+		// Stable for the day, regardless of when it's computed.
+		if again := s.Next(next.Add(-time.Second)); !again.Equal(next) {
+			t.Fatalf("Next(%v) = %v, want %v", next.Add(-time.Second), again, next)
+		}
+
+		// Once the run time has passed, the next run is the following day.
+		if after := s.Next(next); after.YearDay() != next.AddDate(0, 0, 1).YearDay() {
+			t.Fatalf("Next(%v) = %v, want the following day", next, after)
+		}
+	}
+}
+
+func TestRandomWindow_spansMidnight(t *testing.T) {
+	t.Parallel()
+
+	s, err := RandomWindow("23:00", "01:00", "instance-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 90 {
+		day := start.AddDate(0, 0, i)
+		next := s.Next(day)
+
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), 23, 0, 0, 0, time.UTC)
+		windowEnd := windowStart.Add(2 * time.Hour)
+		if next.Before(windowStart) || !next.Before(windowEnd) {
+			t.Fatalf("Next(%v) = %v, want within [%v, %v)", day, next, windowStart, windowEnd)
+		}
+
+		// Still within the previous day's window after midnight.
+		if next.Day() != day.Day() {
+			midnight := windowStart.Add(time.Hour)
+			if again := s.Next(midnight); !again.Equal(next) {
+				t.Fatalf("Next(%v) = %v, want %v", midnight, again, next)
+			}
+		}
+	}
+}
+
+func TestRandomWindow_seeds(t *testing.T) {
+	t.Parallel()
+
+	a, _ := RandomWindow("02:00", "04:00", "instance-a")
+	b, _ := RandomWindow("02:00", "04:00", "instance-b")
+
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var differs bool
+	for i := range 30 {
+		if !a.Next(day.AddDate(0, 0, i)).Equal(b.Next(day.AddDate(0, 0, i))) {
+			differs = true
+			break
+		}
+	}
+	if !differs {
+		t.Fatal("different seeds produced the same run times for 30 days")
+	}
+}
+
+func TestRandomWindow_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range [][2]string{{"25:00", "04:00"}, {"02:00", "4pm"}, {"", "04:00"}} {
+		if _, err := RandomWindow(tt[0], tt[1], ""); err == nil {
+			t.Errorf("RandomWindow(%q, %q) expected error", tt[0], tt[1])
+		}
+	}
+
+	c := NewCron("test", JobFunc(func(context.Context) error { return nil })).WithRandomWindow("02:00", "nope")
+	if c.validate() == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestRandomWindow_String(t *testing.T) {
+	t.Parallel()
+
+	s, _ := RandomWindow("02:00", "04:30:15", "")
+	if got := s.String(); got != "@window 02:00-04:30:15" {
+		t.Fatalf("String() = %q", got)
+	}
+	if got := s.Describe(); got != "Once a day, at a random time between 02:00 and 04:30:15" {
+		t.Fatalf("Describe() = %q", got)
+	}
+}
+
+func TestCron_WithRandomWindow(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		// Start at midnight (local time), so each day contains a single window.
+		now := time.Now()
+		time.Sleep(time.Until(time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())))
+
+		var runs atomic.Int32
+		c := NewCron("nightly", JobFunc(func(context.Context) error {
+			runs.Add(1)
+			return nil
+		})).
+			WithRandomWindow("02:00", "04:00").
+			WithLogger(slog.New(slog.DiscardHandler))
+
+		done := make(chan error, 1)
+		go func() { done <- c.Invoke(ctx) }()
+
+		start := time.Now()
+		for day := 1; day <= 3; day++ {
+			time.Sleep(24 * time.Hour)
+			synctest.Wait()
+
+			if got := runs.Load(); got != int32(day) {
+				t.Fatalf("day %d: runs = %d, want %d", day, got, day)
+			}
+
+			lastRun := c.Stats().LastRun
+			windowStart := time.Date(start.Year(), start.Month(), start.Day()+day-1, 2, 0, 0, 0, start.Location())
+			if lastRun.Before(windowStart) || !lastRun.Before(windowStart.Add(2*time.Hour)) {
+				t.Fatalf("day %d: last run = %v, want within 2 hours of %v", day, lastRun, windowStart)
+			}
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Invoke: %v", err)
+		}
+	})
+}
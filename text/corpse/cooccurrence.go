@@ -0,0 +1,155 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ExpandQuery returns up to n terms which are most associated with the terms of
+// the provided text (excluding the terms of the text itself), which can be added
+// to a search query to improve recall. Terms are scored by how often they appear
+// in the same documents as each query term, normalized by the document frequency
+// of both terms (the Ochiai coefficient), summed across all query terms. Ties are
+// broken alphabetically.
+//
+// Requires [WithCooccurrence], otherwise nil is returned. Like
+// [Corpus.CreateVector], this will automatically call [Corpus.Prune] if there are
+// any new documents that have been indexed since the last prune, and pruned
+// terms are never returned.
+//
+// This is concurrent-safe.
+func (c *Corpus) ExpandQuery(text string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	c.Prune()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.cooccurrence == nil {
+		return nil
+	}
+
+	query := c.seenTermPool.Get()
+	defer c.seenTermPool.Put(query)
+
+	for term := range c.tokenize(text) {
+		query[term] = struct{}{}
+	}
+
+	scores := make(map[string]float64)
+	for q := range query {
+		qFreq := c.termFreq[q]
+		if qFreq == 0 {
+			continue
+		}
+
+		for term, count := range c.cooccurrence[q] {
+			if _, ok := query[term]; ok || c.termFreq[term] == 0 {
+				continue
+			}
+			scores[term] += float64(count) / math.Sqrt(float64(qFreq)*float64(c.termFreq[term]))
+		}
+	}
+
+	terms := slices.Collect(maps.Keys(scores))
+	slices.SortFunc(terms, func(a, b string) int {
+		if r := cmp.Compare(scores[b], scores[a]); r != 0 {
+			return r
+		}
+		return strings.Compare(a, b)
+	})
+
+	return terms[:min(n, len(terms))]
+}
+
+// addCooccurrence counts every pair of the provided (unique) terms of a document
+// as co-occurring, ignoring terms which aren't part of the corpus (e.g. because
+// the vocabulary is frozen). No-op if [WithCooccurrence] isn't used. Caller must
+// hold [Corpus.mu].
+func (c *Corpus) addCooccurrence(terms map[string]struct{}) {
+	if c.cooccurrence == nil {
+		return
+	}
+
+	tracked := make([]string, 0, len(terms))
+	for term := range terms {
+		if _, ok := c.termFreq[term]; ok {
+			tracked = append(tracked, term)
+		}
+	}
+
+	for i, a := range tracked {
+		for _, b := range tracked[i+1:] {
+			c.incrementCooccurrence(a, b)
+			c.incrementCooccurrence(b, a)
+		}
+	}
+}
+
+// incrementCooccurrence increments the co-occurrence count of b within the row of
+// a. Caller must hold [Corpus.mu].
+func (c *Corpus) incrementCooccurrence(a, b string) {
+	row, ok := c.cooccurrence[a]
+	if !ok {
+		row = make(map[string]int)
+		c.cooccurrence[a] = row
+	}
+	row[b]++
+}
+
+// removeCooccurrence reverts [Corpus.addCooccurrence] for a removed document.
+// Caller must hold [Corpus.mu].
+func (c *Corpus) removeCooccurrence(terms map[string]struct{}) {
+	if c.cooccurrence == nil {
+		return
+	}
+
+	for a := range terms {
+		row, ok := c.cooccurrence[a]
+		if !ok {
+			continue
+		}
+
+		for b := range terms {
+			count, ok := row[b]
+			switch {
+			case !ok:
+				continue
+			case count <= 1:
+				delete(row, b)
+			default:
+				row[b] = count - 1
+			}
+		}
+
+		if len(row) == 0 {
+			delete(c.cooccurrence, a)
+		}
+	}
+}
+
+// removeCooccurrenceTerm removes all co-occurrences of a (pruned) term. Caller
+// must hold [Corpus.mu].
+func (c *Corpus) removeCooccurrenceTerm(term string) {
+	if c.cooccurrence == nil {
+		return
+	}
+
+	for other := range c.cooccurrence[term] {
+		delete(c.cooccurrence[other], term)
+		if len(c.cooccurrence[other]) == 0 {
+			delete(c.cooccurrence, other)
+		}
+	}
+	delete(c.cooccurrence, term)
+}
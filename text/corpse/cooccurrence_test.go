@@ -0,0 +1,107 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+var cooccurrenceDocs = []string{
+	"kubernetes cluster pod",
+	"kubernetes pod deployment",
+	"kubernetes cluster node",
+	"database query index",
+	"database index replica",
+}
+
+func TestCorpus_ExpandQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, workers := range []int{1, 4} {
+		corp := New(WithCooccurrence(), WithConcurrentIndexing(workers))
+		corp.IndexDocuments(cooccurrenceDocs...)
+
+		tests := []struct {
+			query string
+			n     int
+			want  []string
+		}{
+			{query: "kubernetes", n: 2, want: []string{"cluster", "pod"}},
+			{query: "kubernetes", n: 10, want: []string{"cluster", "pod", "deployment", "node"}},
+			{query: "Database", n: 1, want: []string{"index"}},
+			{query: "database index", n: 10, want: []string{"query", "replica"}},
+			{query: "unknown", n: 3, want: nil},
+			{query: "kubernetes", n: 0, want: nil},
+		}
+
+		for _, tt := range tests {
+			got := corp.ExpandQuery(tt.query, tt.n)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("workers=%d: ExpandQuery(%q, %d) = %v, want %v", workers, tt.query, tt.n, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestCorpus_ExpandQuery_disabled(t *testing.T) {
+	t.Parallel()
+
+	corp := New()
+	corp.IndexDocuments(cooccurrenceDocs...)
+
+	if got := corp.ExpandQuery("kubernetes", 2); got != nil {
+		t.Fatalf("expected nil without WithCooccurrence, got %v", got)
+	}
+}
+
+func TestCorpus_ExpandQuery_removeAndPrune(t *testing.T) {
+	t.Parallel()
+
+	corp := New(WithCooccurrence(), WithPruneHooks(PruneLessThan(2)))
+	corp.IndexDocuments(cooccurrenceDocs...)
+
+	// Terms in a single document (deployment, node, query, replica) are pruned.
+	if got, want := corp.ExpandQuery("kubernetes", 10), []string{"cluster", "pod"}; !slices.Equal(got, want) {
+		t.Fatalf("ExpandQuery() = %v, want %v", got, want)
+	}
+
+	// Cluster is now only in a single document, so it is pruned as well.
+	corp.RemoveDocument(cooccurrenceDocs[2])
+	if got, want := corp.ExpandQuery("kubernetes", 10), []string{"pod"}; !slices.Equal(got, want) {
+		t.Fatalf("ExpandQuery() after remove = %v, want %v", got, want)
+	}
+
+	corp.Reset()
+	if got := corp.ExpandQuery("kubernetes", 10); len(got) != 0 {
+		t.Fatalf("ExpandQuery() after reset = %v, want none", got)
+	}
+	if corp.cooccurrence == nil {
+		t.Fatal("expected co-occurrence tracking to stay enabled after reset")
+	}
+}
+
+func TestCorpus_IndexDocuments_concurrentReset(t *testing.T) {
+	t.Parallel()
+
+	corp := New(WithCooccurrence(), WithConcurrentIndexing(4))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 20 {
+				corp.IndexDocuments(cooccurrenceDocs...)
+				corp.Reset()
+			}
+		})
+	}
+	wg.Wait()
+
+	corp.IndexDocuments(cooccurrenceDocs...)
+	if got := corp.ExpandQuery("kubernetes", 10); len(got) == 0 {
+		t.Fatal("expected co-occurrence tracking to still work after concurrent resets")
+	}
+}
@@ -7,11 +7,15 @@
 package corpse
 
 import (
+	"bufio"
+	"io"
 	"iter"
 	"maps"
+	"slices"
 	"sync"
 
 	"github.com/chewxy/math32"
+	"github.com/lrstanley/x/sync/conc"
 	"github.com/lrstanley/x/sync/pool"
 	"github.com/lrstanley/x/text/corpse/internal/utils"
 )
@@ -22,12 +26,26 @@ type Corpus struct {
 	tokenizer     Tokenizer
 	termFilters   []TermFilter
 	pruneHooks    []PruneHook
+	indexWorkers  int
+	tf            TFFunc
+	idf           IDFFunc
+	termWeights   map[string]float32
+
+	capacityThreshold int
+	capacityHook      func(percent int)
+	minPruneDocuments int
 
 	mu        sync.RWMutex
 	termFreq  map[string]int           // How many times a term appears in ALL documents.
 	termIndex *utils.SortedSet[string] // Set used for consistent vector positions.
 	documents int                      // How many documents have been indexed.
 	hasPruned bool
+	overCap   bool // If the capacity hook has fired, and capacity is still above the threshold.
+	frozen    bool // If the vocabulary is frozen, see [Corpus.Freeze].
+
+	// Number of documents each pair of terms appear in together (in both
+	// directions), or nil if [WithCooccurrence] isn't used.
+	cooccurrence map[string]map[string]int
 
 	seenTermPool pool.Pool[map[string]struct{}]
 	termFreqPool pool.Pool[map[string]int]
@@ -38,6 +56,8 @@ func New(options ...Option) *Corpus {
 	c := &Corpus{
 		maxVectorSize: 256,
 		tokenizer:     DefaultTokenizer,
+		tf:            DefaultTF,
+		idf:           DefaultIDF,
 		termFreq:      make(map[string]int),
 		termIndex:     &utils.SortedSet[string]{},
 		seenTermPool: pool.Pool[map[string]struct{}]{
@@ -72,46 +92,139 @@ func (c *Corpus) Reset() {
 	c.termFreq = make(map[string]int)
 	c.termIndex.Clear()
 	c.documents = 0
+	c.overCap = false
+	c.frozen = false
+	if c.cooccurrence != nil {
+		c.cooccurrence = make(map[string]map[string]int)
+	}
+}
+
+// Freeze freezes the vocabulary of the corpus (see [Corpus.Vocabulary]), after
+// running [Corpus.Prune]. Once frozen, terms which aren't already part of the
+// vocabulary are ignored when indexing documents, and terms are never removed
+// from the vocabulary (by pruning, or by removing documents), so the position of
+// each term within vectors never changes. Document counts and frequencies of
+// existing terms are still updated, so the weights within vectors may still
+// change.
+//
+// Without freezing, indexing or removing documents can shift the position of
+// terms, invalidating previously created vectors. Freezing is therefore required
+// before persisting vectors externally (e.g. in a vector database), if more
+// documents may be indexed later. [Corpus.Reset] unfreezes the corpus.
+//
+// This is concurrent-safe.
+func (c *Corpus) Freeze() {
+	c.Prune()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen = true
+}
+
+// IsFrozen returns true if the vocabulary is frozen. See [Corpus.Freeze].
+func (c *Corpus) IsFrozen() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.frozen
+}
+
+// Vocabulary returns the terms of the corpus, in vector order (i.e. the term at
+// index i corresponds to index i of vectors created by [Corpus.CreateVector]).
+// Terms beyond the max vector size (see [WithMaxVectorSize]) are not included,
+// as they aren't part of vectors. Like [Corpus.CreateVector], this will
+// automatically call [Corpus.Prune] if there are any new documents that have
+// been indexed since the last prune. See [Corpus.Freeze] to keep the vocabulary
+// stable.
+//
+// This is concurrent-safe.
+func (c *Corpus) Vocabulary() []string {
+	c.Prune()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	terms := c.termIndex.All()
+	return slices.Clone(terms[:min(len(terms), c.maxVectorSize)])
+}
+
+// addTerm increments the frequency of term by n, adding it to the vocabulary if
+// needed. If the vocabulary is frozen, unknown terms are ignored. Caller must
+// hold [Corpus.mu].
+func (c *Corpus) addTerm(term string, n int) {
+	if c.frozen && !c.termIndex.Contains(term) {
+		return
+	}
+	c.termFreq[term] += n
+	c.termIndex.Add(term)
 }
 
 // Prune runs all prune hooks, removing terms of less importance from the corpus.
 // This is automatically ran by [Corpus.CreateVector] if there are any new documents
 // that have been indexed since the last prune. Run it manually if you don't plan to
 // invoke [Corpus.CreateVector] immediately after indexing all documents. Do not
-// run this until you have indexed all documents.
+// run this until you have indexed all documents. If configured, this will also
+// invoke the [WithCapacityHook] hook. Prune hooks are not run once the vocabulary
+// is frozen (see [Corpus.Freeze]). This is a no-op until the number of documents
+// set by [WithMinDocumentsForPrune] have been indexed.
 //
 // This is concurrent-safe.
 func (c *Corpus) Prune() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if c.hasPruned || c.documents == 0 || len(c.pruneHooks) == 0 {
+	if c.hasPruned || c.documents == 0 || c.documents < c.minPruneDocuments {
+		c.mu.Unlock()
 		return
 	}
 
-	snapshot := maps.Clone(c.termFreq)
-
-	for _, hook := range c.pruneHooks {
-		for _, term := range hook(c.documents, snapshot) {
-			delete(c.termFreq, term)
-			c.termIndex.Remove(term)
+	// Pruning removes terms from the vocabulary, so it is skipped when frozen.
+	if len(c.pruneHooks) > 0 && !c.frozen {
+		snapshot := maps.Clone(c.termFreq)
+
+		// Each hook sees the terms removed by previous hooks as already removed.
+		for _, hook := range c.pruneHooks {
+			for _, term := range hook(c.documents, snapshot) {
+				delete(snapshot, term)
+				delete(c.termFreq, term)
+				c.termIndex.Remove(term)
+				c.removeCooccurrenceTerm(term)
+			}
 		}
 	}
 
 	c.hasPruned = true
+
+	// Only fire the capacity hook when crossing the threshold, and run it outside
+	// of the lock, so the hook can query the corpus.
+	percent := c.usedCapacity()
+	fire := false
+	if c.capacityHook != nil {
+		fire = !c.overCap && percent >= c.capacityThreshold
+		c.overCap = percent >= c.capacityThreshold
+	}
+
+	c.mu.Unlock()
+
+	if fire {
+		c.capacityHook(percent)
+	}
 }
 
 // GetUsedCapacity returns the percentage of the corpus capacity that is used.
 // You can use this to determine if you are getting close to the max vector size.
 // If you do go above capacity, all vectors will be calculated with the first X
 // terms (sorted), where X is the max vector size, and you will lose corpus
-// information. Make sure to call [Corpus.Prune] before checking this.
+// information. Make sure to call [Corpus.Prune] before checking this. See also
+// [WithCapacityHook].
 func (c *Corpus) GetUsedCapacity() (percent int) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return c.usedCapacity()
+}
 
-	percent = int(float32(len(c.termFreq)) / float32(c.maxVectorSize) * 100)
-	return percent
+// usedCapacity returns the percentage of the corpus capacity that is used. Caller
+// must hold [Corpus.mu].
+func (c *Corpus) usedCapacity() int {
+	return int(float32(len(c.termFreq)) / float32(c.maxVectorSize) * 100)
 }
 
 // IndexDocument indexes a document, calculating occurrences of each term. Note that
@@ -128,15 +241,164 @@ func (c *Corpus) IndexDocument(text string) {
 
 	for term := range c.tokenize(text) {
 		if _, ok := seenTerms[term]; !ok {
-			c.termFreq[term]++
+			c.addTerm(term, 1)
 			seenTerms[term] = struct{}{}
-			c.termIndex.Add(term)
 		}
 	}
+	c.addCooccurrence(seenTerms)
 	c.documents++
 	c.hasPruned = false
 }
 
+// RemoveDocument removes a previously indexed document from the corpus, without
+// having to [Corpus.Reset] and re-index all other documents. The text is
+// re-tokenized to determine which terms to remove, so the exact same text that
+// was provided to [Corpus.IndexDocument] (or [Corpus.IndexDocuments]) must be
+// supplied, otherwise the term frequencies will be incorrect. Terms which no
+// longer appear in any document are removed from the corpus. Terms which were
+// already pruned are ignored.
+//
+// This is concurrent-safe.
+func (c *Corpus) RemoveDocument(text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.documents == 0 {
+		return
+	}
+
+	seenTerms := c.seenTermPool.Get()
+	defer c.seenTermPool.Put(seenTerms)
+
+	for term := range c.tokenize(text) {
+		if _, ok := seenTerms[term]; ok {
+			continue
+		}
+		seenTerms[term] = struct{}{}
+
+		freq, ok := c.termFreq[term]
+		if !ok {
+			continue
+		}
+		if freq <= 1 {
+			delete(c.termFreq, term)
+			if !c.frozen {
+				c.termIndex.Remove(term)
+			}
+			continue
+		}
+		c.termFreq[term] = freq - 1
+	}
+	c.removeCooccurrence(seenTerms)
+	c.documents--
+	c.hasPruned = false
+}
+
+// IndexReader indexes a single document read from r, tokenizing it incrementally
+// so the document never has to be fully loaded into memory. This is useful for
+// very large documents, like log files. The document is tokenized using a
+// streaming variant of [DefaultTokenizer] (any tokenizer provided through
+// [WithTokenizer] is not used), after which all term filters are applied as
+// usual. If reading fails, the document is not indexed, and the error is
+// returned.
+//
+// This is concurrent-safe.
+func (c *Corpus) IndexReader(r io.Reader) error {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	seenTerms := c.seenTermPool.Get()
+	defer c.seenTermPool.Put(seenTerms)
+
+	var err error
+	seq := readerTokenizer(br, &err)
+	for _, filter := range c.termFilters {
+		seq = filter(seq)
+	}
+	for term := range seq {
+		seenTerms[term] = struct{}{}
+	}
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for term := range seenTerms {
+		c.addTerm(term, 1)
+	}
+	c.addCooccurrence(seenTerms)
+	c.documents++
+	c.hasPruned = false
+	return nil
+}
+
+// IndexDocuments indexes multiple documents, the same as calling
+// [Corpus.IndexDocument] for each document. If [WithConcurrentIndexing] is used,
+// documents are tokenized concurrently, and the results are merged into the
+// corpus once all documents have been tokenized. The resulting corpus is the
+// same regardless of the number of workers.
+//
+// This is concurrent-safe.
+func (c *Corpus) IndexDocuments(texts ...string) {
+	workers := min(c.indexWorkers, len(texts))
+	if workers <= 1 {
+		for _, text := range texts {
+			c.IndexDocument(text)
+		}
+		return
+	}
+
+	c.mu.RLock()
+	track := c.cooccurrence != nil
+	c.mu.RUnlock()
+
+	// Each worker tokenizes every n-th document into its own partial term frequency
+	// map, so no locking is needed until the merge.
+	partials := make([]map[string]int, workers)
+	docTerms := make([]map[string]struct{}, len(texts)) // Only used for co-occurrence.
+	sem := conc.NewSemaphore(workers)
+	for i := range workers {
+		sem.Go(func() {
+			seenTerms := c.seenTermPool.Get()
+			defer c.seenTermPool.Put(seenTerms)
+
+			termFreq := make(map[string]int)
+			for j := i; j < len(texts); j += workers {
+				clear(seenTerms)
+				for term := range c.tokenize(texts[j]) {
+					if _, ok := seenTerms[term]; !ok {
+						termFreq[term]++
+						seenTerms[term] = struct{}{}
+					}
+				}
+				if track {
+					docTerms[j] = maps.Clone(seenTerms)
+				}
+			}
+			partials[i] = termFreq
+		})
+	}
+	sem.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, termFreq := range partials {
+		for term, freq := range termFreq {
+			c.addTerm(term, freq)
+		}
+	}
+	for _, terms := range docTerms {
+		c.addCooccurrence(terms)
+	}
+	c.documents += len(texts)
+	c.hasPruned = false
+}
+
 // GetTermFrequency returns a snapshot of the term frequencies. Note that
 // because [CreateVector] calls [Corpus.Prune] before creating vectors, if you
 // invoke this before [CreateVector], you will receive terms that might not have
@@ -170,31 +432,37 @@ func (c *Corpus) CreateVector(text string) []float32 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	return c.vectorize(text, c.termFreq, c.termIndex.All(), c.documents)
+}
+
+// vectorize creates a TF-IDF vector for the given text, using the provided corpus
+// state (term frequencies, sorted terms, and document count). Caller must either
+// hold [Corpus.mu], or provide state which isn't shared with the live corpus.
+func (c *Corpus) vectorize(text string, corpusFreq map[string]int, terms []string, documents int) []float32 {
 	// Count terms in this document.
 	termFreq := c.termFreqPool.Get()
 	defer c.termFreqPool.Put(termFreq)
 
-	totalTerms := 0
+	totalTerms, maxCount := 0, 0
 
 	for term := range c.tokenize(text) {
 		termFreq[term]++
 		totalTerms++
+		maxCount = max(maxCount, termFreq[term])
 	}
 
-	// Create TF-IDF vector.
-	//
-	// This is not 1-for-1 how TF-IDF is normally calculated, but there are a few good reasons:
-	// 1. We want to avoid extreme values -- add 1 to the value of IDF to avoid completely ignoring
-	//    terms that occur in all documents.
-	// 2. Some implementations use IDF smoothing, which prevents division by zero. Don't really need
-	//    that here.
-	//
-	// This follows patterns by Python libraries like scikit-learn.
-	vector := make([]float32, min(len(c.termIndex.All()), c.maxVectorSize))
-	for i, term := range c.termIndex.All()[:len(vector)] {
-		tf := float32(termFreq[term]) / float32(totalTerms)
-		idf := math32.Log(float32(c.documents)/float32(c.termFreq[term])) + 1
-		vector[i] = tf * idf
+	// Create TF-IDF vector. See [DefaultTF] and [DefaultIDF] for details on the
+	// defaults, and [WithTermWeights] for the weights.
+	vector := make([]float32, min(len(terms), c.maxVectorSize))
+	for i, term := range terms[:len(vector)] {
+		// Terms of a frozen vocabulary may no longer appear in any document.
+		if termFreq[term] == 0 || corpusFreq[term] == 0 {
+			continue
+		}
+		vector[i] = c.tf(termFreq[term], totalTerms, maxCount) * c.idf(documents, corpusFreq[term])
+		if weight, ok := c.termWeights[term]; ok {
+			vector[i] *= weight
+		}
 	}
 
 	// Normalize vector.
@@ -212,6 +480,36 @@ func (c *Corpus) CreateVector(text string) []float32 {
 	return vector
 }
 
+// Similarity returns the cosine similarity (see [CosineSimilarity]) between the
+// TF-IDF vectors of textA and textB, e.g. to find duplicate or near-duplicate
+// documents. Both vectors are created against the same state of the corpus.
+// Returns 0 if either text doesn't match any terms in the corpus.
+//
+// This will automatically call [Corpus.Prune] if there are any new documents that
+// have been indexed since the last prune.
+//
+// This is concurrent-safe.
+func (c *Corpus) Similarity(textA, textB string) float32 {
+	c.Prune()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	terms := c.termIndex.All()
+	return CosineSimilarity(
+		c.vectorize(textA, c.termFreq, terms, c.documents),
+		c.vectorize(textB, c.termFreq, terms, c.documents),
+	)
+}
+
+// padVector pads the vector with zeros, up to the maximum vector size.
+func (c *Corpus) padVector(vector []float32) []float32 {
+	if len(vector) < c.maxVectorSize {
+		vector = append(vector, make([]float32, c.maxVectorSize-len(vector))...)
+	}
+	return vector
+}
+
 // CreatePaddedVector creates a vector with the maximum potential vector size,
 // padding with zeros if the vector is smaller. Not needed unless the graph you
 // use to compare vectors does not support sparse vectors, as it will use more
@@ -219,11 +517,7 @@ func (c *Corpus) CreateVector(text string) []float32 {
 //
 // This is concurrent-safe.
 func (c *Corpus) CreatePaddedVector(text string) []float32 {
-	vector := c.CreateVector(text)
-	if len(vector) < c.maxVectorSize {
-		vector = append(vector, make([]float32, c.maxVectorSize-len(vector))...)
-	}
-	return vector
+	return c.padVector(c.CreateVector(text))
 }
 
 // tokenize is a helper function that applies the term filters (if any) to the
@@ -246,6 +540,33 @@ func IsNoMatchVector(vector []float32) bool {
 	return true
 }
 
+// CosineSimilarity returns the cosine similarity between vectors a and b, from 0
+// (no terms in common) to 1 (identical), for vectors created by the same corpus.
+// Vectors of different lengths (e.g. unpadded vectors, created before and after
+// more terms were indexed) are compared as if the shorter vector was padded with
+// zeros. Returns 0 if either vector doesn't match any terms (see
+// [IsNoMatchVector]).
+func CosineSimilarity(a, b []float32) float32 {
+	var dot, magA, magB float32
+	for i := range max(len(a), len(b)) {
+		var va, vb float32
+		if i < len(a) {
+			va = a[i]
+		}
+		if i < len(b) {
+			vb = b[i]
+		}
+		dot += va * vb
+		magA += va * va
+		magB += vb * vb
+	}
+
+	if magA == 0 || magB == 0 {
+		return 0
+	}
+	return dot / (math32.Sqrt(magA) * math32.Sqrt(magB))
+}
+
 // VectorSubCount returns the number of non-zero values in the vector.
 func VectorSubCount(vector []float32) (count int) {
 	for _, val := range vector {
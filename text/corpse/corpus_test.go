@@ -5,6 +5,12 @@
 package corpse
 
 import (
+	"errors"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -78,6 +84,271 @@ func TestCorpus(t *testing.T) {
 	}
 }
 
+func TestCorpus_WithIDFFunc(t *testing.T) {
+	texts := make([]string, 0, len(sampleData))
+	for _, s := range sampleData {
+		texts = append(texts, s.text)
+	}
+
+	def := New()
+	def.IndexDocuments(texts...)
+	explicit := New(WithIDFFunc(DefaultIDF))
+	explicit.IndexDocuments(texts...)
+
+	if !slices.Equal(def.CreateVector(texts[0]), explicit.CreateVector(texts[0])) {
+		t.Fatal("expected explicit DefaultIDF to match the default vector")
+	}
+
+	for name, fn := range map[string]IDFFunc{
+		"raw":           RawIDF,
+		"smooth":        SmoothIDF,
+		"probabilistic": ProbabilisticIDF,
+		"custom":        func(_, docFreq int) float32 { return 1 / float32(docFreq*docFreq) },
+	} {
+		corp := New(WithIDFFunc(fn))
+		corp.IndexDocuments(texts...)
+
+		if slices.Equal(corp.CreateVector(texts[0]), def.CreateVector(texts[0])) {
+			t.Errorf("%s: expected vector to differ from the default", name)
+		}
+	}
+}
+
+func TestIDFFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   IDFFunc
+		docs int
+		df   int
+		want float32
+	}{
+		{name: "default-all", fn: DefaultIDF, docs: 10, df: 10, want: 1},
+		{name: "raw-all", fn: RawIDF, docs: 10, df: 10, want: 0},
+		{name: "smooth-all", fn: SmoothIDF, docs: 10, df: 10, want: 1},
+		{name: "probabilistic-half", fn: ProbabilisticIDF, docs: 10, df: 5, want: 0},
+		{name: "probabilistic-most", fn: ProbabilisticIDF, docs: 10, df: 8, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.docs, tt.df); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if RawIDF(10, 1) <= RawIDF(10, 2) || SmoothIDF(10, 1) <= SmoothIDF(10, 2) || ProbabilisticIDF(10, 1) <= ProbabilisticIDF(10, 2) {
+		t.Error("expected rarer terms to have a higher IDF")
+	}
+}
+
+func TestCorpus_WithTFFunc(t *testing.T) {
+	texts := []string{
+		"apple apple apple banana",
+		"banana cherry",
+		"cherry durian",
+	}
+
+	def := New()
+	def.IndexDocuments(texts...)
+	explicit := New(WithTFFunc(DefaultTF))
+	explicit.IndexDocuments(texts...)
+
+	vector := def.CreateVector(texts[0])
+	if !slices.Equal(vector, explicit.CreateVector(texts[0])) {
+		t.Fatal("expected explicit DefaultTF to match the default vector")
+	}
+
+	// The default should still be the raw "count/total" ratio. Terms are sorted:
+	// apple, banana, cherry, durian.
+	apple := 3.0 / 4 * DefaultIDF(3, 1)
+	banana := 1.0 / 4 * DefaultIDF(3, 2)
+	magnitude := math.Sqrt(float64(apple*apple + banana*banana))
+	if math.Abs(float64(vector[0])-float64(apple)/magnitude) > 1e-6 ||
+		math.Abs(float64(vector[1])-float64(banana)/magnitude) > 1e-6 {
+		t.Fatalf("default vector = %v, want raw frequency ratio", vector)
+	}
+
+	for name, fn := range map[string]TFFunc{
+		"log":       LogTF,
+		"augmented": AugmentedTF,
+	} {
+		corp := New(WithTFFunc(fn))
+		corp.IndexDocuments(texts...)
+
+		got := corp.CreateVector(texts[0])
+		if slices.Equal(got, vector) {
+			t.Errorf("%s: expected vector to differ from the default", name)
+		}
+
+		// Both dampen repeated terms, relative to the raw ratio.
+		if got[0]/got[1] >= vector[0]/vector[1] {
+			t.Errorf("%s: expected repeated term to be dampened, got %v, default %v", name, got, vector)
+		}
+	}
+}
+
+func TestTFFuncs(t *testing.T) {
+	tests := []struct {
+		name                   string
+		fn                     TFFunc
+		count, total, maxCount int
+		want                   float32
+	}{
+		{name: "default", fn: DefaultTF, count: 2, total: 8, maxCount: 4, want: 0.25},
+		{name: "log-once", fn: LogTF, count: 1, total: 8, maxCount: 4, want: 1},
+		{name: "augmented-max", fn: AugmentedTF, count: 4, total: 8, maxCount: 4, want: 1},
+		{name: "augmented-half", fn: AugmentedTF, count: 2, total: 8, maxCount: 4, want: 0.75},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.count, tt.total, tt.maxCount); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if LogTF(3, 8, 4) <= LogTF(2, 8, 4) {
+		t.Error("expected more frequent terms to have a higher TF")
+	}
+}
+
+func TestCorpus_IndexDocuments(t *testing.T) {
+	texts := make([]string, 0, len(sampleData)*10)
+	for range 10 {
+		for _, s := range sampleData {
+			texts = append(texts, s.text)
+		}
+	}
+
+	want := New()
+	for _, text := range texts {
+		want.IndexDocument(text)
+	}
+
+	for _, workers := range []int{0, 1, 2, 3, 8, 100} {
+		corp := New(WithConcurrentIndexing(workers))
+		corp.IndexDocuments(texts...)
+
+		if got := corp.GetDocumentCount(); got != want.GetDocumentCount() {
+			t.Errorf("workers=%d: document count = %d, want %d", workers, got, want.GetDocumentCount())
+		}
+		if !maps.Equal(corp.GetTermFrequency(), want.GetTermFrequency()) {
+			t.Errorf("workers=%d: term frequency mismatch", workers)
+		}
+		if !slices.Equal(corp.termIndex.All(), want.termIndex.All()) {
+			t.Errorf("workers=%d: term index = %v, want %v", workers, corp.termIndex.All(), want.termIndex.All())
+		}
+		if !slices.Equal(corp.CreateVector("yellow fox"), want.CreateVector("yellow fox")) {
+			t.Errorf("workers=%d: vector mismatch", workers)
+		}
+	}
+}
+
+func TestCorpus_RemoveDocument(t *testing.T) {
+	// Removing a document should result in the same state as never indexing it.
+	want := New()
+	corp := New()
+	for i, s := range sampleData {
+		corp.IndexDocument(s.text)
+		if i != 1 {
+			want.IndexDocument(s.text)
+		}
+	}
+
+	corp.CreateVector("fox") // Prune.
+	corp.RemoveDocument(sampleData[1].text)
+
+	if corp.hasPruned {
+		t.Error("expected corpus to require pruning after removal")
+	}
+	if got := corp.GetDocumentCount(); got != want.GetDocumentCount() {
+		t.Errorf("document count = %d, want %d", got, want.GetDocumentCount())
+	}
+	if !maps.Equal(corp.GetTermFrequency(), want.GetTermFrequency()) {
+		t.Errorf("term frequency = %v, want %v", corp.GetTermFrequency(), want.GetTermFrequency())
+	}
+	if !slices.Equal(corp.termIndex.All(), want.termIndex.All()) {
+		t.Errorf("term index = %v, want %v", corp.termIndex.All(), want.termIndex.All())
+	}
+
+	// Removing from an empty corpus is a no-op.
+	empty := New()
+	empty.RemoveDocument(sampleData[0].text)
+	if got := empty.GetDocumentCount(); got != 0 {
+		t.Errorf("document count = %d, want 0", got)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestCorpus_IndexReader(t *testing.T) {
+	filters := WithTermFilters(StopTermFilter([]string{"the"}))
+
+	want := New(filters)
+	got := New(filters)
+	for _, s := range sampleData {
+		want.IndexDocument(s.text)
+		if err := got.IndexReader(strings.NewReader(s.text)); err != nil {
+			t.Fatalf("IndexReader(%q): %v", s.id, err)
+		}
+	}
+
+	if got.GetDocumentCount() != want.GetDocumentCount() {
+		t.Errorf("document count = %d, want %d", got.GetDocumentCount(), want.GetDocumentCount())
+	}
+	if !maps.Equal(got.GetTermFrequency(), want.GetTermFrequency()) {
+		t.Errorf("term frequency = %v, want %v", got.GetTermFrequency(), want.GetTermFrequency())
+	}
+
+	if err := got.IndexReader(io.MultiReader(strings.NewReader("partial doc "), errReader{})); err == nil {
+		t.Fatal("expected error from failing reader")
+	}
+	if got.GetDocumentCount() != want.GetDocumentCount() {
+		t.Error("failed read should not index the document")
+	}
+}
+
+func TestCorpus_WithCapacityHook(t *testing.T) {
+	var calls []int
+	var corp *Corpus
+	corp = New(
+		WithMaxVectorSize(10),
+		WithCapacityHook(80, func(percent int) {
+			// Querying the corpus must not deadlock.
+			if got := corp.GetUsedCapacity(); got != percent {
+				t.Errorf("GetUsedCapacity() = %d, want %d", got, percent)
+			}
+			calls = append(calls, percent)
+		}),
+	)
+
+	corp.IndexDocument("one two three four five")
+	corp.Prune()
+	if len(calls) != 0 {
+		t.Fatalf("hook fired below threshold: %v", calls)
+	}
+
+	corp.IndexDocument("six seven eight")
+	corp.Prune()
+	if len(calls) != 1 || calls[0] != 80 {
+		t.Fatalf("hook calls = %v, want [80]", calls)
+	}
+
+	// Already above threshold, should not fire again.
+	corp.IndexDocument("nine ten eleven")
+	corp.CreateVector("one")
+	if len(calls) != 1 {
+		t.Fatalf("hook calls = %v, want a single call", calls)
+	}
+
+	corp.Reset()
+	corp.IndexDocuments("a b c d e f g h")
+	corp.Prune()
+	if len(calls) != 2 {
+		t.Fatalf("hook calls = %v, want a second call after reset", calls)
+	}
+}
+
 func BenchmarkCorpus(b *testing.B) {
 	query := "yellow fox"
 	corp := New()
@@ -91,6 +362,65 @@ func BenchmarkCorpus(b *testing.B) {
 	}
 }
 
+func TestCorpus_Freeze(t *testing.T) {
+	corp := New(WithPruneHooks(PruneLessThanPercent(0)))
+	for _, s := range sampleData[:2] {
+		corp.IndexDocument(s.text)
+	}
+
+	corp.Freeze()
+	if !corp.IsFrozen() {
+		t.Fatal("expected corpus to be frozen")
+	}
+
+	vocab := corp.Vocabulary()
+	if len(vocab) == 0 || !slices.IsSorted(vocab) {
+		t.Fatalf("expected sorted non-empty vocabulary, got %v", vocab)
+	}
+	before := corp.CreateVector(sampleData[0].text)
+
+	// Neither new terms, nor removing all documents containing a term, can shift
+	// the vocabulary.
+	for _, s := range sampleData[2:] {
+		corp.IndexDocument(s.text)
+	}
+	corp.IndexDocuments("aardvark the zebra", "a brand new vocabulary")
+	if err := corp.IndexReader(strings.NewReader("another aardvark")); err != nil {
+		t.Fatal(err)
+	}
+	corp.RemoveDocument(sampleData[1].text)
+
+	if got := corp.Vocabulary(); !slices.Equal(got, vocab) {
+		t.Fatalf("vocabulary shifted after freeze:\n%v\nwant:\n%v", got, vocab)
+	}
+	if _, ok := corp.GetTermFrequency()["aardvark"]; ok {
+		t.Fatal("expected new terms to be ignored after freeze")
+	}
+	if _, ok := corp.GetTermFrequency()["yellow"]; ok {
+		t.Fatal("expected frequency of removed term to be dropped")
+	}
+
+	after := corp.CreateVector(sampleData[0].text)
+	if len(after) != len(before) {
+		t.Fatalf("vector length changed from %d to %d", len(before), len(after))
+	}
+	for i, term := range vocab {
+		if (before[i] == 0) != (after[i] == 0) {
+			t.Errorf("position %d (%q) changed from %v to %v", i, term, before[i], after[i])
+		}
+	}
+	for _, v := range corp.CreateVector(sampleData[1].text) {
+		if math.IsNaN(float64(v)) {
+			t.Fatal("expected no NaN values for terms no longer in any document")
+		}
+	}
+
+	corp.Reset()
+	if corp.IsFrozen() {
+		t.Fatal("expected Reset to unfreeze the corpus")
+	}
+}
+
 func TestIsNoMatchVector(t *testing.T) {
 	corp := New()
 	for _, s := range sampleData {
@@ -129,3 +459,105 @@ func TestIsNoMatchVector(t *testing.T) {
 		})
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float32
+	}{
+		{name: "identical", a: []float32{0.6, 0.8}, b: []float32{0.6, 0.8}, want: 1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "unnormalized", a: []float32{3, 4}, b: []float32{6, 8}, want: 1},
+		{name: "different-lengths", a: []float32{1}, b: []float32{1, 0, 0}, want: 1},
+		{name: "no-match", a: []float32{0, 0}, b: []float32{1, 0}, want: 0},
+		{name: "empty", a: nil, b: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(float64(got-tt.want)) > 1e-6 {
+				t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCorpus_Similarity(t *testing.T) {
+	corp := New()
+	for _, s := range sampleData {
+		corp.IndexDocument(s.text)
+	}
+
+	text := sampleData[0].text
+	if got := corp.Similarity(text, text); math.Abs(float64(got-1)) > 1e-6 {
+		t.Errorf("Similarity(text, text) = %v, want 1", got)
+	}
+
+	if got := corp.Similarity(text, "xyzabc123"); got != 0 {
+		t.Errorf("Similarity(text, no-match) = %v, want 0", got)
+	}
+
+	if got := corp.Similarity("xyzabc123", "xyzabc123"); got != 0 {
+		t.Errorf("Similarity(no-match, no-match) = %v, want 0", got)
+	}
+
+	want := CosineSimilarity(corp.CreateVector("lorem ipsum"), corp.CreateVector("lorem dolor"))
+	if got := corp.Similarity("lorem ipsum", "lorem dolor"); got != want {
+		t.Errorf("Similarity() = %v, want %v (from CreateVector)", got, want)
+	}
+	if want <= 0 || want >= 1 {
+		t.Errorf("Similarity() = %v, want a partial match between 0 and 1", want)
+	}
+}
+
+func TestCorpus_WithTermWeights(t *testing.T) {
+	texts := []string{
+		"disk error e1234 occurred",
+		"network error timeout",
+		"disk full warning",
+	}
+
+	def := New()
+	def.IndexDocuments(texts...)
+	weighted := New(WithTermWeights(map[string]float32{"e1234": 3, "occurred": 0}))
+	weighted.IndexDocuments(texts...)
+
+	vocab := def.Vocabulary()
+	index := func(term string) int {
+		t.Helper()
+		i := slices.Index(vocab, term)
+		if i < 0 {
+			t.Fatalf("term %q not in vocabulary %v", term, vocab)
+		}
+		return i
+	}
+
+	want := def.CreateVector(texts[0])
+	got := weighted.CreateVector(texts[0])
+
+	code, disk, occurred := index("e1234"), index("disk"), index("occurred")
+	if got[code] <= want[code] {
+		t.Fatalf("boosted component = %v, want more than default %v", got[code], want[code])
+	}
+	if got[code]/got[disk] <= want[code]/want[disk]*2.99 {
+		t.Fatalf("boosted ratio = %v, want 3x default ratio %v", got[code]/got[disk], want[code]/want[disk])
+	}
+	if got[occurred] != 0 {
+		t.Fatalf("zero-weighted component = %v, want 0", got[occurred])
+	}
+
+	// Vectors are still normalized.
+	var magnitude float64
+	for _, v := range got {
+		magnitude += float64(v * v)
+	}
+	if math.Abs(magnitude-1) > 1e-5 {
+		t.Fatalf("magnitude = %v, want 1", magnitude)
+	}
+
+	// Texts without weighted terms are unaffected.
+	if !slices.Equal(def.CreateVector(texts[1]), weighted.CreateVector(texts[1])) {
+		t.Fatal("expected unweighted terms to match the default vector")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"maps"
+	"slices"
+)
+
+// CorpusDebug is a point-in-time dump of the internal state of a [Corpus], for
+// debugging purposes (e.g. why two documents vectorize unexpectedly). It can be
+// marshalled to JSON as-is. See [Corpus.DebugDump].
+type CorpusDebug struct {
+	// TermFrequency is how many times each term appears across all documents.
+	TermFrequency map[string]int `json:"term_frequency"`
+
+	// Vocabulary is all terms of the corpus, in vector order. Unlike
+	// [Corpus.Vocabulary], this includes terms beyond MaxVectorSize, which aren't
+	// part of vectors.
+	Vocabulary []string `json:"vocabulary"`
+
+	// Documents is the number of documents that have been indexed.
+	Documents int `json:"documents"`
+
+	// MaxVectorSize is the max vector size of the corpus (see [WithMaxVectorSize]).
+	MaxVectorSize int `json:"max_vector_size"`
+
+	// UsedCapacity is the percentage of the corpus capacity that is used (see
+	// [Corpus.GetUsedCapacity]).
+	UsedCapacity int `json:"used_capacity_percent"`
+
+	// HasPruned is true if the corpus has been pruned since the last document was
+	// indexed (see [Corpus.Prune]).
+	HasPruned bool `json:"has_pruned"`
+
+	// Frozen is true if the vocabulary is frozen (see [Corpus.Freeze]).
+	Frozen bool `json:"frozen"`
+}
+
+// DebugDump returns a dump of the internal state of the corpus, captured under
+// a single lock, so all fields are consistent with each other. Unlike
+// [Corpus.Snapshot], this never prunes the corpus, so the dump reflects the
+// state as-is (including terms which haven't been pruned yet, see
+// [CorpusDebug.HasPruned]).
+//
+// This is concurrent-safe.
+func (c *Corpus) DebugDump() CorpusDebug {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return CorpusDebug{
+		TermFrequency: maps.Clone(c.termFreq),
+		Vocabulary:    slices.Clone(c.termIndex.All()),
+		Documents:     c.documents,
+		MaxVectorSize: c.maxVectorSize,
+		UsedCapacity:  c.usedCapacity(),
+		HasPruned:     c.hasPruned,
+		Frozen:        c.frozen,
+	}
+}
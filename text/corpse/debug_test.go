@@ -0,0 +1,66 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCorpus_DebugDump(t *testing.T) {
+	corp := New(WithMaxVectorSize(4))
+	corp.IndexDocuments("apple banana cherry", "banana cherry date elderberry")
+
+	dump := corp.DebugDump()
+	if dump.Documents != 2 {
+		t.Fatalf("documents = %d, want 2", dump.Documents)
+	}
+	if dump.MaxVectorSize != 4 {
+		t.Fatalf("max vector size = %d, want 4", dump.MaxVectorSize)
+	}
+	if dump.HasPruned {
+		t.Fatal("has pruned = true, want false before pruning")
+	}
+	if dump.Frozen {
+		t.Fatal("frozen = true, want false")
+	}
+	if want := corp.GetTermFrequency(); !maps.Equal(dump.TermFrequency, want) {
+		t.Fatalf("term frequency = %v, want %v", dump.TermFrequency, want)
+	}
+	if want := []string{"apple", "banana", "cherry", "date", "elderberry"}; !slices.Equal(dump.Vocabulary, want) {
+		t.Fatalf("vocabulary = %v, want %v", dump.Vocabulary, want)
+	}
+	if dump.UsedCapacity != 125 {
+		t.Fatalf("used capacity = %d, want 125", dump.UsedCapacity)
+	}
+
+	// The dump is a copy.
+	dump.TermFrequency["apple"] = 100
+	if corp.GetTermFrequency()["apple"] == 100 {
+		t.Fatal("modifying the dump modified the corpus")
+	}
+
+	corp.Freeze()
+	dump = corp.DebugDump()
+	if !dump.HasPruned || !dump.Frozen {
+		t.Fatalf("has pruned = %v, frozen = %v, want both true", dump.HasPruned, dump.Frozen)
+	}
+
+	b, err := json.Marshal(dump)
+	if err != nil {
+		t.Fatalf("failed to marshal dump: %v", err)
+	}
+
+	var got CorpusDebug
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal dump: %v", err)
+	}
+	if !maps.Equal(got.TermFrequency, dump.TermFrequency) || !slices.Equal(got.Vocabulary, dump.Vocabulary) ||
+		got.Documents != dump.Documents || got.UsedCapacity != dump.UsedCapacity {
+		t.Fatalf("round-tripped dump = %+v, want %+v", got, dump)
+	}
+}
@@ -5,9 +5,17 @@
 package corpse
 
 import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io"
 	"iter"
+	"maps"
+	"slices"
 	"strings"
 	"unicode"
+
+	"github.com/chewxy/math32"
 )
 
 type Option func(*Corpus)
@@ -19,6 +27,138 @@ func WithMaxVectorSize(size int) Option {
 	}
 }
 
+// WithConcurrentIndexing makes [Corpus.IndexDocuments] tokenize documents across
+// the given number of workers. Values less than 2 disable concurrent indexing,
+// which is the default. Note that tokenizers and term filters must be
+// concurrent-safe when this is used.
+func WithConcurrentIndexing(workers int) Option {
+	return func(c *Corpus) {
+		c.indexWorkers = workers
+	}
+}
+
+// WithCooccurrence enables tracking how many documents each pair of terms appear
+// in together, which is required for [Corpus.ExpandQuery]. This is opt-in, as it
+// is expensive: indexing a document with k unique terms costs O(k²), and memory
+// grows with the number of distinct pairs of terms (up to the square of the
+// number of terms in the corpus, stored in both directions). Consider using term
+// filters (e.g. [StopTermFilter]) and prune hooks to keep the number of terms
+// small. Pruned terms are removed from the co-occurrence counts.
+func WithCooccurrence() Option {
+	return func(c *Corpus) {
+		c.cooccurrence = make(map[string]map[string]int)
+	}
+}
+
+// WithCapacityHook sets a hook which is invoked by [Corpus.Prune] (and as such,
+// [Corpus.CreateVector]) when the used capacity (see [Corpus.GetUsedCapacity])
+// crosses the given threshold percentage. This can be used to log a warning, or
+// to re-vectorize with a larger max vector size. The hook fires once when crossing
+// the threshold, and again only if capacity drops below the threshold and then
+// crosses it again. The hook is invoked outside of any locks, so it is safe to
+// query the corpus from within it.
+func WithCapacityHook(threshold int, fn func(percent int)) Option {
+	return func(c *Corpus) {
+		c.capacityThreshold = threshold
+		c.capacityHook = fn
+	}
+}
+
+// WithTermWeights sets weights for specific terms, which multiply the TF-IDF
+// value of the term within vectors (before normalization), e.g. to make domain
+// specific terms (like error codes) carry more weight, or less weight, regardless
+// of how often they appear. Terms which aren't listed have a weight of 1, and a
+// weight of 0 effectively removes the term from vectors. Terms must match the
+// output of the tokenizer and term filters (e.g. lowercased or stemmed).
+func WithTermWeights(weights map[string]float32) Option {
+	return func(c *Corpus) {
+		c.termWeights = maps.Clone(weights)
+	}
+}
+
+// TFFunc calculates the term frequency (TF) of a term within a document, given
+// the number of times the term appears in the document (always at least 1), the
+// total number of terms in the document, and the number of times the most
+// frequent term appears in the document.
+type TFFunc func(count, total, maxCount int) float32
+
+// WithTFFunc sets the function used to calculate the TF of each term in
+// [Corpus.CreateVector]. Defaults to [DefaultTF]. See also [LogTF] and
+// [AugmentedTF].
+func WithTFFunc(fn TFFunc) Option {
+	return func(c *Corpus) {
+		if fn != nil {
+			c.tf = fn
+		}
+	}
+}
+
+// DefaultTF is the default [TFFunc], which calculates the raw frequency ratio
+// "count/total".
+func DefaultTF(count, total, _ int) float32 {
+	return float32(count) / float32(total)
+}
+
+// LogTF is a [TFFunc] which calculates "1 + log(count)", which dampens the weight
+// of terms which are repeated many times within a document.
+func LogTF(count, _, _ int) float32 {
+	return 1 + math32.Log(float32(count))
+}
+
+// AugmentedTF is a [TFFunc] which calculates "0.5 + 0.5*count/maxCount", which
+// prevents a bias towards longer documents, as the frequency is relative to the
+// most frequent term in the document, rather than the document length.
+func AugmentedTF(count, _, maxCount int) float32 {
+	return 0.5 + 0.5*float32(count)/float32(maxCount)
+}
+
+// IDFFunc calculates the inverse document frequency (IDF) of a term, given the
+// total number of documents, and the number of documents the term appears in
+// (always at least 1).
+type IDFFunc func(documents, docFreq int) float32
+
+// WithIDFFunc sets the function used to calculate the IDF of each term in
+// [Corpus.CreateVector]. Defaults to [DefaultIDF]. See also [RawIDF], [SmoothIDF]
+// and [ProbabilisticIDF].
+func WithIDFFunc(fn IDFFunc) Option {
+	return func(c *Corpus) {
+		if fn != nil {
+			c.idf = fn
+		}
+	}
+}
+
+// DefaultIDF is the default [IDFFunc], which calculates "log(N/df) + 1". Adding 1
+// avoids completely ignoring terms that occur in all documents. Smoothing (which
+// prevents division by zero) isn't needed, as only indexed terms are weighted.
+// This follows patterns by Python libraries like scikit-learn.
+func DefaultIDF(documents, docFreq int) float32 {
+	return RawIDF(documents, docFreq) + 1
+}
+
+// RawIDF is an [IDFFunc] which calculates the textbook "log(N/df)". Terms which
+// occur in all documents have an IDF of 0, and as such are ignored.
+func RawIDF(documents, docFreq int) float32 {
+	return math32.Log(float32(documents) / float32(docFreq))
+}
+
+// SmoothIDF is an [IDFFunc] which calculates "log((1+N)/(1+df)) + 1", as if an
+// extra document containing every term was indexed. This is the default used by
+// scikit-learn, and dampens the weight of very rare terms.
+func SmoothIDF(documents, docFreq int) float32 {
+	return math32.Log(float32(1+documents)/float32(1+docFreq)) + 1
+}
+
+// ProbabilisticIDF is an [IDFFunc] which calculates "log((N-df)/df)". Terms
+// which occur in half or more of all documents have an IDF of 0 (rather than a
+// negative weight), and as such are ignored.
+func ProbabilisticIDF(documents, docFreq int) float32 {
+	if documents-docFreq <= docFreq {
+		return 0
+	}
+	return math32.Log(float32(documents-docFreq) / float32(docFreq))
+}
+
 type Tokenizer func(text string) iter.Seq[string]
 
 func WithTokenizer(tokenizer Tokenizer) Option {
@@ -46,6 +186,82 @@ func DefaultTokenizer(text string) iter.Seq[string] {
 	}
 }
 
+// DefaultJoiningRunes are the runes kept within a token by [CodeTokenizer] when
+// no joining runes are provided, e.g. "kube-system", "v1.2.3" and "max_retries".
+const DefaultJoiningRunes = "-._"
+
+// CodeTokenizer returns a [Tokenizer] which, like [DefaultTokenizer], splits on
+// whitespace and punctuation, however any of the joining runes are kept when they
+// occur between two letters or numbers. This keeps identifiers, hostnames and
+// version strings intact (useful for log and config corpora), e.g. with
+// [DefaultJoiningRunes], "kube-system v1.2.3." is tokenized into "kube-system"
+// and "v1.2.3". Leading, trailing and repeated joining runes still split tokens.
+// If joining is empty, [DefaultJoiningRunes] is used.
+func CodeTokenizer(joining string) Tokenizer {
+	if joining == "" {
+		joining = DefaultJoiningRunes
+	}
+
+	return func(text string) iter.Seq[string] {
+		return func(yield func(string) bool) {
+			var token strings.Builder
+			var pending rune // Joining rune which may be kept, if followed by a letter/number.
+			for _, r := range strings.ToLower(text) {
+				switch {
+				case unicode.IsLetter(r) || unicode.IsNumber(r):
+					if pending != 0 {
+						token.WriteRune(pending)
+						pending = 0
+					}
+					token.WriteRune(r)
+				case token.Len() > 0 && pending == 0 && strings.ContainsRune(joining, r):
+					pending = r
+				case token.Len() > 0:
+					if !yield(token.String()) {
+						return
+					}
+					token.Reset()
+					pending = 0
+				}
+			}
+			if token.Len() > 0 {
+				yield(token.String())
+			}
+		}
+	}
+}
+
+// readerTokenizer is a streaming variant of [DefaultTokenizer], which reads runes
+// from r until EOF. Any non-EOF read error stops iteration and is stored in err.
+func readerTokenizer(r *bufio.Reader, err *error) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var token strings.Builder
+		for {
+			ch, _, rerr := r.ReadRune()
+			if rerr != nil {
+				if !errors.Is(rerr, io.EOF) {
+					*err = rerr
+					return
+				}
+				break
+			}
+
+			ch = unicode.ToLower(ch)
+			if unicode.IsLetter(ch) || unicode.IsNumber(ch) {
+				token.WriteRune(ch)
+			} else if token.Len() > 0 {
+				if !yield(token.String()) {
+					return
+				}
+				token.Reset()
+			}
+		}
+		if token.Len() > 0 {
+			yield(token.String())
+		}
+	}
+}
+
 type TermFilter func(iter.Seq[string]) iter.Seq[string]
 
 // TermFilterFunc is a helper function that creates a TermFilter from a function
@@ -126,13 +342,27 @@ type PruneHook func(documents int, termFreq map[string]int) (toRemove []string)
 
 // WithPruneHooks allows adding hooks, which are ran before vectorization, that remove
 // terms from the corpus. This can be used to remove terms that are either in too
-// few documents, or too many documents, to reduce the sizze of the corpus.
+// few documents, or too many documents, to reduce the sizze of the corpus. Hooks
+// are ran in order, and terms removed by a hook are not passed to later hooks.
+// See [WithMinDocumentsForPrune] to avoid pruning small corpora.
 func WithPruneHooks(hooks ...PruneHook) Option {
 	return func(c *Corpus) {
 		c.pruneHooks = hooks
 	}
 }
 
+// WithMinDocumentsForPrune makes [Corpus.Prune] a no-op until at least n documents
+// have been indexed. Percentage-based prune hooks (e.g. [PruneMoreThanPercent])
+// can remove most or all terms of very small corpora, so this protects vectors
+// created early on (e.g. while the corpus is still being populated) from being
+// over-pruned. Defaults to 0, where pruning runs as soon as any document is
+// indexed.
+func WithMinDocumentsForPrune(n int) Option {
+	return func(c *Corpus) {
+		c.minPruneDocuments = max(0, n)
+	}
+}
+
 // PruneLessThan is a [PruneHook] that removes terms that appear in less than the
 // given number of documents. Keep in mind that if you happen to have very few
 // documents, this may remove all terms.
@@ -188,3 +418,65 @@ func PruneMoreThanPercent(percent int) PruneHook {
 		return toRemove
 	}
 }
+
+// InformativenessFunc scores how informative a term is, given the total number of
+// documents, and the number of documents the term appears in. Higher is more
+// informative. See [PruneToVocabularySizeFunc].
+type InformativenessFunc func(documents, docFreq int) float64
+
+// MidFrequencyInformativeness is the default [InformativenessFunc], which
+// prefers terms appearing in around half of all documents, using "p * (1-p)",
+// where p is the fraction of documents the term appears in. Terms which are
+// very rare (e.g. typos) or very common (e.g. stop words) score the lowest, as
+// they do the least to distinguish documents from one another.
+func MidFrequencyInformativeness(documents, docFreq int) float64 {
+	if documents <= 0 {
+		return 0
+	}
+	p := float64(docFreq) / float64(documents)
+	return p * (1 - p)
+}
+
+// PruneToVocabularySize is a [PruneHook] that keeps only the k most informative
+// terms (see [MidFrequencyInformativeness]), removing all others. Using a k of
+// at most the max vector size (see [WithMaxVectorSize]) ensures vectors never
+// exceed it, which would otherwise silently drop terms. As hooks are ran in
+// order, this should generally be the last hook, so the remaining vocabulary
+// is as close to k as possible.
+func PruneToVocabularySize(k int) PruneHook {
+	return PruneToVocabularySizeFunc(k, MidFrequencyInformativeness)
+}
+
+// PruneToVocabularySizeFunc is the same as [PruneToVocabularySize], but with a
+// custom [InformativenessFunc]. Terms with equal scores are ranked
+// alphabetically, so results are deterministic.
+func PruneToVocabularySizeFunc(k int, score InformativenessFunc) PruneHook {
+	return func(documents int, termFreq map[string]int) (toRemove []string) {
+		if len(termFreq) <= k {
+			return nil
+		}
+
+		type scoredTerm struct {
+			term  string
+			score float64
+		}
+
+		terms := make([]scoredTerm, 0, len(termFreq))
+		for term, freq := range termFreq {
+			terms = append(terms, scoredTerm{term: term, score: score(documents, freq)})
+		}
+
+		slices.SortFunc(terms, func(a, b scoredTerm) int {
+			if c := cmp.Compare(b.score, a.score); c != 0 {
+				return c
+			}
+			return strings.Compare(a.term, b.term)
+		})
+
+		toRemove = make([]string, 0, len(terms)-max(0, k))
+		for _, t := range terms[max(0, k):] {
+			toRemove = append(toRemove, t.term)
+		}
+		return toRemove
+	}
+}
@@ -5,6 +5,8 @@
 package corpse
 
 import (
+	"bufio"
+	"maps"
 	"reflect"
 	"slices"
 	"strings"
@@ -21,6 +23,70 @@ func TestDefaultTokenizer(t *testing.T) {
 	}
 }
 
+func TestReaderTokenizer(t *testing.T) {
+	for _, s := range sampleData {
+		var err error
+		got := slices.Collect(readerTokenizer(bufio.NewReader(strings.NewReader(s.text)), &err))
+		if err != nil {
+			t.Fatalf("tokenized %q: unexpected error: %v", s.text, err)
+		}
+		if !reflect.DeepEqual(got, s.tokenized) {
+			t.Errorf("tokenized %q: %v != %v", s.text, got, s.tokenized)
+		}
+	}
+}
+
+func TestCodeTokenizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		joining string
+		text    string
+		want    []string
+	}{
+		{
+			name: "identifiers",
+			text: "Pod kube-system/coredns-5d78c9869d failed: max_retries exceeded",
+			want: []string{"pod", "kube-system", "coredns-5d78c9869d", "failed", "max_retries", "exceeded"},
+		},
+		{
+			name: "versions",
+			text: "upgraded from v1.2.3 to v1.10.0.",
+			want: []string{"upgraded", "from", "v1.2.3", "to", "v1.10.0"},
+		},
+		{
+			name: "hostnames",
+			text: "connect to api.example.com:443 (timeout)",
+			want: []string{"connect", "to", "api.example.com", "443", "timeout"},
+		},
+		{
+			name: "leading trailing and repeated",
+			text: "-flag --verbose a..b trailing- _private",
+			want: []string{"flag", "verbose", "a", "b", "trailing", "private"},
+		},
+		{
+			name:    "custom joining runes",
+			joining: "-",
+			text:    "kube-system v1.2.3 max_retries",
+			want:    []string{"kube-system", "v1", "2", "3", "max", "retries"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(CodeTokenizer(tt.joining)(tt.text))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenized %q: %q != %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	corp := New(WithTokenizer(CodeTokenizer("")))
+	corp.IndexDocument("namespace kube-system")
+	if !slices.Contains(corp.Vocabulary(), "kube-system") {
+		t.Errorf("vocabulary %q: missing %q", corp.Vocabulary(), "kube-system")
+	}
+}
+
 func TestTermFilter(t *testing.T) {
 	corp := New(
 		// result: "The" (tokenizer) -> "THE" (upper) -> "tHE" (lowerFirstChar)
@@ -149,6 +215,44 @@ func TestPrune(t *testing.T) {
 			pruned:    []string{"the", "quick", "jumps", "over", "lazy", "dog"},
 			notPruned: []string{"unique", "terms", "brown", "yellow", "fox", "frog"},
 		},
+		{
+			name:  "PruneToVocabularySize",
+			hooks: []PruneHook{PruneToVocabularySize(2)},
+			documents: []string{
+				"The quick brown fox jumps over the lazy dog",
+				"The quick brown frog jumps over the lazy dog",
+				"The quick yellow jumps over the lazy dog",
+				"The quick yellow jumps over the lazy dog",
+				"unique terms",
+			},
+			pruned:    []string{"the", "quick", "jumps", "over", "lazy", "dog", "fox", "frog", "unique", "terms"},
+			notPruned: []string{"brown", "yellow"},
+		},
+		{
+			name:  "PruneToVocabularySize-after-other-hooks",
+			hooks: []PruneHook{PruneMoreThanPercent(50), PruneToVocabularySize(3)},
+			documents: []string{
+				"The quick brown fox jumps over the lazy dog",
+				"The quick brown frog jumps over the lazy dog",
+				"The quick yellow jumps over the lazy dog",
+				"The quick yellow jumps over the lazy dog",
+				"unique terms",
+			},
+			pruned:    []string{"the", "quick", "jumps", "over", "lazy", "dog", "frog", "unique", "terms"},
+			notPruned: []string{"brown", "yellow", "fox"},
+		},
+		{
+			name: "PruneToVocabularySizeFunc",
+			hooks: []PruneHook{PruneToVocabularySizeFunc(1, func(_, docFreq int) float64 {
+				return float64(docFreq)
+			})},
+			documents: []string{
+				"The quick brown fox",
+				"The lazy dog",
+			},
+			pruned:    []string{"quick", "brown", "fox", "lazy", "dog"},
+			notPruned: []string{"the"},
+		},
 	}
 
 	for _, tt := range cases {
@@ -180,3 +284,36 @@ func TestPrune(t *testing.T) {
 		})
 	}
 }
+
+func TestWithMinDocumentsForPrune(t *testing.T) {
+	corp := New(
+		WithPruneHooks(PruneMoreThanPercent(50)),
+		WithMinDocumentsForPrune(3),
+	)
+
+	corp.IndexDocument("The quick brown fox")
+	want := corp.GetTermFrequency()
+
+	// Without the threshold, every term would be pruned, as they all appear in
+	// 100% of documents.
+	corp.Prune()
+	if got := corp.GetTermFrequency(); !maps.Equal(got, want) {
+		t.Fatalf("term frequency after prune = %v, want unpruned %v", got, want)
+	}
+	if vector := corp.CreateVector("quick fox"); !slices.ContainsFunc(vector, func(v float32) bool { return v > 0 }) {
+		t.Fatalf("vector = %v, want non-zero components", vector)
+	}
+	if corp.DebugDump().HasPruned {
+		t.Fatal("expected corpus to not be pruned below the threshold")
+	}
+
+	corp.IndexDocuments("The lazy dog", "The quick cat")
+	corp.Prune()
+	got := corp.GetTermFrequency()
+	if _, ok := got["the"]; ok {
+		t.Fatalf("term %q should be pruned once the threshold is reached: %v", "the", got)
+	}
+	if _, ok := got["fox"]; !ok {
+		t.Fatalf("term %q should not be pruned: %v", "fox", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"maps"
+	"slices"
+)
+
+// CorpusSnapshot is a read-only, point-in-time view of a [Corpus]. All methods
+// operate on the captured state, so multiple reads (e.g. when building a report)
+// are consistent with each other, even if the corpus is modified concurrently.
+// See [Corpus.Snapshot].
+type CorpusSnapshot struct {
+	corpus    *Corpus // Only used for configuration (tokenizer, IDF, etc), never state.
+	termFreq  map[string]int
+	terms     []string
+	documents int
+}
+
+// Snapshot returns a read-only, point-in-time view of the corpus, captured under
+// a single lock. Like [Corpus.CreateVector], this will automatically call
+// [Corpus.Prune] if there are any new documents that have been indexed since the
+// last prune, so the snapshot reflects the pruned state.
+//
+// Snapshots copy the term frequencies and terms of the corpus, so they are cheap
+// to read from, but not free to create.
+//
+// This is concurrent-safe.
+func (c *Corpus) Snapshot() *CorpusSnapshot {
+	c.Prune()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &CorpusSnapshot{
+		corpus:    c,
+		termFreq:  maps.Clone(c.termFreq),
+		terms:     slices.Clone(c.termIndex.All()),
+		documents: c.documents,
+	}
+}
+
+// GetTermFrequency returns a copy of the term frequencies at the time of the
+// snapshot.
+func (s *CorpusSnapshot) GetTermFrequency() map[string]int {
+	return maps.Clone(s.termFreq)
+}
+
+// GetDocumentCount returns the number of documents that had been indexed at the
+// time of the snapshot.
+func (s *CorpusSnapshot) GetDocumentCount() int {
+	return s.documents
+}
+
+// GetUsedCapacity returns the percentage of the corpus capacity that was used at
+// the time of the snapshot. See [Corpus.GetUsedCapacity].
+func (s *CorpusSnapshot) GetUsedCapacity() (percent int) {
+	return int(float32(len(s.termFreq)) / float32(s.corpus.maxVectorSize) * 100)
+}
+
+// Terms returns a copy of the terms at the time of the snapshot, in vector
+// order (i.e. the term at index i corresponds to index i of vectors created from
+// the snapshot).
+func (s *CorpusSnapshot) Terms() []string {
+	return slices.Clone(s.terms)
+}
+
+// Vector creates a TF-IDF vector for the given text, against the state of the
+// corpus at the time of the snapshot. Unlike [Corpus.CreateVector], this never
+// locks or prunes the live corpus. See [CorpusSnapshot.PaddedVector] if you need
+// a constant-sized vector.
+//
+// This is concurrent-safe.
+func (s *CorpusSnapshot) Vector(text string) []float32 {
+	return s.corpus.vectorize(text, s.termFreq, s.terms, s.documents)
+}
+
+// PaddedVector is the same as [CorpusSnapshot.Vector], but pads the vector with
+// zeros up to the maximum vector size. See [Corpus.CreatePaddedVector].
+//
+// This is concurrent-safe.
+func (s *CorpusSnapshot) PaddedVector(text string) []float32 {
+	return s.corpus.padVector(s.Vector(text))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package corpse
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCorpus_Snapshot(t *testing.T) {
+	corp := New(WithMaxVectorSize(64))
+	for _, s := range sampleData {
+		corp.IndexDocument(s.text)
+	}
+
+	snap := corp.Snapshot()
+
+	if got, want := snap.GetDocumentCount(), corp.GetDocumentCount(); got != want {
+		t.Fatalf("snapshot document count = %d, want %d", got, want)
+	}
+	if got, want := snap.GetTermFrequency(), corp.GetTermFrequency(); !maps.Equal(got, want) {
+		t.Fatalf("snapshot term frequency = %v, want %v", got, want)
+	}
+	if got, want := snap.GetUsedCapacity(), corp.GetUsedCapacity(); got != want {
+		t.Fatalf("snapshot used capacity = %d, want %d", got, want)
+	}
+	if !slices.IsSorted(snap.Terms()) {
+		t.Fatalf("snapshot terms are not sorted: %v", snap.Terms())
+	}
+
+	text := sampleData[0].text
+	want := corp.CreateVector(text)
+	if got := snap.Vector(text); !slices.Equal(got, want) {
+		t.Fatalf("snapshot vector = %v, want %v", got, want)
+	}
+	if got := snap.PaddedVector(text); len(got) != 64 {
+		t.Fatalf("snapshot padded vector length = %d, want 64", len(got))
+	}
+
+	// Modifying the corpus must not affect the snapshot.
+	corp.IndexDocument("entirely new words appear here")
+	corp.RemoveDocument(sampleData[1].text)
+
+	if snap.GetDocumentCount() != len(sampleData) {
+		t.Fatalf("snapshot document count changed to %d", snap.GetDocumentCount())
+	}
+	if _, ok := snap.GetTermFrequency()["entirely"]; ok {
+		t.Fatal("snapshot term frequency contains term indexed after the snapshot")
+	}
+	if got := snap.Vector(text); !slices.Equal(got, want) {
+		t.Fatalf("snapshot vector changed after corpus modification: %v, want %v", got, want)
+	}
+	if slices.Equal(corp.CreateVector(text), want) {
+		t.Fatal("expected live corpus vector to change after modification")
+	}
+
+	// Returned values must be copies.
+	snap.GetTermFrequency()["the"] = 1000
+	snap.Terms()[0] = "zzz"
+	if snap.GetTermFrequency()["the"] == 1000 || snap.Terms()[0] == "zzz" {
+		t.Fatal("expected snapshot getters to return copies")
+	}
+}
@@ -5,6 +5,7 @@
 package fuzzy
 
 import (
+	"container/heap"
 	"strings"
 	"unicode"
 )
@@ -23,6 +24,32 @@ func DefaultNormalizer(s string) string {
 type result[T any] struct {
 	value T
 	score int
+	index int
+}
+
+// worse reports whether r ranks below o. Equal scores are ranked by their
+// original position.
+func (r result[T]) worse(o result[T]) bool {
+	if r.score != o.score {
+		return r.score < o.score
+	}
+	return r.index > o.index
+}
+
+// resultHeap is a min-heap of results, where the worst ranked result is at the
+// root.
+type resultHeap[T any] []result[T]
+
+func (h resultHeap[T]) Len() int           { return len(h) }
+func (h resultHeap[T]) Less(i, j int) bool { return h[i].worse(h[j]) }
+func (h resultHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *resultHeap[T]) Push(x any)        { *h = append(*h, x.(result[T])) } //nolint:forcetypeassert
+func (h *resultHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	v := old[n-1]
+	*h = old[:n-1]
+	return v
 }
 
 // FindRanked returns a slice of values sorted by their similarity to the filter
@@ -58,6 +85,82 @@ func FindRankedRow[T any](filter string, values []T, valuesFn func(T) []string,
 	if normalizeFn == nil {
 		normalizeFn = DefaultNormalizer
 	}
+	return findRankedRow(filter, values, valuesFn, normalizeFn, true)
+}
+
+// FindRankedRowDedup is the same as [FindRankedRow], however after ranking, only
+// the highest ranked value is kept for each key returned by keyFn, e.g. when
+// merging search results from multiple sources, where the same entity may be
+// present more than once. Otherwise, the ranked order is preserved.
+//
+// If the filter is empty, the original values are returned, with only the first
+// value for each key kept.
+func FindRankedRowDedup[T any](
+	filter string,
+	values []T,
+	valuesFn func(T) []string,
+	keyFn func(T) string,
+	normalizeFn NormalizerFunc,
+) []T {
+	ranked := FindRankedRow(filter, values, valuesFn, normalizeFn)
+
+	seen := make(map[string]struct{}, len(ranked))
+	result := make([]T, 0, len(ranked))
+	for _, value := range ranked {
+		key := keyFn(value)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
+
+// MatchOptions are the options for [FindRankedRowOpts].
+type MatchOptions struct {
+	// CaseSensitive, if true, matches case-sensitively. Ignored if SmartCase is
+	// true.
+	CaseSensitive bool
+
+	// SmartCase, if true, matches case-sensitively only if the filter contains an
+	// uppercase character, otherwise case-insensitively (e.g. "app" matches "App"
+	// and "app", but "App" only matches "App").
+	SmartCase bool
+
+	// Normalizer is an optional function to normalize the filter and strings
+	// before matching (e.g. stripping punctuation, whitespace, ansi sequences,
+	// etc). Case is handled separately (based on CaseSensitive and SmartCase), so
+	// it should not change the case of the string.
+	Normalizer NormalizerFunc
+}
+
+// FindRankedRowOpts is the same as [FindRankedRow], but with [MatchOptions] to
+// control case sensitivity.
+//
+// If the filter is empty, the original values are returned as-is.
+func FindRankedRowOpts[T any](filter string, values []T, valuesFn func(T) []string, opts MatchOptions) []T {
+	normalizeFn := opts.Normalizer
+	if normalizeFn == nil {
+		normalizeFn = func(s string) string { return s }
+	}
+
+	caseSensitive := opts.CaseSensitive
+	if opts.SmartCase {
+		caseSensitive = strings.IndexFunc(normalizeFn(filter), unicode.IsUpper) >= 0
+	}
+
+	if !caseSensitive {
+		base := normalizeFn
+		normalizeFn = func(s string) string { return strings.ToLower(base(s)) }
+	}
+
+	return findRankedRow(filter, values, valuesFn, normalizeFn, !caseSensitive)
+}
+
+// findRankedRow is the implementation of [FindRankedRow] and
+// [FindRankedRowOpts].
+func findRankedRow[T any](filter string, values []T, valuesFn func(T) []string, normalizeFn NormalizerFunc, foldCase bool) []T {
 	filter = normalizeFn(filter)
 	if filter == "" {
 		return values
@@ -72,7 +175,7 @@ func FindRankedRow[T any](filter string, values []T, valuesFn func(T) []string,
 		bestScore = -1
 
 		for _, str := range strs {
-			score = calculateScore(normalizeFn(str), filter)
+			score = scoreFold(normalizeFn(str), filter, foldCase)
 			if score > bestScore {
 				bestScore = score
 			}
@@ -104,7 +207,91 @@ func FindRankedRow[T any](filter string, values []T, valuesFn func(T) []string,
 	return result
 }
 
+// FindTopK returns up to k values with the highest similarity to the filter
+// string, sorted by their similarity. The valuesFn function is used to extract
+// the strings to compare from each value. Unlike [FindRankedRow], only the top k
+// results are kept while scoring (using a bounded heap), rather than sorting all
+// matching values, which is much faster when only a few results are needed from
+// a large dataset (e.g. autocomplete). Values with equal scores are ordered by
+// their original position.
+//
+// If the filter is empty, the first k original values are returned as-is.
+func FindTopK[T any](filter string, values []T, valuesFn func(T) []string, k int, normalizeFn NormalizerFunc) []T {
+	if k <= 0 {
+		return nil
+	}
+	if normalizeFn == nil {
+		normalizeFn = DefaultNormalizer
+	}
+	filter = normalizeFn(filter)
+	if filter == "" {
+		return values[:min(k, len(values))]
+	}
+
+	results := make(resultHeap[T], 0, min(k, len(values)))
+	var bestScore, score int
+
+	for i, value := range values {
+		bestScore = -1
+
+		for _, str := range valuesFn(value) {
+			score = calculateScore(normalizeFn(str), filter)
+			if score > bestScore {
+				bestScore = score
+			}
+		}
+
+		if bestScore <= 0 {
+			continue
+		}
+
+		r := result[T]{value: value, score: bestScore, index: i}
+		if len(results) < k {
+			heap.Push(&results, r)
+			continue
+		}
+		if results[0].worse(r) {
+			results[0] = r
+			heap.Fix(&results, 0)
+		}
+	}
+
+	// Pop in reverse order (worst first).
+	out := make([]T, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(&results).(result[T]).value //nolint:forcetypeassert
+	}
+	return out
+}
+
+// calculateScore is an alias of [Score].
 func calculateScore(text, query string) int {
+	return Score(text, query)
+}
+
+// Score returns the similarity score of text to query, as used by [FindRanked],
+// [FindRankedRow], [FindTopK], etc. Neither text nor query are normalized, so
+// use a [NormalizerFunc] (e.g. [DefaultNormalizer]) first if needed. Higher is
+// better, and 0 means no match. The magnitudes are:
+//
+//   - 10000: exact match.
+//   - 1000-pos: query is a substring of text, where pos is the byte offset of the
+//     first occurrence (earlier is better).
+//   - fuzzy: all query characters appear in-order in text. The score is a
+//     composite of bonuses for consecutive matches (10 per consecutive run
+//     length), matches at word boundaries (50), and uppercase matches (30), plus
+//     100 minus the length of text (shorter text is more precise). This is
+//     typically below 1000, but may be negative for long text.
+//
+// This can be used to combine the scoring heuristic with other signals, in a
+// custom ranking pipeline.
+func Score(text, query string) int {
+	return scoreFold(text, query, true)
+}
+
+// scoreFold is the implementation of [Score], where fuzzy matching is optionally
+// case-insensitive (exact and substring matches are always case-sensitive).
+func scoreFold(text, query string, foldCase bool) int {
 	if query == "" {
 		return 0
 	}
@@ -132,7 +319,7 @@ func calculateScore(text, query string) int {
 			break
 		}
 
-		if unicode.ToLower(char) == unicode.ToLower(rune(query[queryIdx])) {
+		if char == rune(query[queryIdx]) || (foldCase && unicode.ToLower(char) == unicode.ToLower(rune(query[queryIdx]))) {
 			// Bonus for consecutive matches.
 			if lastMatch == i-1 {
 				consecutive++
@@ -251,6 +251,23 @@ func TestCalculateScore(t *testing.T) {
 	}
 }
 
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	if got := Score("apple", "apple"); got != 10000 {
+		t.Errorf("exact: expected 10000, got %d", got)
+	}
+	if got := Score("pineapple", "apple"); got != 1000-4 {
+		t.Errorf("substring: expected %d, got %d", 1000-4, got)
+	}
+	if got := Score("pineapple", "zzz"); got != 0 {
+		t.Errorf("no match: expected 0, got %d", got)
+	}
+	if got, want := Score("hello world", "hw"), calculateScore("hello world", "hw"); got != want {
+		t.Errorf("fuzzy: expected %d, got %d", want, got)
+	}
+}
+
 func TestFindRankedSliceWithComplexData(t *testing.T) {
 	t.Parallel()
 
@@ -378,6 +395,53 @@ func TestFindRankedSliceEdgeCases(t *testing.T) {
 	}
 }
 
+func TestFindTopK(t *testing.T) {
+	t.Parallel()
+
+	values := []string{"pineapple", "banana", "apple", "crabapple", "application", "apples"}
+	valuesFn := func(v string) []string { return []string{v} }
+
+	tests := []struct {
+		name     string
+		filter   string
+		k        int
+		expected []string
+	}{
+		{name: "zero k", filter: "app", k: 0, expected: nil},
+		{name: "empty filter", filter: "", k: 2, expected: []string{"pineapple", "banana"}},
+		{name: "top 1", filter: "apple", k: 1, expected: []string{"apple"}},
+		{name: "top 3", filter: "app", k: 3, expected: []string{"apple", "application", "apples"}},
+		{name: "k larger than matches", filter: "banana", k: 10, expected: []string{"banana"}},
+		{name: "no matches", filter: "zzz", k: 5, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := FindTopK(tt.filter, values, valuesFn, tt.k, nil)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, result)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, result)
+				}
+			}
+		})
+	}
+
+	// Should have the same scores as the head of the fully ranked results (order
+	// of ties may differ).
+	ranked := FindRanked("ple", values, nil)
+	top := FindTopK("ple", values, valuesFn, 3, nil)
+	for i := range top {
+		if calculateScore(top[i], "ple") != calculateScore(ranked[i], "ple") {
+			t.Fatalf("FindTopK = %v, want head of FindRanked %v", top, ranked)
+		}
+	}
+}
+
 func BenchmarkFindRankedSlice(b *testing.B) {
 	type testItem struct {
 		name string
@@ -398,3 +462,167 @@ func BenchmarkFindRankedSlice(b *testing.B) {
 		}, nil)
 	}
 }
+
+func benchmarkTopKItems() []string {
+	items := make([]string, 100000)
+	for i := range items {
+		items[i] = strings.Repeat("item", i%10+1) + string(rune('a'+i%26)) + strings.Repeat("x", i%7)
+	}
+	return items
+}
+
+func BenchmarkFindTopK(b *testing.B) {
+	items := benchmarkTopKItems()
+	valuesFn := func(v string) []string { return []string{v} }
+
+	b.Run("FindRankedRow", func(b *testing.B) {
+		for b.Loop() {
+			results := FindRankedRow("itemc", items, valuesFn, nil)
+			_ = results[:min(10, len(results))]
+		}
+	})
+
+	b.Run("FindTopK", func(b *testing.B) {
+		for b.Loop() {
+			FindTopK("itemc", items, valuesFn, 10, nil)
+		}
+	})
+}
+
+func TestFindRankedRowOpts(t *testing.T) {
+	values := []string{"App", "app", "APPLE", "Application", "zap"}
+	valuesFn := func(v string) []string { return []string{v} }
+
+	tests := []struct {
+		name   string
+		filter string
+		opts   MatchOptions
+		want   []string
+	}{
+		{
+			name:   "case-insensitive",
+			filter: "App",
+			opts:   MatchOptions{},
+			want:   []string{"App", "app", "APPLE", "Application"},
+		},
+		{
+			name:   "case-sensitive",
+			filter: "App",
+			opts:   MatchOptions{CaseSensitive: true},
+			want:   []string{"App", "Application"},
+		},
+		{
+			name:   "case-sensitive-lowercase",
+			filter: "app",
+			opts:   MatchOptions{CaseSensitive: true},
+			want:   []string{"app"},
+		},
+		{
+			name:   "smart-case-lowercase",
+			filter: "app",
+			opts:   MatchOptions{SmartCase: true},
+			want:   []string{"App", "app", "APPLE", "Application"},
+		},
+		{
+			name:   "smart-case-uppercase",
+			filter: "App",
+			opts:   MatchOptions{SmartCase: true},
+			want:   []string{"App", "Application"},
+		},
+		{
+			name:   "smart-case-overrides-case-sensitive",
+			filter: "app",
+			opts:   MatchOptions{SmartCase: true, CaseSensitive: true},
+			want:   []string{"App", "app", "APPLE", "Application"},
+		},
+		{
+			name:   "normalizer",
+			filter: "a-p-p",
+			opts: MatchOptions{
+				CaseSensitive: true,
+				Normalizer:    func(s string) string { return strings.ReplaceAll(s, "-", "") },
+			},
+			want: []string{"app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindRankedRowOpts(tt.filter, values, valuesFn, tt.opts)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FindRankedRowOpts(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRankedRowOpts_matchesFindRankedRow(t *testing.T) {
+	values := []string{"Hello World", "help", "world", "HELIUM", "yellow"}
+	valuesFn := func(v string) []string { return []string{v} }
+
+	for _, filter := range []string{"hel", "HW", "low", ""} {
+		got := FindRankedRowOpts(filter, values, valuesFn, MatchOptions{})
+		want := FindRankedRow(filter, values, valuesFn, nil)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("filter %q: FindRankedRowOpts = %v, FindRankedRow = %v", filter, got, want)
+		}
+	}
+}
+
+func TestFindRankedRowDedup(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		id     string
+		name   string
+		source string
+	}
+
+	values := []item{
+		{id: "1", name: "application", source: "a"},
+		{id: "2", name: "banana", source: "a"},
+		{id: "3", name: "apples", source: "a"},
+		{id: "1", name: "apple", source: "b"},
+		{id: "4", name: "pineapple", source: "b"},
+		{id: "3", name: "crabapple", source: "b"},
+	}
+	valuesFn := func(v item) []string { return []string{v.name} }
+	keyFn := func(v item) string { return v.id }
+
+	result := FindRankedRowDedup("apple", values, valuesFn, keyFn, nil)
+
+	want := []item{
+		{id: "1", name: "apple", source: "b"},
+		{id: "3", name: "apples", source: "a"},
+		{id: "4", name: "pineapple", source: "b"},
+	}
+	if len(result) != len(want) {
+		t.Fatalf("expected %v, got %v", want, result)
+	}
+	for i := range want {
+		if result[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, result)
+		}
+	}
+
+	// The order should match the fully ranked results, with duplicates removed.
+	var ranked []item
+	seen := map[string]bool{}
+	for _, v := range FindRankedRow("apple", values, valuesFn, nil) {
+		if !seen[v.id] {
+			seen[v.id] = true
+			ranked = append(ranked, v)
+		}
+	}
+	for i := range ranked {
+		if result[i] != ranked[i] {
+			t.Fatalf("expected ranked order %v, got %v", ranked, result)
+		}
+	}
+
+	// Empty filter keeps the original order, with the first value per key.
+	result = FindRankedRowDedup("", values, valuesFn, keyFn, nil)
+	if len(result) != 4 || result[0].source != "a" || result[2].name != "apples" || result[3].id != "4" {
+		t.Fatalf("unexpected result for empty filter: %v", result)
+	}
+}
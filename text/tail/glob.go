@@ -0,0 +1,219 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package tail
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Line is a token read from one of the files watched by [WatchGlob].
+type Line struct {
+	// Path is the absolute path of the file the token was read from.
+	Path string
+	// Data is the token, as split by [Config.SplitFunc].
+	Data []byte
+}
+
+// globResult is a line or error from a sub-watcher of [WatchGlob].
+type globResult struct {
+	line Line
+	err  error
+}
+
+// globWatcher is a sub-watcher of [WatchGlob], tailing a single file.
+type globWatcher struct {
+	watcher *Watcher
+	done    chan struct{} // Closed once the sub-watcher has returned.
+}
+
+// WatchGlob monitors all files matching pattern (see [filepath.Match]), e.g.
+// "/var/log/app-*.log", including files which are created after watching has
+// started, and yields new lines from all of them as they are written. Each file is
+// tailed the same way as [Watch], and lines are multiplexed into a single
+// sequence, with the path of the file they were read from. Only the file name
+// (the last element of the pattern) can contain wildcards, as only a single
+// directory is watched.
+//
+// Files which exist when watching starts are tailed from the end. Files created
+// afterwards are read from the beginning if [Config.ReadFromStart] is true,
+// otherwise only data appended after they were detected is yielded. When a
+// matching file is removed or renamed, it is no longer tailed (unlike [Watch],
+// which waits for it to reappear), until a file with the same name is created
+// again. [Config.RotatedGlob] is ignored.
+//
+// Each file uses its own filesystem watcher, so watching many files at once may
+// run into operating system limits (e.g. fs.inotify.max_user_instances on Linux).
+func WatchGlob(ctx context.Context, config *Config, pattern string) iter.Seq2[Line, error] { //nolint:gocognit
+	return func(yield func(Line, error) bool) {
+		if config == nil {
+			config = &Config{}
+		}
+
+		pattern, err := filepath.Abs(pattern)
+		if err != nil {
+			yield(Line{}, err)
+			return
+		}
+
+		if _, err = filepath.Match(pattern, ""); err != nil {
+			yield(Line{}, err)
+			return
+		}
+
+		dir := filepath.Dir(pattern)
+		if hasMeta(dir) {
+			yield(Line{}, fmt.Errorf("only the file name of pattern %q can contain wildcards", pattern))
+			return
+		}
+
+		logger := config.Logger
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+
+		// Sub-watchers don't read rotated files, and each get their own copy of the
+		// config, as [NewWatcher] sets defaults on it.
+		subConfig := *config
+		subConfig.RotatedGlob = ""
+
+		// Watch the directory before looking for existing files, so files created in
+		// between aren't missed.
+		dirWatcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			yield(Line{}, err)
+			return
+		}
+		defer dirWatcher.Close()
+
+		if err = dirWatcher.Add(dir); err != nil {
+			yield(Line{}, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		results := make(chan globResult)
+		watchers := make(map[string]*globWatcher)
+
+		// start starts tailing path, unless it is already being tailed.
+		start := func(path string, created bool) error {
+			if sub, ok := watchers[path]; ok {
+				select {
+				case <-sub.done:
+				default:
+					return nil
+				}
+			}
+
+			cfg := subConfig
+			w, err := NewWatcher(&cfg, path)
+			if err != nil {
+				return err
+			}
+			w.fromStart = created && cfg.ReadFromStart
+
+			sub := &globWatcher{watcher: w, done: make(chan struct{})}
+			watchers[path] = sub
+
+			logger.DebugContext(ctx, "tailing matched file", "path", path)
+
+			wg.Go(func() {
+				defer close(sub.done)
+				defer w.Close()
+
+				for data, err := range w.Start(ctx) {
+					select {
+					case results <- globResult{line: Line{Path: path, Data: data}, err: err}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+			return nil
+		}
+
+		// stop stops tailing path, if it is being tailed.
+		stop := func(path string) {
+			if sub, ok := watchers[path]; ok {
+				logger.DebugContext(ctx, "matched file removed", "path", path)
+				sub.watcher.Stop()
+				delete(watchers, path)
+			}
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			yield(Line{}, err)
+			return
+		}
+
+		for _, path := range matches {
+			if err = start(path, false); err != nil && !yield(Line{}, err) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case result := <-results:
+				if !yield(result.line, result.err) {
+					return
+				}
+			case event, ok := <-dirWatcher.Events:
+				if !ok {
+					return
+				}
+
+				if matched, _ := filepath.Match(pattern, event.Name); !matched {
+					continue
+				}
+
+				switch {
+				case event.Has(fsnotify.Create):
+					if err = start(event.Name, true); err != nil && !yield(Line{}, err) {
+						return
+					}
+				case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+					stop(event.Name)
+				}
+			case err, ok := <-dirWatcher.Errors:
+				if !ok {
+					return
+				}
+				logger.DebugContext(ctx, "watcher error", "error", err)
+			}
+		}
+	}
+}
+
+// hasMeta returns true if path contains any of the special characters recognized
+// by [filepath.Match].
+func hasMeta(path string) bool {
+	for _, c := range path {
+		switch c {
+		case '*', '?', '[':
+			return true
+		case '\\':
+			if filepath.Separator != '\\' {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package tail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open file for writing: %v", err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(data); err != nil {
+		t.Fatalf("failed to write to file: %v", err)
+	}
+}
+
+func TestWatchGlob(t *testing.T) {
+	tmpdir := t.TempDir()
+	existing := filepath.Join(tmpdir, "app-1.log")
+	created := filepath.Join(tmpdir, "app-2.log")
+	ignored := filepath.Join(tmpdir, "other.log")
+
+	if err := os.WriteFile(existing, []byte("old\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay:  50 * time.Millisecond,
+		ReadFromStart: true,
+	}
+
+	lines := make(chan Line)
+	go func() {
+		defer close(lines)
+		for line, err := range WatchGlob(ctx, config, filepath.Join(tmpdir, "app-*.log")) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	next := func() Line {
+		t.Helper()
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("watcher stopped unexpectedly")
+			}
+			return line
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for line")
+			return Line{}
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Existing files are tailed from the end.
+	appendFile(t, existing, "existing-1\n")
+	if line := next(); line.Path != existing || string(line.Data) != "existing-1" {
+		t.Fatalf("got %s: %q, want %s: %q", line.Path, line.Data, existing, "existing-1")
+	}
+
+	// New files are read from the start (as ReadFromStart is set), and files not
+	// matching the pattern are ignored.
+	appendFile(t, ignored, "ignored\n")
+	appendFile(t, created, "created-1\n")
+	if line := next(); line.Path != created || string(line.Data) != "created-1" {
+		t.Fatalf("got %s: %q, want %s: %q", line.Path, line.Data, created, "created-1")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	appendFile(t, created, "created-2\n")
+	if line := next(); line.Path != created || string(line.Data) != "created-2" {
+		t.Fatalf("got %s: %q, want %s: %q", line.Path, line.Data, created, "created-2")
+	}
+
+	// Removed files are no longer tailed, and are tailed again (from the start)
+	// once recreated.
+	if err := os.Remove(existing); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	appendFile(t, existing, "recreated-1\n")
+	if line := next(); line.Path != existing || string(line.Data) != "recreated-1" {
+		t.Fatalf("got %s: %q, want %s: %q", line.Path, line.Data, existing, "recreated-1")
+	}
+}
+
+func TestWatchGlob_invalidPattern(t *testing.T) {
+	for _, pattern := range []string{"[", filepath.Join(t.TempDir(), "*", "app.log")} {
+		var got error
+		for _, err := range WatchGlob(t.Context(), nil, pattern) {
+			got = err
+		}
+		if got == nil {
+			t.Fatalf("expected error for pattern %q", pattern)
+		}
+	}
+}
@@ -2,6 +2,9 @@ module github.com/lrstanley/x/text/tail
 
 go 1.25.5
 
-require github.com/fsnotify/fsnotify v1.10.1
+require (
+	github.com/fsnotify/fsnotify v1.10.1
+	github.com/klauspost/compress v1.18.0
+)
 
 require golang.org/x/sys v0.44.0 // indirect
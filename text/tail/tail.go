@@ -8,16 +8,22 @@ package tail
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"iter"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/klauspost/compress/zstd"
 )
 
 // Config holds configuration for the tail function.
@@ -32,14 +38,198 @@ type Config struct {
 
 	// ReadFromStart, if true, causes the watcher to read from the beginning of the
 	// file when it's newly created or truncated. If false (default), it skips the
-	// content and only reads new data appended after the event.
+	// content and only reads new data appended after the event. See also
+	// RotatedGlob.
 	ReadFromStart bool
 
+	// RotatedGlob, if set (and ReadFromStart is true), is a glob pattern (see
+	// [filepath.Match]) matching rotated files of the watched file (e.g.
+	// "/var/log/app.log.*"). On startup, before tailing the watched file, matching
+	// files are read in chronological order (oldest modification time first), and
+	// then the watched file is read from the beginning, giving a complete stream
+	// across rotations. Files are transparently decompressed based on their
+	// extension, see Decompressors.
+	RotatedGlob string
+
+	// Decompressors maps file extensions (e.g. ".gz") of rotated files (see
+	// RotatedGlob) to a function which decompresses them. Rotated files with other
+	// extensions are read as-is. Defaults to [DefaultDecompressors].
+	Decompressors map[string]Decompressor
+
+	// BatchSize is the maximum number of tokens read (including those skipped by
+	// LineFilter) per write event, before returning to the event loop, where
+	// context cancellation and other file events are checked. Any remaining data
+	// is read in further batches, without waiting for another write event. As
+	// tokens are read directly from the file as the consumer requests them, data
+	// which hasn't been read yet stays on disk rather than being buffered in
+	// memory, so a slow consumer never causes unbounded memory usage. Smaller
+	// values make the watcher more responsive (e.g. to cancellation) under high
+	// write rates, at the cost of more overhead per token. Defaults to 100.
+	BatchSize int
+
+	// TailLines, if greater than 0, causes the watcher to read the last TailLines
+	// lines of the file when it's first opened (like "tail -n <lines> -f"),
+	// before following new data. Lines are found by scanning backwards from the
+	// end of the file for newlines, after which the content is split by
+	// SplitFunc as usual. If the file has fewer lines, it's read from the start.
+	// Ignored when the file is read from the start anyway (see RotatedGlob), or
+	// doesn't exist yet when the watcher starts.
+	TailLines int
+
+	// FollowSymlinks, if true, resolves the watched path if it's a symlink (e.g.
+	// "current.log -> app-2024.log") each time the file is (re)opened, and also
+	// watches the directory of the target, so writes to the target are detected.
+	// When the symlink is re-pointed to a new target (e.g. by log rotation), the
+	// old target is closed (see [EventRotated]), and the new target is opened
+	// (see [EventReopened]), as if the file was moved and recreated. Without it,
+	// the original target is followed until the symlink is removed.
+	FollowSymlinks bool
+
 	// Logger is used for logging. If nil, no logging is performed.
 	Logger *slog.Logger
+
+	// LineFilter, if set, is called for each token (as split by SplitFunc) before
+	// it is yielded. Returning false skips the token, otherwise the returned bytes
+	// are yielded in place of the token. The provided line is only valid until
+	// LineFilter returns (it must not be retained), and the returned bytes are
+	// copied before being yielded.
+	LineFilter func(line []byte) ([]byte, bool)
+
+	// OnError, if set, is called for each error before it is yielded, and returns
+	// the action to take once it has been yielded (see [ErrorAction]). This allows
+	// deciding which errors are fatal, e.g. retrying on permission errors until
+	// the permissions of the file are fixed. Errors are yielded regardless of the
+	// action, and iteration always stops if the consumer stops. If nil, the
+	// following default actions are used, based on where the error occurred:
+	//   - Opening the file (e.g. [os.ErrPermission]) when starting, while waiting
+	//     for it to be created, or after it was moved/deleted: [ActionStop].
+	//   - Reopening the file after a write or create event: [ActionContinue],
+	//     where opening is attempted again on the next event.
+	//   - Reading the file (permission errors while reading, or failing to stat or
+	//     seek it): [ActionContinue].
+	//   - Reading rotated files (see RotatedGlob): [ActionContinue]. These can't
+	//     be retried, so [ActionRetry] is the same as [ActionContinue].
+	//
+	// Other read errors (which are likely transient), and filesystem notification
+	// errors, are only logged, and never passed to OnError.
+	OnError func(err error) ErrorAction
+}
+
+// Decompressor returns a reader which decompresses r. See
+// [Config.Decompressors].
+type Decompressor func(r io.Reader) (io.Reader, error)
+
+// DefaultDecompressors are the default [Config.Decompressors], supporting gzip
+// (".gz") and Zstandard (".zst").
+var DefaultDecompressors = map[string]Decompressor{
+	".gz": func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	},
+	".zst": func(r io.Reader) (io.Reader, error) {
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	},
+}
+
+// EventType is the type of an [Event].
+type EventType int
+
+const (
+	// EventLine is emitted for each token (as split by [Config.SplitFunc]).
+	EventLine EventType = iota
+	// EventTruncated is emitted when the file was truncated, and reading was reset
+	// to the beginning of the file.
+	EventTruncated
+	// EventRotated is emitted when the file was moved/renamed or deleted (e.g. by
+	// log rotation).
+	EventRotated
+	// EventReopened is emitted when the file reappeared after [EventRotated], and
+	// was reopened.
+	EventReopened
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventLine:
+		return "line"
+	case EventTruncated:
+		return "truncated"
+	case EventRotated:
+		return "rotated"
+	case EventReopened:
+		return "reopened"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorAction is the action to take after an error was yielded, see
+// [Config.OnError].
+type ErrorAction int
+
+const (
+	// ActionContinue continues watching the file. If the file couldn't be opened,
+	// it's opened again on the next event, or after [Config.RecheckDelay] while
+	// waiting for it to appear.
+	ActionContinue ErrorAction = iota
+	// ActionStop stops iteration.
+	ActionStop
+	// ActionRetry waits [Config.RecheckDelay], and retries the operation which
+	// failed (opening the file, or reading new data from it).
+	ActionRetry
+)
+
+// String returns the name of the action.
+func (a ErrorAction) String() string {
+	switch a {
+	case ActionContinue:
+		return "continue"
+	case ActionStop:
+		return "stop"
+	case ActionRetry:
+		return "retry"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is an event yielded by [WatchEvents] and [Watcher.StartEvents].
+type Event struct {
+	// Type is the type of the event.
+	Type EventType
+	// Line is the token, only set for [EventLine].
+	Line []byte
+	// Number is the 1-based number of the token, only set for [EventLine]. See
+	// [LineInfo.Number].
+	Number int
+	// Offset is the byte offset of the start of the token, only set for
+	// [EventLine]. See [LineInfo.Offset].
+	Offset int64
+}
+
+// LineInfo is a token yielded by [WatchLines], with its position.
+type LineInfo struct {
+	// Number is the 1-based line (token) number. Numbering starts with the first
+	// token read (which is the first line of the file only if it was read from
+	// the start, see [Config.ReadFromStart]), and continues across rotations
+	// (including rotated files read through [Config.RotatedGlob]). It is reset
+	// back to 1 when the file is truncated. Tokens skipped by [Config.LineFilter]
+	// are still counted.
+	Number int
+	// Offset is the byte offset of the start of the token within the file it was
+	// read from (for compressed rotated files, within the decompressed content).
+	Offset int64
+	// Data is the token (as split by [Config.SplitFunc]).
+	Data []byte
 }
 
-// Watcher monitors a file and yields new lines as they are written.
+// Watcher monitors a file and yields new lines as they are written. A Watcher is
+// single-use: once it has been stopped (see [Watcher.Stop]) or closed, it cannot
+// be restarted, and a new Watcher must be created with [NewWatcher] instead.
 type Watcher struct {
 	config          *Config
 	path            string
@@ -47,7 +237,19 @@ type Watcher struct {
 	scanner         *bufio.Scanner
 	filePos         int64
 	fileJustCreated bool
+	rotated         bool
+	pending         bool // More data is available, but the batch size was reached.
+	lineNumber      int  // Number of the last token read, see [LineInfo.Number].
+	offsets         offsetTracker
+	fromStart       bool   // Read the file from the start when first opened, see [WatchGlob].
+	target          string // Resolved symlink target, see [Config.FollowSymlinks].
 	watcher         *fsnotify.Watcher
+
+	mu        sync.Mutex
+	stopped   bool
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closeErr  error
 }
 
 // NewWatcher creates a new Watcher for the given path with the provided config.
@@ -64,10 +266,24 @@ func NewWatcher(config *Config, path string) (*Watcher, error) {
 		config.RecheckDelay = 100 * time.Millisecond
 	}
 
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+
 	if config.Logger == nil {
 		config.Logger = slog.New(slog.DiscardHandler)
 	}
 
+	if config.Decompressors == nil {
+		config.Decompressors = DefaultDecompressors
+	}
+
+	if config.RotatedGlob != "" {
+		if _, err := filepath.Match(config.RotatedGlob, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, err
@@ -94,41 +310,105 @@ func NewWatcher(config *Config, path string) (*Watcher, error) {
 	}, nil
 }
 
-// Close closes the watcher and any open file handles.
-func (w *Watcher) Close() error {
-	if w.file != nil {
-		_ = w.file.Close()
-		w.file = nil
+// Stop signals any running iterator (from [Watcher.Start] or
+// [Watcher.StartEvents]) to stop, as if its context was cancelled, after which
+// the iterator returns cleanly. Any future calls to [Watcher.Start] or
+// [Watcher.StartEvents] will return immediately without yielding anything.
+// [Watcher.Close] should still be called to release resources. Stop is safe to
+// call multiple times, and concurrently with a running iterator.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	if w.cancel != nil {
+		w.cancel()
 	}
-	if w.watcher != nil {
-		return w.watcher.Close()
-	}
-	return nil
+}
+
+// Close stops the watcher (see [Watcher.Stop]), and closes the watcher and any
+// open file handles. Close is idempotent, and subsequent calls return the same
+// error as the first call. Use [Watcher.Stop] (rather than Close) to stop an
+// iterator running in another goroutine.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() {
+		w.Stop()
+		if w.file != nil {
+			_ = w.file.Close()
+			w.file = nil
+		}
+		if w.watcher != nil {
+			w.closeErr = w.watcher.Close()
+		}
+	})
+	return w.closeErr
 }
 
 // Watch monitors a file and yields new lines as they are written. It returns an
 // iterator sequence that yields []byte chunks (as split by SplitFunc) and error
-// values.
+// values. See [WatchEvents] if you need to know when the file was truncated or
+// rotated.
 //
 // The function only returns errors for permission or access issues. It does not
 // return errors if the file doesn't exist or EOF is hit; instead, it waits for
-// the file to reappear or for new data.
+// the file to reappear or for new data. See [Config.OnError] to control whether
+// watching continues after an error.
 //
 // The function gracefully handles:
 //   - File moved/renamed: waits for file to reappear at original path.
 //   - File deleted: waits for file to reappear.
 //   - File truncated: resets read position to beginning.
 func Watch(ctx context.Context, config *Config, path string) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for event, err := range WatchEvents(ctx, config, path) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			if event.Type == EventLine && !yield(event.Line, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WatchEvents is the same as [Watch], however it yields [Event]s, which in
+// addition to lines ([EventLine]), also notify when the file was truncated
+// ([EventTruncated]), moved/deleted ([EventRotated]), and when it reappeared
+// ([EventReopened]). This is useful to reset any per-file state.
+func WatchEvents(ctx context.Context, config *Config, path string) iter.Seq2[Event, error] {
 	w, err := NewWatcher(config, path)
 	if err != nil {
-		return func(yield func([]byte, error) bool) {
-			yield(nil, err)
+		return func(yield func(Event, error) bool) {
+			yield(Event{}, err)
 		}
 	}
-	return func(yield func([]byte, error) bool) {
+	return func(yield func(Event, error) bool) {
 		defer w.Close()
-		for line, err := range w.Start(ctx) {
-			if !yield(line, err) {
+		for event, err := range w.StartEvents(ctx) {
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// WatchLines is the same as [Watch], however each token is yielded with its line
+// number and byte offset (see [LineInfo]), e.g. for a searchable log viewer.
+func WatchLines(ctx context.Context, config *Config, path string) iter.Seq2[LineInfo, error] {
+	return func(yield func(LineInfo, error) bool) {
+		for event, err := range WatchEvents(ctx, config, path) {
+			if err != nil {
+				if !yield(LineInfo{}, err) {
+					return
+				}
+				continue
+			}
+			if event.Type != EventLine {
+				continue
+			}
+			if !yield(LineInfo{Number: event.Number, Offset: event.Offset, Data: event.Line}, nil) {
 				return
 			}
 		}
@@ -136,24 +416,66 @@ func Watch(ctx context.Context, config *Config, path string) iter.Seq2[[]byte, e
 }
 
 // Start begins monitoring the file and returns an iterator sequence. It yields
-// []byte chunks (as split by SplitFunc) and error values.
+// []byte chunks (as split by SplitFunc) and error values. See [Watcher.StartEvents]
+// if you need to know when the file was truncated or rotated.
 //
 // The function only returns errors for permission or access issues. It does not
 // return errors if the file doesn't exist or EOF is hit; instead, it waits for
-// the file to reappear or for new data.
+// the file to reappear or for new data. See [Config.OnError] to control whether
+// watching continues after an error.
 //
 // The function gracefully handles:
 //   - File moved/renamed: waits for file to reappear at original path.
 //   - File deleted: waits for file to reappear.
 //   - File truncated: resets read position to beginning.
-func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint:gocognit
+func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] {
 	return func(yield func([]byte, error) bool) {
-		// Try to open file initially.
-		err := w.openFile(ctx)
-		if err != nil {
-			if !yield(nil, err) {
+		for event, err := range w.StartEvents(ctx) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			if event.Type == EventLine && !yield(event.Line, nil) {
 				return
 			}
+		}
+	}
+}
+
+// StartEvents is the same as [Watcher.Start], however it yields [Event]s, which
+// in addition to lines ([EventLine]), also notify when the file was truncated
+// ([EventTruncated]), moved/deleted ([EventRotated]), and when it reappeared
+// ([EventReopened]).
+func (w *Watcher) StartEvents(ctx context.Context) iter.Seq2[Event, error] { //nolint:gocognit
+	return func(yield func(Event, error) bool) {
+		w.mu.Lock()
+		if w.stopped {
+			w.mu.Unlock()
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		w.cancel = cancel
+		w.mu.Unlock()
+		defer cancel()
+
+		readHistory := w.config.ReadFromStart && w.config.RotatedGlob != ""
+		if readHistory && !w.readRotated(ctx, yield) {
+			return
+		}
+
+		// Try to open file initially.
+		if !w.tryOpenFile(ctx, ActionStop, yield) {
+			return
+		}
+
+		if (readHistory || w.fromStart) && w.file != nil && !w.readInitialData(ctx, yield) {
+			return
+		}
+
+		if !readHistory && !w.fromStart && w.config.TailLines > 0 && w.file != nil && !w.readLastLines(ctx, yield) {
 			return
 		}
 
@@ -168,6 +490,13 @@ func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint
 		var ok bool
 
 		for {
+			// If the last batch didn't read all available data, continue reading,
+			// though still give cancellation and other events a chance first.
+			var pending <-chan struct{}
+			if w.pending && w.file != nil {
+				pending = closedChan
+			}
+
 			select {
 			case <-ctx.Done():
 				if w.file != nil {
@@ -175,6 +504,10 @@ func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint
 					w.file = nil
 				}
 				return
+			case <-pending:
+				if !w.handleWriteEvent(ctx, fsnotify.Event{}, yield) {
+					return
+				}
 			case event, ok = <-w.watcher.Events:
 				if !ok {
 					if w.file != nil {
@@ -185,7 +518,7 @@ func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint
 				}
 
 				// Only process events for our target file.
-				if filepath.Base(event.Name) != filepath.Base(w.path) {
+				if !w.isWatchedEvent(event) {
 					continue
 				}
 
@@ -207,7 +540,7 @@ func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint
 						return
 					}
 				}
-			case err, ok = <-w.watcher.Errors:
+			case err, ok := <-w.watcher.Errors:
 				if !ok {
 					if w.file != nil {
 						_ = w.file.Close()
@@ -222,6 +555,99 @@ func (w *Watcher) Start(ctx context.Context) iter.Seq2[[]byte, error] { //nolint
 	}
 }
 
+// readRotated reads all rotated files matching [Config.RotatedGlob], oldest
+// first. Returns false if iteration should stop.
+func (w *Watcher) readRotated(ctx context.Context, yield func(Event, error) bool) bool {
+	matches, err := filepath.Glob(w.config.RotatedGlob)
+	if err != nil {
+		return w.handleError(err, ActionContinue, yield) != ActionStop
+	}
+
+	type rotatedFile struct {
+		path    string
+		modTime time.Time
+	}
+
+	files := make([]rotatedFile, 0, len(matches))
+	for _, path := range matches {
+		path, err = filepath.Abs(path)
+		if err != nil || path == w.path {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, rotatedFile{path: path, modTime: info.ModTime()})
+	}
+
+	slices.SortStableFunc(files, func(a, b rotatedFile) int {
+		if c := a.modTime.Compare(b.modTime); c != 0 {
+			return c
+		}
+		// Same modification time, assume "app.log.2" is older than "app.log.1".
+		return strings.Compare(b.path, a.path)
+	})
+
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		w.config.Logger.DebugContext(ctx, "reading rotated file", "path", file.path)
+		if err = w.readFile(file.path, yield); err != nil {
+			if errors.Is(err, errStopIteration) || w.handleError(err, ActionContinue, yield) == ActionStop {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// errStopIteration is returned by [Watcher.readFile] when yield returns false.
+var errStopIteration = errors.New("stop iteration")
+
+// closedChan is a closed channel, which is always ready to receive from.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// readFile reads all tokens from the file at path, decompressing it based on its
+// extension (see [Config.Decompressors]).
+func (w *Watcher) readFile(path string, yield func(Event, error) bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if decompress, ok := w.config.Decompressors[filepath.Ext(path)]; ok {
+		r, err = decompress(f)
+		if err != nil {
+			return fmt.Errorf("decompress %s: %w", path, err)
+		}
+		if rc, ok := r.(io.Closer); ok {
+			defer rc.Close()
+		}
+	}
+
+	scanner := w.newScanner(r, 0)
+	for scanner.Scan() {
+		if !w.yieldToken(scanner.Bytes(), yield) {
+			return errStopIteration
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	return nil
+}
+
 // openFile opens and positions the file at the end.
 func (w *Watcher) openFile(ctx context.Context) error {
 	if w.file != nil {
@@ -230,7 +656,17 @@ func (w *Watcher) openFile(ctx context.Context) error {
 		w.scanner = nil
 	}
 
-	f, err := os.Open(w.path)
+	path := w.path
+	if w.config.FollowSymlinks {
+		// If resolving fails (e.g. a dangling symlink), opening the path below
+		// fails the same way.
+		if target, err := filepath.EvalSymlinks(w.path); err == nil {
+			path = target
+			w.setTarget(ctx, target)
+		}
+	}
+
+	f, err := os.Open(path)
 	if err != nil {
 		// Check if it's a permission/access error.
 		if errors.Is(err, os.ErrPermission) {
@@ -262,16 +698,126 @@ func (w *Watcher) openFile(ctx context.Context) error {
 
 	w.file = f
 	w.filePos = pos
-	w.scanner = bufio.NewScanner(w.file)
-	w.scanner.Split(w.config.SplitFunc)
+	w.scanner = w.newScanner(w.file, pos)
 
 	w.config.Logger.DebugContext(ctx, "opened file", "path", w.path, "position", pos)
 
 	return nil
 }
 
+// tryOpenFile opens the file (see [Watcher.openFile]), handling any error as
+// decided by [Config.OnError], or def if it isn't set. Returns false if
+// iteration should stop.
+func (w *Watcher) tryOpenFile(ctx context.Context, def ErrorAction, yield func(Event, error) bool) bool {
+	for {
+		err := w.openFile(ctx)
+		if err == nil {
+			return true
+		}
+
+		switch w.handleError(err, def, yield) {
+		case ActionStop:
+			return false
+		case ActionRetry:
+			w.config.Logger.DebugContext(ctx, "retrying open", "path", w.path, "error", err)
+			if !w.sleep(ctx) {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
+
+// handleReadError handles an error reading the open file, as decided by
+// [Config.OnError] (defaulting to [ActionContinue]). If the read should be
+// retried, it waits [Config.RecheckDelay], and marks more data as pending, so it's
+// read again from [Watcher.filePos]. Returns false if iteration should stop.
+func (w *Watcher) handleReadError(ctx context.Context, err error, yield func(Event, error) bool) bool {
+	switch w.handleError(err, ActionContinue, yield) {
+	case ActionStop:
+		return false
+	case ActionRetry:
+		w.config.Logger.DebugContext(ctx, "retrying read", "path", w.path, "error", err)
+		if !w.sleep(ctx) {
+			return false
+		}
+		w.pending = true
+	}
+	return true
+}
+
+// handleError yields err, and returns the action to take, as decided by
+// [Config.OnError], or def if it isn't set. Returns [ActionStop] if the consumer
+// stopped iterating.
+func (w *Watcher) handleError(err error, def ErrorAction, yield func(Event, error) bool) ErrorAction {
+	action := def
+	if w.config.OnError != nil {
+		action = w.config.OnError(err)
+	}
+	if !yield(Event{}, err) {
+		return ActionStop
+	}
+	return action
+}
+
+// sleep waits [Config.RecheckDelay]. Returns false if the context was canceled
+// first.
+func (w *Watcher) sleep(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(w.config.RecheckDelay):
+		return true
+	}
+}
+
+// setTarget updates the resolved symlink target (see [Config.FollowSymlinks]),
+// watching the directory of the new target, if it differs from the directory of
+// the watched path.
+func (w *Watcher) setTarget(ctx context.Context, target string) {
+	if target == w.target {
+		return
+	}
+
+	dir := filepath.Dir(w.path)
+	oldDir := filepath.Dir(w.target)
+	newDir := filepath.Dir(target)
+
+	if w.target != "" && oldDir != dir && oldDir != newDir {
+		_ = w.watcher.Remove(oldDir)
+	}
+	if newDir != dir && (w.target == "" || newDir != oldDir) {
+		if err := w.watcher.Add(newDir); err != nil {
+			w.config.Logger.DebugContext(ctx, "failed to watch symlink target directory", "path", newDir, "error", err)
+		}
+	}
+
+	w.config.Logger.DebugContext(ctx, "following symlink", "path", w.path, "target", target)
+	w.target = target
+}
+
+// isWatchedEvent returns true if the event is for the watched path, or the
+// resolved symlink target (see [Config.FollowSymlinks]).
+func (w *Watcher) isWatchedEvent(event fsnotify.Event) bool {
+	if filepath.Base(event.Name) == filepath.Base(w.path) {
+		return true
+	}
+	return w.target != "" && filepath.Base(event.Name) == filepath.Base(w.target)
+}
+
+// symlinkChanged returns true if the watched path is a symlink which now points
+// to a different target than the one which is open (see [Config.FollowSymlinks]).
+func (w *Watcher) symlinkChanged() bool {
+	if !w.config.FollowSymlinks || w.target == "" {
+		return false
+	}
+	target, err := filepath.EvalSymlinks(w.path)
+	return err == nil && target != w.target
+}
+
 // waitForFile waits for the file to appear if it doesn't exist initially.
-func (w *Watcher) waitForFile(ctx context.Context, yield func([]byte, error) bool) bool {
+func (w *Watcher) waitForFile(ctx context.Context, yield func(Event, error) bool) bool {
 	w.config.Logger.DebugContext(ctx, "file does not exist, waiting", "path", w.path)
 
 	w.fileJustCreated = true // File doesn't exist, so when it's created, it's "just created"
@@ -282,11 +828,7 @@ func (w *Watcher) waitForFile(ctx context.Context, yield func([]byte, error) boo
 		case <-ctx.Done():
 			return false
 		case <-time.After(w.config.RecheckDelay):
-			err := w.openFile(ctx)
-			if err != nil {
-				if !yield(nil, err) {
-					return false
-				}
+			if !w.tryOpenFile(ctx, ActionStop, yield) {
 				return false
 			}
 		case event, ok := <-w.watcher.Events:
@@ -295,11 +837,7 @@ func (w *Watcher) waitForFile(ctx context.Context, yield func([]byte, error) boo
 			}
 			// Only process events for our target file.
 			if filepath.Base(event.Name) == filepath.Base(w.path) && event.Has(fsnotify.Create) {
-				err := w.openFile(ctx)
-				if err != nil {
-					if !yield(nil, err) {
-						return false
-					}
+				if !w.tryOpenFile(ctx, ActionStop, yield) {
 					return false
 				}
 			}
@@ -314,7 +852,7 @@ func (w *Watcher) waitForFile(ctx context.Context, yield func([]byte, error) boo
 }
 
 // handleWriteEvent handles file write events.
-func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield func([]byte, error) bool) bool {
+func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield func(Event, error) bool) bool {
 	// File was written to.
 	wasNil := w.file == nil
 
@@ -330,14 +868,16 @@ func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield
 
 	if w.file == nil {
 		// File was created or reappeared.
-		err := w.openFile(ctx)
-		if err != nil {
-			return yield(nil, err)
+		if !w.tryOpenFile(ctx, ActionContinue, yield) {
+			return false
 		}
 		if w.file == nil {
 			// Still doesn't exist, wait.
 			return true
 		}
+		if !w.notifyReopened(yield) {
+			return false
+		}
 		// After opening and seeking to end, check if there's data that was written
 		// during creation (before we opened it). Only read from beginning if file
 		// was just created (didn't exist initially).
@@ -365,9 +905,9 @@ func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield
 			_ = w.file.Close()
 			w.file = nil
 			w.scanner = nil
-			return true
+			return w.notifyRotated(yield)
 		}
-		return yield(nil, err)
+		return w.handleReadError(ctx, err, yield)
 	}
 
 	// Check for truncation.
@@ -377,8 +917,7 @@ func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield
 
 	// Ensure scanner is set up.
 	if w.scanner == nil {
-		w.scanner = bufio.NewScanner(w.file)
-		w.scanner.Split(w.config.SplitFunc)
+		w.scanner = w.newScanner(w.file, w.filePos)
 	}
 
 	// Read all available new data.
@@ -386,7 +925,7 @@ func (w *Watcher) handleWriteEvent(ctx context.Context, _ fsnotify.Event, yield
 }
 
 // readInitialData reads initial data from a just-created file.
-func (w *Watcher) readInitialData(_ context.Context, yield func([]byte, error) bool) bool {
+func (w *Watcher) readInitialData(_ context.Context, yield func(Event, error) bool) bool {
 	info, err := w.file.Stat()
 	if err != nil || info.Size() == 0 {
 		return true
@@ -401,15 +940,11 @@ func (w *Watcher) readInitialData(_ context.Context, yield func([]byte, error) b
 	}
 
 	w.filePos = 0
-	w.scanner = bufio.NewScanner(w.file)
-	w.scanner.Split(w.config.SplitFunc)
+	w.scanner = w.newScanner(w.file, 0)
 
 	// Read all existing data.
 	for w.scanner.Scan() {
-		data := w.scanner.Bytes()
-		dataCopy := make([]byte, len(data))
-		copy(dataCopy, data)
-		if !yield(dataCopy, nil) {
+		if !w.yieldToken(w.scanner.Bytes(), yield) {
 			return false
 		}
 		w.filePos, _ = w.file.Seek(0, io.SeekCurrent)
@@ -417,14 +952,156 @@ func (w *Watcher) readInitialData(_ context.Context, yield func([]byte, error) b
 
 	// After reading, seek to end for future tailing.
 	w.filePos, _ = w.file.Seek(0, io.SeekEnd)
-	w.scanner = bufio.NewScanner(w.file)
-	w.scanner.Split(w.config.SplitFunc)
+	w.scanner = w.newScanner(w.file, w.filePos)
+
+	return true
+}
+
+// readLastLines reads the last [Config.TailLines] lines of the file, leaving the
+// file positioned at the end for future tailing.
+func (w *Watcher) readLastLines(ctx context.Context, yield func(Event, error) bool) bool {
+	offset, err := lastLinesOffset(w.file, w.filePos, w.config.TailLines)
+	if err != nil {
+		w.config.Logger.DebugContext(ctx, "failed to find last lines", "error", err)
+		return true
+	}
+
+	if _, err = w.file.Seek(offset, io.SeekStart); err != nil {
+		return true
+	}
+
+	w.scanner = w.newScanner(io.LimitReader(w.file, w.filePos-offset), offset)
+	for w.scanner.Scan() {
+		if !w.yieldToken(w.scanner.Bytes(), yield) {
+			return false
+		}
+	}
+	if err = w.scanner.Err(); err != nil {
+		// E.g. binary content without newlines, which is too large for a token.
+		w.config.Logger.DebugContext(ctx, "scanner error reading last lines", "error", err)
+	}
 
+	// Continue from where the last lines ended (the file may have grown since).
+	w.filePos, _ = w.file.Seek(w.filePos, io.SeekStart)
+	w.scanner = w.newScanner(w.file, w.filePos)
 	return true
 }
 
+// tailChunkSize is the size of the chunks read by [lastLinesOffset].
+const tailChunkSize = 4096
+
+// lastLinesOffset returns the offset of the start of the last n lines of r,
+// which is size bytes long, by scanning backwards from the end for newlines. A
+// trailing newline at the end doesn't start a new line. Returns 0 if there are
+// fewer than n lines.
+func lastLinesOffset(r io.ReaderAt, size int64, n int) (int64, error) {
+	buf := make([]byte, tailChunkSize)
+	end := size
+
+	// Ignore the trailing newline of the last line (if any).
+	if end > 0 {
+		if _, err := r.ReadAt(buf[:1], end-1); err != nil {
+			return 0, err
+		}
+		if buf[0] == '\n' {
+			end--
+		}
+	}
+
+	for end > 0 {
+		start := max(0, end-tailChunkSize)
+		chunk := buf[:end-start]
+		if _, err := r.ReadAt(chunk, start); err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' {
+				continue
+			}
+			n--
+			if n == 0 {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// yieldToken applies the configured [Config.LineFilter] (if any) to data, and
+// yields a copy of the result (as the scanner reuses its buffer). Skipped tokens
+// are not yielded, and return true.
+func (w *Watcher) yieldToken(data []byte, yield func(Event, error) bool) bool {
+	w.lineNumber++
+
+	if w.config.LineFilter != nil {
+		var ok bool
+		data, ok = w.config.LineFilter(data)
+		if !ok {
+			return true
+		}
+	}
+
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	return yield(Event{
+		Type:   EventLine,
+		Line:   dataCopy,
+		Number: w.lineNumber,
+		Offset: w.offsets.token,
+	}, nil)
+}
+
+// offsetTracker tracks the byte offsets of the tokens read by a scanner created
+// with [Watcher.newScanner].
+type offsetTracker struct {
+	base     int64 // Offset the scanner started reading from.
+	consumed int64 // Bytes consumed by the split function so far.
+	token    int64 // Offset of the start of the last token.
+}
+
+// newScanner returns a scanner reading from r (which is positioned at offset
+// base), using [Config.SplitFunc], and resets [Watcher.offsets] to track the
+// offsets of its tokens.
+func (w *Watcher) newScanner(r io.Reader, base int64) *bufio.Scanner {
+	w.offsets = offsetTracker{base: base, token: base}
+
+	split := w.config.SplitFunc
+	scanner := bufio.NewScanner(r)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		if token != nil {
+			w.offsets.token = w.offsets.base + w.offsets.consumed
+		}
+		w.offsets.consumed += int64(advance)
+		return advance, token, err
+	})
+	return scanner
+}
+
+// notifyRotated yields [EventRotated], unless it was already yielded since the
+// file was last (re)opened.
+func (w *Watcher) notifyRotated(yield func(Event, error) bool) bool {
+	if w.rotated {
+		return true
+	}
+	w.rotated = true
+	return yield(Event{Type: EventRotated}, nil)
+}
+
+// notifyReopened yields [EventReopened] if the file was reopened after
+// [EventRotated] was yielded.
+func (w *Watcher) notifyReopened(yield func(Event, error) bool) bool {
+	if !w.rotated || w.file == nil {
+		return true
+	}
+	w.rotated = false
+	return yield(Event{Type: EventReopened}, nil)
+}
+
 // checkTruncation checks if the file was truncated and handles it.
-func (w *Watcher) checkTruncation(ctx context.Context, info os.FileInfo, yield func([]byte, error) bool) bool {
+func (w *Watcher) checkTruncation(ctx context.Context, info os.FileInfo, yield func(Event, error) bool) bool {
 	// If file size is less than our position, it was truncated.
 	if info.Size() >= w.filePos {
 		return true
@@ -437,14 +1114,18 @@ func (w *Watcher) checkTruncation(ctx context.Context, info os.FileInfo, yield f
 		"new_size", info.Size(),
 	)
 
+	if !yield(Event{Type: EventTruncated}, nil) {
+		return false
+	}
+
 	// Reset to beginning.
 	_, err := w.file.Seek(0, io.SeekStart)
 	if err != nil {
-		return yield(nil, err)
+		return w.handleReadError(ctx, err, yield)
 	}
 	w.filePos = 0
-	w.scanner = bufio.NewScanner(w.file)
-	w.scanner.Split(w.config.SplitFunc)
+	w.lineNumber = 0
+	w.scanner = w.newScanner(w.file, 0)
 
 	if info.Size() == 0 {
 		return true
@@ -453,10 +1134,7 @@ func (w *Watcher) checkTruncation(ctx context.Context, info os.FileInfo, yield f
 	// After truncation, read all available data if configured to read from start.
 	if w.config.ReadFromStart {
 		for w.scanner.Scan() {
-			data := w.scanner.Bytes()
-			dataCopy := make([]byte, len(data))
-			copy(dataCopy, data)
-			if !yield(dataCopy, nil) {
+			if !w.yieldToken(w.scanner.Bytes(), yield) {
 				return false
 			}
 			w.filePos, _ = w.file.Seek(0, io.SeekCurrent)
@@ -465,119 +1143,68 @@ func (w *Watcher) checkTruncation(ctx context.Context, info os.FileInfo, yield f
 		err = w.scanner.Err()
 		if err != nil && !errors.Is(err, io.EOF) {
 			if errors.Is(err, os.ErrPermission) {
-				return yield(nil, err)
+				return w.handleReadError(ctx, err, yield)
 			}
 			w.config.Logger.DebugContext(ctx, "scanner error after truncation", "error", err)
 		}
 	} else {
 		// Not reading from start after truncation. Seek to end to only read new appends.
 		w.filePos, _ = w.file.Seek(0, io.SeekEnd)
-		w.scanner = bufio.NewScanner(w.file)
-		w.scanner.Split(w.config.SplitFunc)
+		w.scanner = w.newScanner(w.file, w.filePos)
 	}
 	return true
 }
 
-// readNewData reads all available new data from the file.
-func (w *Watcher) readNewData(ctx context.Context, yield func([]byte, error) bool) bool {
-	// Create a fresh scanner to pick up new data. The scanner maintains internal
-	// EOF state, so we need to recreate it when the file has grown.
-	w.scanner = bufio.NewScanner(w.file)
-	w.scanner.Split(w.config.SplitFunc)
-
-	// Read all available new data. Keep reading until we've consumed all new data.
-	maxIterations := 100 // Prevent infinite loops.
-	iteration := 0
-	readSomething := false
-	for iteration < maxIterations {
-		iteration++
-
-		// Check current position and file size.
-		currentPos, err := w.file.Seek(0, io.SeekCurrent)
-		if err != nil {
-			break
-		}
-
-		// Re-check file size in case more data was written.
-		newInfo, err := w.file.Stat()
-		if err != nil {
-			break
-		}
+// readNewData reads available new data from the file, up to [Config.BatchSize]
+// tokens. If more data is available, [Watcher.pending] is set, and the file is
+// positioned right after the last token read, so the next call resumes there.
+func (w *Watcher) readNewData(ctx context.Context, yield func(Event, error) bool) bool {
+	w.pending = false
 
-		// If we're at or past the end, check if we read anything.
-		if currentPos >= newInfo.Size() {
-			// Update filePos.
-			w.filePos = currentPos
-			// If we read something, we might have more in the scanner buffer. If
-			// we didn't read anything and we're at the end, we're done.
-			if !readSomething {
-				break
-			}
-			// Continue to try reading from scanner buffer.
-		}
-
-		// Try to scan a line/token.
-		if !w.scanner.Scan() {
-			if scanErr := w.scanner.Err(); scanErr != nil {
-				if errors.Is(scanErr, io.EOF) {
-					// EOF is expected when we've read all available data.
-					w.filePos, _ = w.file.Seek(0, io.SeekCurrent)
-					break
-				}
-				// Check if it's a permission/access error.
-				if errors.Is(scanErr, os.ErrPermission) {
-					if !yield(nil, scanErr) {
-						return false
-					}
-					break
-				}
-				// Other errors might be transient, log and continue.
-				w.config.Logger.DebugContext(ctx, "scanner error", "error", scanErr)
-				break
-			}
-			// No more data to read right now. Update position.
-			w.filePos, _ = w.file.Seek(0, io.SeekCurrent)
+	start, err := w.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return true
+	}
 
-			// Check if we're at or past EOF to avoid infinite loop.
-			checkPos, _ := w.file.Seek(0, io.SeekCurrent)
-			checkInfo, _ := w.file.Stat()
-			if checkInfo != nil && checkPos >= checkInfo.Size() {
-				// At EOF, no more data available.
-				break
-			}
+	// Create a fresh scanner to pick up new data. The scanner maintains internal
+	// EOF state, so we need to recreate it when the file has grown. The scanner
+	// reads ahead of the tokens it returns, so the offset tracker is used to know
+	// how much has actually been consumed, in case we stop early.
+	w.scanner = w.newScanner(w.file, start)
 
-			// If we read something, check file size again in case more was written.
-			if readSomething {
-				continue
-			}
-			break
+	for tokens := 1; w.scanner.Scan(); tokens++ {
+		if !w.yieldToken(w.scanner.Bytes(), yield) {
+			return false
 		}
 
-		readSomething = true
-
-		// Got a token, yield it.
-		data := w.scanner.Bytes()
-
-		// Make a copy since scanner reuses the buffer.
-		dataCopy := make([]byte, len(data))
-		copy(dataCopy, data)
-		if !yield(dataCopy, nil) {
-			return false
+		if tokens >= w.config.BatchSize {
+			// Rewind to right after the last token, so read-ahead data isn't lost,
+			// and continue from there on the next batch.
+			w.filePos, _ = w.file.Seek(w.offsets.base+w.offsets.consumed, io.SeekStart)
+			w.pending = true
+			return true
 		}
+	}
 
-		// Update position after reading.
-		newPos, err := w.file.Seek(0, io.SeekCurrent)
-		if err == nil {
-			w.filePos = newPos
-		} else {
-			break
+	if scanErr := w.scanner.Err(); scanErr != nil {
+		// Check if it's a permission/access error.
+		if errors.Is(scanErr, os.ErrPermission) {
+			// Rewind to right after the last token, so nothing is skipped if the
+			// read is retried.
+			w.filePos, _ = w.file.Seek(w.offsets.base+w.offsets.consumed, io.SeekStart)
+			return w.handleReadError(ctx, scanErr, yield)
 		}
+		// Other errors might be transient, log and continue.
+		w.config.Logger.DebugContext(ctx, "scanner error", "error", scanErr)
 	}
+
+	// No more data to read right now. Update position.
+	w.filePos, _ = w.file.Seek(0, io.SeekCurrent)
 	return true
 }
 
 // handleRemoveRenameEvent handles file remove/rename events.
-func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event, yield func([]byte, error) bool) bool {
+func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event, yield func(Event, error) bool) bool {
 	// File was removed or renamed.
 	if w.file != nil {
 		_ = w.file.Close()
@@ -587,6 +1214,10 @@ func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event,
 
 	w.config.Logger.DebugContext(ctx, "file removed/renamed, waiting for reappearance", "path", w.path)
 
+	if !w.notifyRotated(yield) {
+		return false
+	}
+
 	// Wait for file to reappear.
 	fileReappeared := false
 	for !fileReappeared {
@@ -594,11 +1225,7 @@ func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event,
 		case <-ctx.Done():
 			return false
 		case <-time.After(w.config.RecheckDelay):
-			err := w.openFile(ctx)
-			if err != nil {
-				if !yield(nil, err) {
-					return false
-				}
+			if !w.tryOpenFile(ctx, ActionStop, yield) {
 				return false
 			}
 			if w.file != nil {
@@ -606,6 +1233,9 @@ func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event,
 				w.config.Logger.DebugContext(ctx, "file reappeared", "path", w.path)
 				w.fileJustCreated = true
 				fileReappeared = true
+				if !w.notifyReopened(yield) {
+					return false
+				}
 			}
 		}
 	}
@@ -613,17 +1243,32 @@ func (w *Watcher) handleRemoveRenameEvent(ctx context.Context, _ fsnotify.Event,
 }
 
 // handleCreateEvent handles file create events.
-func (w *Watcher) handleCreateEvent(ctx context.Context, _ fsnotify.Event, yield func([]byte, error) bool) bool {
+func (w *Watcher) handleCreateEvent(ctx context.Context, _ fsnotify.Event, yield func(Event, error) bool) bool {
 	if w.file != nil {
-		return true
+		if !w.symlinkChanged() {
+			return true
+		}
+
+		// The symlink was re-pointed, so handle it like the file was moved and
+		// recreated.
+		w.config.Logger.DebugContext(ctx, "symlink target changed", "path", w.path, "target", w.target)
+		_ = w.file.Close()
+		w.file = nil
+		w.scanner = nil
+		w.fileJustCreated = true
+		if !w.notifyRotated(yield) {
+			return false
+		}
 	}
-	err := w.openFile(ctx)
-	if err != nil {
-		return yield(nil, err)
+	if !w.tryOpenFile(ctx, ActionContinue, yield) {
+		return false
 	}
 	if w.file == nil {
 		return true
 	}
+	if !w.notifyReopened(yield) {
+		return false
+	}
 
 	// After opening and seeking to end, check if there's data. If file was
 	// created with content, we're at the end, so no data to read But if data
@@ -7,12 +7,16 @@ package tail
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -733,3 +737,751 @@ func TestWatch_ReadFromStartFalse_Truncation(t *testing.T) {
 		t.Fatalf("timeout waiting for lines, received: %v", receivedLines)
 	}
 }
+
+func TestWatch_LineFilter(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "test.log")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay: 50 * time.Millisecond,
+		LineFilter: func(line []byte) ([]byte, bool) {
+			v, ok := bytes.CutPrefix(line, []byte("ERROR "))
+			return v, ok
+		},
+	}
+
+	done := make(chan bool)
+	var receivedLines []string
+
+	go func() {
+		for line, err := range Watch(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			receivedLines = append(receivedLines, string(line))
+			if len(receivedLines) >= 2 {
+				done <- true
+				return
+			}
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open file for writing: %v", err)
+	}
+
+	_, _ = file.WriteString("INFO skipped\nERROR first\nDEBUG skipped\n")
+	_ = file.Sync()
+	time.Sleep(100 * time.Millisecond)
+
+	_, _ = file.WriteString("ERROR second\n")
+	_ = file.Sync()
+	file.Close()
+
+	select {
+	case <-done:
+		if len(receivedLines) != 2 || receivedLines[0] != "first" || receivedLines[1] != "second" {
+			t.Errorf("expected [first second], got %v", receivedLines)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for lines")
+	}
+}
+
+func TestWatch_BatchSize(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "test.log")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay: 50 * time.Millisecond,
+		BatchSize:    3,
+	}
+
+	var want []string
+	for i := range 50 {
+		want = append(want, fmt.Sprintf("line %d", i))
+	}
+
+	done := make(chan bool)
+	var receivedLines []string
+
+	go func() {
+		for line, err := range Watch(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			receivedLines = append(receivedLines, string(line))
+			if len(receivedLines) >= len(want) {
+				done <- true
+				return
+			}
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Single write, so all lines must be read without further write events.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open file for writing: %v", err)
+	}
+	_, _ = file.WriteString(strings.Join(want, "\n") + "\n")
+	file.Close()
+
+	select {
+	case <-done:
+		if !slices.Equal(receivedLines, want) {
+			t.Errorf("expected %v, got %v", want, receivedLines)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting for lines, got %d of %d", len(receivedLines), len(want))
+	}
+}
+
+func TestWatchEvents_RotationAndTruncation(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "test.log")
+
+	err := os.WriteFile(path, []byte("initial\n"), 0o644)
+	if err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{RecheckDelay: 50 * time.Millisecond}
+
+	events := make(chan Event, 100)
+	done := make(chan bool)
+
+	go func() {
+		for event, err := range WatchEvents(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				break
+			}
+			events <- event
+		}
+		done <- true
+	}()
+
+	waitFor := func(want EventType) Event {
+		t.Helper()
+		for {
+			select {
+			case event := <-events:
+				if event.Type == want {
+					return event
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("timeout waiting for %v event", want)
+			}
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Rotate.
+	if err = os.Rename(path, filepath.Join(tmpdir, "test.log.1")); err != nil {
+		t.Fatalf("failed to move file: %v", err)
+	}
+	waitFor(EventRotated)
+
+	if err = os.WriteFile(path, []byte("a much longer line after rotation\n"), 0o644); err != nil {
+		t.Fatalf("failed to recreate file: %v", err)
+	}
+	waitFor(EventReopened)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open file for writing: %v", err)
+	}
+	_, _ = file.WriteString("appended\n")
+	_ = file.Sync()
+	file.Close()
+
+	if event := waitFor(EventLine); string(event.Line) != "appended" {
+		t.Errorf("expected 'appended', got %q", event.Line)
+	}
+
+	// Truncate.
+	time.Sleep(100 * time.Millisecond)
+	if err = os.WriteFile(path, []byte("short\n"), 0o644); err != nil {
+		t.Fatalf("failed to truncate file: %v", err)
+	}
+	waitFor(EventTruncated)
+
+	cancel()
+	<-done
+}
+
+func TestWatchLines(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "test.log")
+
+	if err := os.WriteFile(path+".1", []byte("r1\nr2\n"), 0o644); err != nil {
+		t.Fatalf("failed to create rotated file: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("a\nbb\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay:  50 * time.Millisecond,
+		ReadFromStart: true,
+		RotatedGlob:   path + ".*",
+	}
+
+	lines := make(chan LineInfo, 100)
+	done := make(chan bool)
+
+	go func() {
+		for line, err := range WatchLines(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				break
+			}
+			lines <- line
+		}
+		done <- true
+	}()
+
+	expect := func(want ...LineInfo) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case got := <-lines:
+				if got.Number != w.Number || got.Offset != w.Offset || string(got.Data) != string(w.Data) {
+					t.Fatalf("got line %d at %d (%q), want line %d at %d (%q)",
+						got.Number, got.Offset, got.Data, w.Number, w.Offset, w.Data)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("timeout waiting for line %d (%q)", w.Number, w.Data)
+			}
+		}
+	}
+
+	// Numbering continues from the rotated file into the watched file.
+	expect(
+		LineInfo{Number: 1, Offset: 0, Data: []byte("r1")},
+		LineInfo{Number: 2, Offset: 3, Data: []byte("r2")},
+		LineInfo{Number: 3, Offset: 0, Data: []byte("a")},
+		LineInfo{Number: 4, Offset: 2, Data: []byte("bb")},
+	)
+
+	time.Sleep(100 * time.Millisecond)
+	appendFile(t, path, "ccc\ndddd\n")
+	expect(
+		LineInfo{Number: 5, Offset: 5, Data: []byte("ccc")},
+		LineInfo{Number: 6, Offset: 9, Data: []byte("dddd")},
+	)
+
+	// Truncation resets numbering.
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("x\nyy\n"), 0o644); err != nil {
+		t.Fatalf("failed to truncate file: %v", err)
+	}
+	expect(
+		LineInfo{Number: 1, Offset: 0, Data: []byte("x")},
+		LineInfo{Number: 2, Offset: 2, Data: []byte("yy")},
+	)
+
+	cancel()
+	<-done
+}
+
+func TestWatch_RotatedGlob(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "app.log")
+
+	writeGzip := func(name, data string) {
+		t.Helper()
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(data))
+		_ = zw.Close()
+		if err := os.WriteFile(filepath.Join(tmpdir, name), buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to create rotated file: %v", err)
+		}
+	}
+
+	writeGzip("app.log.3.gz", "one\ntwo\n")
+	if err := os.WriteFile(filepath.Join(tmpdir, "app.log.2"), []byte("three\n"), 0o644); err != nil {
+		t.Fatalf("failed to create rotated file: %v", err)
+	}
+	// Generated with: printf 'four\n' | zstd -c > testdata/rotated.log.zst
+	zst, err := os.ReadFile("testdata/rotated.log.zst")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(tmpdir, "app.log.1.zst"), zst, 0o644); err != nil {
+		t.Fatalf("failed to create rotated file: %v", err)
+	}
+	if err = os.WriteFile(path, []byte("five\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	// Rotated files are read by modification time, oldest first.
+	now := time.Now()
+	for i, name := range []string{"app.log.3.gz", "app.log.2", "app.log.1.zst", "app.log"} {
+		mtime := now.Add(time.Duration(i-4) * time.Hour)
+		if err := os.Chtimes(filepath.Join(tmpdir, name), mtime, mtime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		ReadFromStart: true,
+		RotatedGlob:   filepath.Join(tmpdir, "app.log.*"),
+		RecheckDelay:  50 * time.Millisecond,
+	}
+
+	want := []string{"one", "two", "three", "four", "five", "six"}
+
+	var lines []string
+	for line, err := range Watch(ctx, config, path) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, string(line))
+
+		if len(lines) == 5 {
+			file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			_, _ = file.WriteString("six\n")
+			file.Close()
+		}
+		if len(lines) == len(want) {
+			break
+		}
+	}
+
+	if strings.Join(lines, ",") != strings.Join(want, ",") {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestWatch_RotatedGlobInvalid(t *testing.T) {
+	_, err := NewWatcher(&Config{RotatedGlob: "["}, filepath.Join(t.TempDir(), "app.log"))
+	if err == nil {
+		t.Fatal("expected error for invalid glob")
+	}
+}
+
+func TestWatcher_CloseIdempotent(t *testing.T) {
+	w, err := NewWatcher(nil, filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatalf("first close: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+}
+
+func TestWatcher_StopThenIterate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	if err := os.WriteFile(path, []byte("line1\nline2\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	w, err := NewWatcher(&Config{ReadFromStart: true}, path)
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	defer w.Close()
+
+	w.Stop()
+	w.Stop()
+
+	for line, err := range w.Start(context.Background()) {
+		t.Fatalf("unexpected yield from stopped watcher: %q, %v", line, err)
+	}
+}
+
+func TestWatcher_StopDuringIteration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	w, err := NewWatcher(&Config{RecheckDelay: 50 * time.Millisecond}, path)
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for line, err := range w.Start(ctx) {
+			t.Errorf("unexpected yield: %q, %v", line, err)
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	w.Stop()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("iterator did not return after Stop")
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	for typ, want := range map[EventType]string{
+		EventLine:      "line",
+		EventTruncated: "truncated",
+		EventRotated:   "rotated",
+		EventReopened:  "reopened",
+		EventType(99):  "unknown",
+	} {
+		if got := typ.String(); got != want {
+			t.Errorf("EventType(%d).String() = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestWatch_TailLines(t *testing.T) {
+	tmpdir := t.TempDir()
+	path := filepath.Join(tmpdir, "test.log")
+
+	if err := os.WriteFile(path, []byte("line1\nline2\nline3\nline4\nline5\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay: 50 * time.Millisecond,
+		TailLines:    2,
+	}
+
+	lines := make(chan string, 100)
+	done := make(chan bool)
+
+	go func() {
+		for line, err := range Watch(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				break
+			}
+			lines <- string(line)
+		}
+		done <- true
+	}()
+
+	expect := func(want ...string) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case got := <-lines:
+				if got != w {
+					t.Fatalf("got line %q, want %q", got, w)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("timeout waiting for line %q", w)
+			}
+		}
+	}
+
+	expect("line4", "line5")
+
+	time.Sleep(100 * time.Millisecond)
+	appendFile(t, path, "line6\n")
+	expect("line6")
+
+	cancel()
+	<-done
+
+	select {
+	case line := <-lines:
+		t.Fatalf("unexpected line %q", line)
+	default:
+	}
+}
+
+func TestLastLinesOffset(t *testing.T) {
+	long := strings.Repeat("x", tailChunkSize+10)
+
+	tests := []struct {
+		name    string
+		content string
+		n       int
+		want    int64
+	}{
+		{name: "empty", content: "", n: 3, want: 0},
+		{name: "fewer-lines", content: "a\nb\n", n: 3, want: 0},
+		{name: "exact-lines", content: "a\nb\nc\n", n: 3, want: 0},
+		{name: "more-lines", content: "a\nb\nc\n", n: 2, want: 2},
+		{name: "no-trailing-newline", content: "a\nb\nc", n: 2, want: 2},
+		{name: "no-newlines", content: "binary\x00data", n: 1, want: 0},
+		{name: "only-newline", content: "\n", n: 1, want: 0},
+		{name: "across-chunks", content: "a\n" + long + "\nb\n", n: 2, want: 2},
+		{name: "last-line", content: "a\n" + long + "\nb\n", n: 1, want: int64(len(long)) + 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lastLinesOffset(strings.NewReader(tt.content), int64(len(tt.content)), tt.n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("lastLinesOffset() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatch_FollowSymlinks(t *testing.T) {
+	tmpdir := t.TempDir()
+	releases := filepath.Join(tmpdir, "releases")
+	if err := os.Mkdir(releases, 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	oldTarget := filepath.Join(releases, "app-1.log")
+	newTarget := filepath.Join(releases, "app-2.log")
+	path := filepath.Join(tmpdir, "current.log")
+
+	if err := os.WriteFile(oldTarget, []byte("old1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if err := os.Symlink(oldTarget, path); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay:   50 * time.Millisecond,
+		ReadFromStart:  true,
+		FollowSymlinks: true,
+	}
+
+	lines := make(chan string, 100)
+	done := make(chan bool)
+
+	go func() {
+		for line, err := range Watch(ctx, config, path) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				break
+			}
+			lines <- string(line)
+		}
+		done <- true
+	}()
+
+	expect := func(want ...string) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case got := <-lines:
+				if got != w {
+					t.Fatalf("got line %q, want %q", got, w)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("timeout waiting for line %q", w)
+			}
+		}
+	}
+
+	// Writes to the target (in another directory) are followed.
+	time.Sleep(100 * time.Millisecond)
+	appendFile(t, oldTarget, "old2\n")
+	expect("old2")
+
+	// Atomically re-point the symlink to the new target.
+	if err := os.WriteFile(newTarget, []byte("new1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create new target: %v", err)
+	}
+	tmpLink := filepath.Join(tmpdir, "current.log.tmp")
+	if err := os.Symlink(newTarget, tmpLink); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	if err := os.Rename(tmpLink, path); err != nil {
+		t.Fatalf("failed to replace symlink: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	appendFile(t, newTarget, "new2\n")
+	expect("new1", "new2")
+
+	// The old target is no longer followed.
+	appendFile(t, oldTarget, "old3\n")
+	time.Sleep(100 * time.Millisecond)
+	appendFile(t, newTarget, "new3\n")
+	expect("new3")
+
+	cancel()
+	<-done
+}
+
+func TestErrorAction_String(t *testing.T) {
+	for action, want := range map[ErrorAction]string{
+		ActionContinue:  "continue",
+		ActionStop:      "stop",
+		ActionRetry:     "retry",
+		ErrorAction(99): "unknown",
+	} {
+		if got := action.String(); got != want {
+			t.Errorf("ErrorAction(%d).String() = %q, want %q", action, got, want)
+		}
+	}
+}
+
+// unopenablePath returns a path which exists, but fails to open with an error
+// other than [os.ErrNotExist] (as permission errors can't be tested as root).
+func unopenablePath(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.log")
+	if err := os.Symlink(path, path); err != nil {
+		t.Fatalf("failed to create symlink loop: %v", err)
+	}
+	return path
+}
+
+func TestWatch_OnErrorDefaultStop(t *testing.T) {
+	path := unopenablePath(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var errs int
+	for _, err := range Watch(ctx, &Config{RecheckDelay: 50 * time.Millisecond}, path) {
+		if err == nil {
+			t.Fatal("expected only errors")
+		}
+		errs++
+	}
+
+	if errs != 1 {
+		t.Fatalf("expected 1 error before stopping, got %d", errs)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("expected iteration to stop before the context was canceled")
+	}
+}
+
+func TestWatch_OnErrorRetry(t *testing.T) {
+	path := unopenablePath(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var calls int
+	fixed := make(chan struct{})
+	config := &Config{
+		RecheckDelay:  50 * time.Millisecond,
+		ReadFromStart: true,
+		OnError: func(err error) ErrorAction {
+			calls++
+			if calls == 3 {
+				// Fix the file, so the next attempt succeeds.
+				if err := os.Remove(path); err != nil {
+					t.Errorf("failed to remove symlink: %v", err)
+				}
+				if err := os.WriteFile(path, nil, 0o644); err != nil {
+					t.Errorf("failed to create file: %v", err)
+				}
+				close(fixed)
+			}
+			return ActionRetry
+		},
+	}
+
+	go func() {
+		<-fixed
+		time.Sleep(200 * time.Millisecond)
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Errorf("failed to open file for writing: %v", err)
+			return
+		}
+		_, _ = file.WriteString("line1\n")
+		file.Close()
+	}()
+
+	var errs int
+	var lines []string
+	for line, err := range Watch(ctx, config, path) {
+		if err != nil {
+			errs++
+			continue
+		}
+		lines = append(lines, string(line))
+		break
+	}
+
+	if errs != 3 || calls != 3 {
+		t.Fatalf("expected 3 errors and OnError calls, got %d and %d", errs, calls)
+	}
+	if !slices.Equal(lines, []string{"line1"}) {
+		t.Fatalf("expected [line1], got %v", lines)
+	}
+}
+
+func TestWatch_OnErrorConsumerStops(t *testing.T) {
+	path := unopenablePath(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &Config{
+		RecheckDelay: 50 * time.Millisecond,
+		OnError:      func(error) ErrorAction { return ActionRetry },
+	}
+
+	for _, err := range Watch(ctx, config, path) {
+		if err == nil {
+			t.Fatal("expected only errors")
+		}
+		break
+	}
+}